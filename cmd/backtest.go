@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/buggy-95/lott/internal/lottery/backtest"
+)
+
+func runBacktest(args []string) error {
+	fs := flag.NewFlagSet("backtest", flag.ExitOnError)
+	storePath := fs.String("store", "", "历史文件路径，默认为 <彩票类型>_history.json")
+	lotteryType := fs.String("type", "DLT", "彩票类型")
+	ticketsPath := fs.String("tickets", "", "彩票文件，每行一张彩票")
+	from := fs.Int("from", 0, "起始期号")
+	to := fs.Int("to", 0, "结束期号")
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	fs.Parse(args)
+
+	strategy, err := backtest.NewFixedStrategy(fs.Args())
+	if err != nil {
+		return err
+	}
+
+	if *ticketsPath != "" {
+		tickets, err := readTickets(*ticketsPath)
+		if err != nil {
+			return err
+		}
+
+		strategy = append(strategy, tickets...)
+	}
+
+	if len(strategy) == 0 {
+		return errors.New("没有需要回测的彩票")
+	}
+
+	for _, ticket := range strategy {
+		if ticket.Type != *lotteryType {
+			return fmt.Errorf("彩票类型 %s 与开奖历史的类型 %s 不一致: %s", ticket.Type, *lotteryType, ticket.String())
+		}
+	}
+
+	draws, err := loadDraws(getStorePath(*storePath, *lotteryType), *lotteryType)
+	if err != nil {
+		return err
+	}
+
+	report, err := backtest.Run(draws, strategy, backtest.Options{From: *from, To: *to})
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(report)
+	}
+
+	report.Print(os.Stdout)
+
+	return nil
+}
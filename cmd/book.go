@@ -0,0 +1,182 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/buggy-95/lott/internal/lottery/book"
+)
+
+// 购彩记录本的子命令用法
+const bookUsage = `用法: lott book <子命令> [-book ticket_book.json] [参数]
+  add    添加购彩记录: add [-date 2025-05-12] [-channel 渠道] [-from 期号] [-to 期号] [-cost 金额] [-owner 购买人] [-note 备注] DLT:01,02,03,04,05-01,02:25053
+  list   查看购彩记录: list [-owner 购买人] [-unclaimed]
+  rm     删除购彩记录: rm 编号
+  check  用历史开奖数据核对购彩记录: check [-store dlt_history.json]
+  claim  标记已兑奖: claim [-date 2025-05-14] 编号 期号`
+
+// getIDs
+//
+// @Description 解析子命令的数字参数，例如编号和期号
+//
+// @Param args []string 参数列表
+//
+// @Param names ...string 参数名称
+//
+// @Return []int 参数值
+//
+// @Return error 错误信息
+func getIDs(args []string, names ...string) ([]int, error) {
+	if len(args) != len(names) {
+		return nil, fmt.Errorf("需要参数: %v", names)
+	}
+
+	result := make([]int, len(args))
+
+	for i, arg := range args {
+		value, err := strconv.Atoi(arg)
+		if err != nil {
+			return nil, fmt.Errorf("%s解析失败: %s", names[i], arg)
+		}
+
+		result[i] = value
+	}
+
+	return result, nil
+}
+
+func runBook(args []string) error {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, bookUsage)
+		return errors.New("缺少子命令")
+	}
+
+	today := time.Now().Format("2006-01-02")
+
+	fs := flag.NewFlagSet("book "+args[0], flag.ExitOnError)
+	bookPath := fs.String("book", "ticket_book.json", "购彩记录文件路径")
+
+	var run func(b *book.Book) (bool, error)
+
+	switch args[0] {
+	case "add":
+		var entry book.Entry
+
+		fs.StringVar(&entry.Date, "date", today, "购买日期")
+		fs.StringVar(&entry.Channel, "channel", "", "购买渠道")
+		fs.IntVar(&entry.From, "from", 0, "起始期号，为0时使用彩票中的期号")
+		fs.IntVar(&entry.To, "to", 0, "结束期号，为0时与起始期号相同")
+		fs.IntVar(&entry.Cost, "cost", 0, "投注金额，为0时按注数、倍数和期数计算")
+		fs.StringVar(&entry.Owner, "owner", "", "购买人")
+		fs.StringVar(&entry.Note, "note", "", "备注")
+
+		run = func(b *book.Book) (bool, error) {
+			if fs.NArg() != 1 {
+				return false, errors.New("需要一张彩票")
+			}
+
+			entry.Ticket = fs.Arg(0)
+
+			added, err := b.Add(entry)
+			if err != nil {
+				return false, err
+			}
+
+			fmt.Printf("已添加购彩记录 %d: %s，金额: %d\n", added.ID, added.Ticket, added.Cost)
+
+			return true, nil
+		}
+	case "list":
+		owner := fs.String("owner", "", "只显示指定购买人的记录")
+		unclaimed := fs.Bool("unclaimed", false, "只显示有未兑奖金额的记录")
+
+		run = func(b *book.Book) (bool, error) {
+			var entries []book.Entry
+
+			for _, entry := range b.Entries {
+				if (*owner == "" || entry.Owner == *owner) && (!*unclaimed || entry.GetUnclaimed() > 0) {
+					entries = append(entries, entry)
+				}
+			}
+
+			book.Print(os.Stdout, entries)
+
+			return false, nil
+		}
+	case "rm":
+		run = func(b *book.Book) (bool, error) {
+			ids, err := getIDs(fs.Args(), "编号")
+			if err != nil {
+				return false, err
+			}
+
+			return true, b.Remove(ids[0])
+		}
+	case "check":
+		storePath := fs.String("store", "", "历史文件路径，默认为 <彩票类型>_history.json")
+		lotteryType := fs.String("type", "DLT", "彩票类型")
+
+		run = func(b *book.Book) (bool, error) {
+			draws, err := loadDraws(getStorePath(*storePath, *lotteryType), *lotteryType)
+			if err != nil {
+				return false, err
+			}
+
+			checked, err := b.Check(draws)
+			if err != nil {
+				return false, err
+			}
+
+			fmt.Printf("新核对%d期\n", checked)
+
+			var entries []book.Entry
+
+			for _, entry := range b.Entries {
+				if entry.GetUnclaimed() > 0 {
+					entries = append(entries, entry)
+				}
+			}
+
+			if len(entries) > 0 {
+				fmt.Println("未兑奖的购彩记录:")
+				book.Print(os.Stdout, entries)
+			}
+
+			return checked > 0, nil
+		}
+	case "claim":
+		date := fs.String("date", today, "兑奖日期")
+
+		run = func(b *book.Book) (bool, error) {
+			ids, err := getIDs(fs.Args(), "编号", "期号")
+			if err != nil {
+				return false, err
+			}
+
+			return true, b.Claim(ids[0], ids[1], *date)
+		}
+	default:
+		fmt.Fprintln(os.Stderr, bookUsage)
+		return fmt.Errorf("未知的子命令: %s", args[0])
+	}
+
+	fs.Parse(args[1:])
+
+	b, err := book.LoadBook(*bookPath)
+	if err != nil {
+		return err
+	}
+
+	changed, err := run(&b)
+	if err != nil || !changed {
+		return err
+	}
+
+	b.UpdateTime = time.Now().Format("2006-01-02 15:04:05")
+
+	return b.Save(*bookPath)
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/buggy-95/lott/internal/lottery/dlt"
+)
+
+// addCSVFlags
+//
+// @Description 注册 CSV 列映射相关的参数，返回根据参数生成列映射的函数，未指定的列使用默认列名
+//
+// @Param fs *flag.FlagSet 参数集
+//
+// @Return *string 彩票类型
+//
+// @Return func() (dlt.CSVMapping, error) 生成列映射
+func addCSVFlags(fs *flag.FlagSet) (*string, func() (dlt.CSVMapping, error)) {
+	lotteryType := fs.String("type", "DLT", "彩票类型 (DLT, SSQ, QLC)")
+	issue := fs.String("issue", "", "期号列，默认为 issue")
+	date := fs.String("date", "", "开奖日期列，默认为 date，为 - 时不包含日期")
+	front := fs.String("front", "", "前区号码列，多列用逗号分隔，默认为 front1..frontN")
+	back := fs.String("back", "", "后区号码列，多列用逗号分隔，默认为 back1..backN")
+	special := fs.String("special", "", "特别号列，多列用逗号分隔，默认为 special1..specialN，只用于七乐彩")
+	comma := fs.String("comma", ",", "CSV 分隔符")
+
+	return lotteryType, func() (dlt.CSVMapping, error) {
+		mapping, err := dlt.NewCSVMapping(*lotteryType)
+		if err != nil {
+			return mapping, err
+		}
+
+		if *issue != "" {
+			mapping.Issue = *issue
+		}
+
+		if *date == "-" {
+			mapping.Date = ""
+		} else if *date != "" {
+			mapping.Date = *date
+		}
+
+		if *front != "" {
+			mapping.Front = strings.Split(*front, ",")
+		}
+
+		if *back != "" {
+			mapping.Back = strings.Split(*back, ",")
+		}
+
+		if *special != "" {
+			mapping.Special = strings.Split(*special, ",")
+		}
+
+		if utf8.RuneCountInString(*comma) != 1 {
+			return mapping, fmt.Errorf("分隔符必须是一个字符: %q", *comma)
+		}
+
+		mapping.Comma, _ = utf8.DecodeRuneInString(*comma)
+
+		return mapping, nil
+	}
+}
+
+func runImportCSV(args []string) error {
+	fs := flag.NewFlagSet("import-csv", flag.ExitOnError)
+	storePath := fs.String("store", "", "历史文件路径，默认为 <彩票类型>_history.json，不存在时创建")
+	filePath := fs.String("file", "", "导入的 CSV 文件，第一行必须是表头")
+	lotteryType, newMapping := addCSVFlags(fs)
+	fs.Parse(args)
+
+	if *filePath == "" {
+		return errors.New("缺少 CSV 文件，请使用 -file 指定")
+	}
+
+	mapping, err := newMapping()
+	if err != nil {
+		return err
+	}
+
+	path := getStorePath(*storePath, *lotteryType)
+
+	store, err := dlt.LoadGameStore(path, *lotteryType)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	file, err := os.Open(*filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	list, err := dlt.ImportCSV(file, mapping)
+	if err != nil {
+		var rowErr *dlt.CSVRowError
+
+		// 校验失败的行会被跳过，读取失败或全部行都校验失败时中止
+		if !errors.As(err, &rowErr) || len(list) == 0 {
+			return err
+		}
+
+		fmt.Fprintln(os.Stderr, "跳过的行:")
+		fmt.Fprintln(os.Stderr, err)
+	}
+
+	// CSV 中没有奖级和奖池数据，开奖号码一致的期号保留原有数据
+	added := store.MergeNumbers(list)
+	store.Type = *lotteryType
+	store.UpdateTime = time.Now().Format("2006-01-02 15:04:05")
+
+	if err := store.Save(path); err != nil {
+		return err
+	}
+
+	fmt.Printf("导入%d期，新增%d期，共%d期\n", len(list), added, len(store.List))
+
+	return nil
+}
+
+func runExportCSV(args []string) error {
+	fs := flag.NewFlagSet("export-csv", flag.ExitOnError)
+	storePath := fs.String("store", "", "历史文件路径，默认为 <彩票类型>_history.json")
+	filePath := fs.String("file", "", "导出的 CSV 文件，为空时输出到标准输出")
+	lotteryType, newMapping := addCSVFlags(fs)
+	fs.Parse(args)
+
+	mapping, err := newMapping()
+	if err != nil {
+		return err
+	}
+
+	store, err := dlt.LoadGameStore(getStorePath(*storePath, *lotteryType), *lotteryType)
+	if err != nil {
+		return err
+	}
+
+	if *filePath == "" {
+		return dlt.ExportCSV(os.Stdout, store.List, mapping)
+	}
+
+	file, err := os.Create(*filePath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := dlt.ExportCSV(file, store.List, mapping); err != nil {
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/digit"
+)
+
+// isDigitInput
+//
+// @Description 判断彩票字符串是否为排列三、排列五等位置型彩票
+//
+// @Param input string 彩票字符串
+//
+// @Return bool 是否为位置型彩票
+func isDigitInput(input string) bool {
+	head, _, _ := strings.Cut(input, ":")
+	lotteryType, _, _ := strings.Cut(head, "/")
+
+	return digit.IsDigitType(lotteryType)
+}
+
+// checkDigitTickets
+//
+// @Description 用开奖号码核对位置型彩票并输出结果
+//
+// @Param drawInput string 开奖号码字符串
+//
+// @Param inputs []string 彩票字符串
+//
+// @Return error 错误信息
+func checkDigitTickets(drawInput string, inputs []string) error {
+	draw, err := digit.ParseDraw(drawInput)
+	if err != nil {
+		return lottery.NewError("", "parse.draw", err)
+	}
+
+	for _, input := range inputs {
+		ticket, err := digit.Parse(input)
+		if err != nil {
+			return err
+		}
+
+		result, err := ticket.GetResult(draw)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(result.FormatResult())
+	}
+
+	return nil
+}
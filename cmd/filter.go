@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/generate"
+	"github.com/buggy-95/lott/internal/lottery/stats"
+)
+
+// parseRange
+//
+// @Description 解析取值范围，例如: 60-120，单个数字表示最小值和最大值相同
+//
+// @Param input string 取值范围字符串
+//
+// @Return *stats.Range 取值范围，输入为空时返回 nil
+//
+// @Return error 错误信息
+func parseRange(input string) (*stats.Range, error) {
+	if input == "" {
+		return nil, nil
+	}
+
+	minStr, maxStr, found := strings.Cut(input, "-")
+	if !found {
+		maxStr = minStr
+	}
+
+	minNum, minErr := strconv.Atoi(minStr)
+	maxNum, maxErr := strconv.Atoi(maxStr)
+
+	if minErr != nil || maxErr != nil || minNum > maxNum {
+		return nil, fmt.Errorf("取值范围解析失败: %s", input)
+	}
+
+	return &stats.Range{Min: minNum, Max: maxNum}, nil
+}
+
+// parseContain
+//
+// @Description 解析包含条件，例如: 2:01,02,03 表示至少包含其中2个号码，2-3:01,02,03 表示包含2到3个
+//
+// @Param input string 包含条件字符串
+//
+// @Return generate.ContainFilter 包含条件
+//
+// @Return error 错误信息
+func parseContain(input string) (generate.ContainFilter, error) {
+	var filter generate.ContainFilter
+
+	countStr, numStr, found := strings.Cut(input, ":")
+	if !found {
+		return filter, fmt.Errorf("包含条件格式错误: %s", input)
+	}
+
+	count, err := parseRange(countStr)
+	if err != nil || count == nil {
+		return filter, fmt.Errorf("包含条件格式错误: %s", input)
+	}
+
+	nums, err := parseNums(numStr)
+	if err != nil {
+		return filter, err
+	}
+
+	filter.Nums = nums
+	filter.Min = count.Min
+
+	if strings.Contains(countStr, "-") {
+		filter.Max = count.Max
+	}
+
+	return filter, nil
+}
+
+func runFilter(args []string) error {
+	var options generate.FilterOptions
+
+	fs := flag.NewFlagSet("filter", flag.ExitOnError)
+	frontExclude := fs.String("front-exclude", "", "前区杀号，例如: 01,02")
+	backExclude := fs.String("back-exclude", "", "后区杀号")
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出过滤结果")
+
+	ranges := []struct {
+		name   string
+		usage  string
+		target **stats.Range
+	}{
+		{"sum", "和值范围，例如: 60-120", &options.Pattern.Sum},
+		{"span", "跨度范围", &options.Pattern.Span},
+		{"odd", "奇数个数范围", &options.Pattern.Odd},
+		{"big", "大号个数范围", &options.Pattern.Big},
+		{"consecutive", "连号对数范围", &options.Pattern.Consecutive},
+		{"max-run", "最长连号个数范围", &options.Pattern.MaxRun},
+		{"ac", "AC值范围", &options.Pattern.AC},
+	}
+
+	for _, item := range ranges {
+		target := item.target
+
+		fs.Func(item.name, item.usage, func(value string) (err error) {
+			*target, err = parseRange(value)
+			return err
+		})
+	}
+
+	for _, item := range []struct {
+		name   string
+		target *[]generate.ContainFilter
+	}{
+		{"front-contains", &options.FrontContains},
+		{"back-contains", &options.BackContains},
+	} {
+		target := item.target
+
+		fs.Func(item.name, "包含条件，可以重复，例如: 2:01,02,03 表示至少包含其中2个号码", func(value string) error {
+			filter, err := parseContain(value)
+			if err != nil {
+				return err
+			}
+
+			*target = append(*target, filter)
+
+			return nil
+		})
+	}
+
+	fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		return errors.New("需要一张待过滤的彩票，例如: filter -sum 60-120 DLT:01,02,03,04,05,06,07,08-01,02,03")
+	}
+
+	lott, err := lottery.GetLottery(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+
+	if options.FrontExclude, err = parseNums(*frontExclude); err != nil {
+		return err
+	}
+
+	if options.BackExclude, err = parseNums(*backExclude); err != nil {
+		return err
+	}
+
+	report, err := generate.Filter(lott, options)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		var tickets []string
+
+		for _, item := range report.List {
+			tickets = append(tickets, item.String())
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(struct {
+			generate.FilterReport
+			Tickets []string `json:"tickets"`
+		}{report, tickets})
+	}
+
+	for _, item := range report.List {
+		fmt.Println(item.String())
+	}
+
+	report.Print(os.Stderr)
+
+	return nil
+}
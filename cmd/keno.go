@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/keno"
+)
+
+// isKenoInput
+//
+// @Description 判断彩票字符串是否为快乐8等单区选号型彩票
+//
+// @Param input string 彩票字符串
+//
+// @Return bool 是否为单区选号型彩票
+func isKenoInput(input string) bool {
+	head, _, _ := strings.Cut(input, ":")
+	lotteryType, _, _ := strings.Cut(head, "/")
+
+	return keno.IsKenoType(lotteryType)
+}
+
+// checkKenoTickets
+//
+// @Description 用开奖号码核对单区选号型彩票并输出结果
+//
+// @Param drawInput string 开奖号码字符串
+//
+// @Param inputs []string 彩票字符串
+//
+// @Return error 错误信息
+func checkKenoTickets(drawInput string, inputs []string) error {
+	draw, err := keno.ParseDraw(drawInput)
+	if err != nil {
+		return lottery.NewError("", "parse.draw", err)
+	}
+
+	for _, input := range inputs {
+		ticket, err := keno.Parse(input)
+		if err != nil {
+			return err
+		}
+
+		result, err := ticket.GetResult(draw)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(result.FormatResult())
+	}
+
+	return nil
+}
@@ -1,29 +1,204 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/buggy-95/lott/internal/lottery"
 	"github.com/buggy-95/lott/internal/lottery/dlt"
 )
 
+// 子命令
+type command struct {
+	name  string
+	usage string
+	run   func(args []string) error
+}
+
+var commands = []command{
+	{"check", "核对彩票: check -draw DLT:02,04,11,29,30-02,08 DLT:16,18,29,30,31-09,12x3 ... (也支持 SSQ、QLC、PL3、PL5、3D、QXC、KL8，格式见 README)", runCheck},
+	{"sync", "同步历史开奖数据: sync [-store <彩票类型>_history.json] [-game DLT|PL3|PL5|QXC|SSQ|QLC] [-file 历史文件 | -replay 录制目录] [-record 录制目录]", runSync},
+	{"import-csv", "从 CSV 导入开奖历史: import-csv -file history.csv [-store <彩票类型>_history.json] [-type DLT] [-issue 期号列] [-front 列1,列2,...] [-back 列1,列2] [-special 列] [-date 日期列]", runImportCSV},
+	{"export-csv", "导出开奖历史为 CSV: export-csv [-file history.csv] [-store <彩票类型>_history.json] [-type DLT] [-front 列1,列2,...] [-back 列1,列2] [-special 列]", runExportCSV},
+	{"verify", "校验历史开奖数据，可以重新获取缺失和错误的期号: verify [-store <彩票类型>_history.json] [-game DLT|SSQ|QLC] [-repair]", runVerify},
+	{"stats", "号码频率和遗漏统计: stats [-store dlt_history.json] [-window 100] [-json]", runStats},
+	{"pattern", "和值、跨度、奇偶、大小等形态分布: pattern [-store dlt_history.json] [-window 30] [-json]", runPattern},
+	{"backtest", "回测彩票的历史收益: backtest [-store dlt_history.json] [-from 期号] [-to 期号] [-tickets 文件] DLT:01,02,03,04,05,06-01,02 ...", runBacktest},
+	{"odds", "中奖概率和期望收益: odds [-store dlt_history.json] [-window 30] DLT:01,02~03,04,05,06,07,08,09,10-01,02,03,04", runOdds},
+	{"pick", "机选彩票: pick [-type DLT] [-n 5] [-front 7] [-back 3] [-front-dan 01,02] [-front-exclude 03,04] [-seed 种子]", runPick},
+	{"filter", "展开复式票并按条件过滤: filter [-sum 60-120] [-odd 2-3] [-front-exclude 01,02] [-front-contains 2:01,02,03] DLT:01,02,03,04,05,06,07,08-01,02,03", runFilter},
+	{"wheel", "旋转矩阵: wheel -front 01,03,05,07,09,11,13,15,17,19 -back 03,09 [-hit 5] [-match 4]", runWheel},
+	{"book", "购彩记录本: book add|list|rm|check|claim [-book ticket_book.json] ...", runBook},
+	{"syndicate", "合买结算: syndicate -config syndicate.json [-store dlt_history.json] [-csv 结算单.csv]", runSyndicate},
+	{"serve", "HTTP JSON 接口: serve [-addr :8080] [-store dlt_history.json] [-max-bets 10000]", runServe},
+	{"tui", "交互式核对彩票: tui [-store dlt_history.json]", runTUI},
+	{"watch", "监听开奖并通知: watch -tickets tickets.txt [-game DLT|SSQ|QLC] [-store <彩票类型>_history.json] [-webhook URL] [-cmd 命令] [-smtp-addr 地址]", runWatch},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "用法: lott [-lang zh-CN|en] <命令> [参数]")
+
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-10s %s\n", cmd.name, cmd.usage)
+	}
+}
+
 func main() {
-	targetLottery := "DLT:02,04,11,29,30-02,08"
-	sourceLotteryList := []string{
-		"DLT:16,18,29,30,31-09,12x3",
-		"DLT:01,12,22,30,33-07,10x3",
-		"DLT:06,10,21,22,31-07,09x3",
-		"DLT:07,10,15,31,33-10,12x3",
-		"DLT:08,14,17,27,31-01,07x3",
+	lang := flag.String("lang", "", "输出语言 (zh-CN, en)，默认按 LC_ALL、LC_MESSAGES、LANG 环境变量选择")
+	flag.Usage = usage
+	flag.Parse()
+
+	locale := lottery.GetEnvLocale()
+
+	if *lang != "" {
+		var ok bool
+
+		if locale, ok = lottery.ParseLocale(*lang); !ok {
+			fmt.Fprintf(os.Stderr, "不支持的语言: %s\n", *lang)
+			os.Exit(2)
+		}
 	}
 
-	target, _ := lottery.GetLottery(targetLottery)
+	lottery.SetLocale(locale)
 
-	for _, str := range sourceLotteryList {
-		lott, _ := lottery.GetLottery(str)
-		result, _ := lott.GetLotteryResult(target)
+	args := flag.Args()
+	if len(args) < 1 {
+		usage()
+		os.Exit(2)
+	}
+
+	for _, cmd := range commands {
+		if cmd.name == args[0] {
+			if err := cmd.run(args[1:]); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+				os.Exit(1)
+			}
+
+			return
+		}
+	}
+
+	usage()
+	os.Exit(2)
+}
+
+// addSourceFlags
+//
+// @Description 注册数据源相关的参数，返回根据参数创建数据源的函数
+//
+// @Param fs *flag.FlagSet 参数集
+//
+// @Return *string 彩票类型
+//
+// @Return func() (dlt.Source, error) 创建数据源
+func addSourceFlags(fs *flag.FlagSet) (*string, func() (dlt.Source, error)) {
+	game := fs.String("game", "DLT", "从官网接口获取的彩票类型 (DLT, PL3, PL5, QXC, SSQ, QLC)")
+	file := fs.String("file", "", "从本地历史文件读取开奖数据，用于离线环境")
+	replay := fs.String("replay", "", "从录制目录回放开奖数据")
+	record := fs.String("record", "", "将接口返回的页面录制到目录中")
+
+	return game, func() (dlt.Source, error) {
+		src, err := dlt.NewSource(*game)
+		if err != nil {
+			return nil, err
+		}
+
+		if *file != "" {
+			src = &dlt.FileSource{Path: *file}
+		} else if *replay != "" {
+			src = &dlt.ReplaySource{Dir: *replay}
+		}
+
+		if *record != "" {
+			src = &dlt.RecordSource{Source: src, Dir: *record}
+		}
+
+		return src, nil
+	}
+}
 
-		result.PrintResult(true, true)
-		result.PrintList(true, true)
+// getStorePath
+//
+// @Description 获取历史文件路径，未指定时按彩票类型使用 <彩票类型>_history.json，例如 dlt_history.json
+//
+// @Param storePath string 指定的历史文件路径
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return string 历史文件路径
+func getStorePath(storePath, lotteryType string) string {
+	if storePath != "" {
+		return storePath
 	}
 
-	dlt.CheckStore()
+	return strings.ToLower(lotteryType) + "_history.json"
+}
+
+func runCheck(args []string) error {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	draw := fs.String("draw", "", "开奖号码，例如 DLT:02,04,11,29,30-02,08")
+	useColor := fs.Bool("color", true, "用颜色标记中奖号码")
+	showList := fs.Bool("list", false, "展示复式票的全部单式票")
+	fs.Parse(args)
+
+	if isDigitInput(*draw) {
+		return checkDigitTickets(*draw, fs.Args())
+	}
+
+	if isPositionInput(*draw) {
+		return checkPositionTickets(*draw, fs.Args())
+	}
+
+	if isKenoInput(*draw) {
+		return checkKenoTickets(*draw, fs.Args())
+	}
+
+	target, err := lottery.GetLottery(*draw)
+	if err != nil {
+		return lottery.NewError("", "parse.draw", err)
+	}
+
+	for _, str := range fs.Args() {
+		lott, err := lottery.GetLottery(str)
+		if err != nil {
+			return err
+		}
+
+		result, err := lott.GetLotteryResult(target)
+		if err != nil {
+			return err
+		}
+
+		result.PrintResult(*useColor, true)
+
+		if *showList {
+			result.PrintList(*useColor, true)
+		}
+	}
+
+	return nil
+}
+
+func runSync(args []string) error {
+	fs := flag.NewFlagSet("sync", flag.ExitOnError)
+	storePath := fs.String("store", "", "历史文件路径，默认为 <彩票类型>_history.json")
+	game, newSource := addSourceFlags(fs)
+	fs.Parse(args)
+
+	src, err := newSource()
+	if err != nil {
+		return err
+	}
+
+	store, err := dlt.SyncStore(src, *game, getStorePath(*storePath, *game))
+	if err != nil {
+		return err
+	}
+
+	fmt.Println("数据:", len(store.List))
+	fmt.Println("文件写入成功")
+
+	return nil
 }
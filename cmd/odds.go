@@ -0,0 +1,81 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/dlt"
+	"github.com/buggy-95/lott/internal/lottery/odds"
+)
+
+func runOdds(args []string) error {
+	fs := flag.NewFlagSet("odds", flag.ExitOnError)
+	storePath := fs.String("store", "dlt_history.json", "历史文件路径，用于读取奖池余额和浮动奖历史，为空时使用参考金额")
+	lotteryType := fs.String("type", "DLT", "历史文件的彩票类型，其他类型的彩票使用参考金额")
+	window := fs.Int("window", 30, "统计浮动奖平均奖金的期数，为0时统计全部期数")
+	index := fs.Int("index", 0, "期号，用于选择奖级规则")
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	fs.Parse(args)
+
+	if fs.NArg() == 0 {
+		return errors.New("没有需要计算的彩票")
+	}
+
+	var reports []odds.Report
+
+	for _, str := range fs.Args() {
+		ticket, err := lottery.GetLottery(str)
+		if err != nil {
+			return err
+		}
+
+		options := odds.Options{Index: *index, Tax: lottery.DefaultTaxRule}
+
+		if *storePath != "" && ticket.Type == *lotteryType {
+			store, err := dlt.LoadStore(*storePath)
+
+			switch {
+			case errors.Is(err, os.ErrNotExist):
+				fmt.Fprintf(os.Stderr, "历史文件不存在，使用参考金额: %s\n", *storePath)
+			case err != nil:
+				return err
+			case len(store.List) > 0:
+				if options.Pool, err = store.List[0].GetPoolBalance(); err != nil {
+					return err
+				}
+
+				if options.Floating, err = dlt.GetFloatingPrizes(store.List, ticket.Type, *window); err != nil {
+					return err
+				}
+			}
+		}
+
+		report, err := odds.Calculate(ticket, options)
+		if err != nil {
+			return err
+		}
+
+		reports = append(reports, report)
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(reports)
+	}
+
+	for i, report := range reports {
+		if i > 0 {
+			fmt.Println()
+		}
+
+		report.Print(os.Stdout)
+	}
+
+	return nil
+}
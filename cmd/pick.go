@@ -0,0 +1,121 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/generate"
+)
+
+// parseNums
+//
+// @Description 解析以逗号分隔的号码列表，例如: 01,02,03
+//
+// @Param input string 号码列表字符串
+//
+// @Return []int 号码列表
+//
+// @Return error 错误信息
+func parseNums(input string) ([]int, error) {
+	var nums []int
+
+	for _, field := range strings.Split(input, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		num, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("号码解析失败: %s", field)
+		}
+
+		nums = append(nums, num)
+	}
+
+	return nums, nil
+}
+
+func runPick(args []string) error {
+	fs := flag.NewFlagSet("pick", flag.ExitOnError)
+	lotteryType := fs.String("type", "DLT", "彩票类型")
+	n := fs.Int("n", 1, "彩票数量，单式票互不重复")
+	front := fs.Int("front", 0, "前区号码数量，为0时使用单式票数量")
+	back := fs.Int("back", 0, "后区号码数量，为0时使用单式票数量")
+	frontDan := fs.String("front-dan", "", "前区胆码，例如: 01,02")
+	backDan := fs.String("back-dan", "", "后区胆码")
+	frontExclude := fs.String("front-exclude", "", "前区排除的号码")
+	backExclude := fs.String("back-exclude", "", "后区排除的号码")
+	scale := fs.Int("scale", 0, "倍投倍数")
+	index := fs.Int("index", 0, "期号")
+	seed := fs.String("seed", "", "随机种子，用于复现结果，为空时使用 crypto/rand")
+	fs.Parse(args)
+
+	options := generate.Options{
+		Type:      *lotteryType,
+		FrontSize: *front,
+		BackSize:  *back,
+		Scale:     *scale,
+		Index:     *index,
+	}
+
+	for _, item := range []struct {
+		input  string
+		target *[]int
+	}{
+		{*frontDan, &options.FrontDan},
+		{*backDan, &options.BackDan},
+		{*frontExclude, &options.FrontExclude},
+		{*backExclude, &options.BackExclude},
+	} {
+		nums, err := parseNums(item.input)
+		if err != nil {
+			return err
+		}
+
+		*item.target = nums
+	}
+
+	r := generate.NewCryptoRand()
+
+	if *seed != "" {
+		value, err := strconv.ParseUint(*seed, 10, 64)
+		if err != nil {
+			return fmt.Errorf("随机种子解析失败: %s", *seed)
+		}
+
+		r = generate.NewSeededRand(value)
+	}
+
+	rule, err := lottery.GetGameRule(*lotteryType)
+	if err != nil {
+		return err
+	}
+
+	var list []lottery.Lottery
+
+	if (*front == 0 || *front == rule.FrontSize) && (*back == 0 || *back == rule.BackSize) {
+		list, err = generate.GenerateSingles(r, options, *n)
+		if err != nil {
+			return err
+		}
+	} else {
+		for range *n {
+			lott, err := generate.Generate(r, options)
+			if err != nil {
+				return err
+			}
+
+			list = append(list, lott)
+		}
+	}
+
+	for _, lott := range list {
+		fmt.Println(lott.String())
+	}
+
+	return nil
+}
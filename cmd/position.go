@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/position"
+)
+
+// isPositionInput
+//
+// @Description 判断彩票字符串是否为七星彩等定位型彩票
+//
+// @Param input string 彩票字符串
+//
+// @Return bool 是否为定位型彩票
+func isPositionInput(input string) bool {
+	lotteryType, _, _ := strings.Cut(input, ":")
+
+	return position.IsPositionType(lotteryType)
+}
+
+// checkPositionTickets
+//
+// @Description 用开奖号码核对定位型彩票并输出结果
+//
+// @Param drawInput string 开奖号码字符串
+//
+// @Param inputs []string 彩票字符串
+//
+// @Return error 错误信息
+func checkPositionTickets(drawInput string, inputs []string) error {
+	draw, err := position.ParseDraw(drawInput)
+	if err != nil {
+		return lottery.NewError("", "parse.draw", err)
+	}
+
+	for _, input := range inputs {
+		ticket, err := position.Parse(input)
+		if err != nil {
+			return err
+		}
+
+		result, err := ticket.GetResult(draw)
+		if err != nil {
+			return err
+		}
+
+		fmt.Println(result.FormatResult())
+	}
+
+	return nil
+}
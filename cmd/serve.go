@@ -0,0 +1,39 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/buggy-95/lott/internal/server"
+)
+
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "监听地址")
+	storePath := fs.String("store", "", "历史文件路径，默认为 <彩票类型>_history.json")
+	lotteryType := fs.String("type", "DLT", "彩票类型")
+	maxBody := fs.Int64("max-body", 64<<10, "请求体的最大字节数")
+	maxBets := fs.Int("max-bets", 10000, "单次请求展开的最大注数")
+	fs.Parse(args)
+
+	s := &server.Server{
+		StorePath:   getStorePath(*storePath, *lotteryType),
+		Type:        *lotteryType,
+		MaxBodySize: *maxBody,
+		MaxBets:     *maxBets,
+	}
+
+	httpServer := &http.Server{
+		Addr:              *addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		ReadTimeout:       30 * time.Second,
+		WriteTimeout:      30 * time.Second,
+	}
+
+	log.Printf("监听地址: %s", *addr)
+
+	return httpServer.ListenAndServe()
+}
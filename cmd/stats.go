@@ -0,0 +1,93 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/dlt"
+	"github.com/buggy-95/lott/internal/lottery/stats"
+)
+
+// loadDraws
+//
+// @Description 读取历史文件并转换为开奖号码列表，历史文件保存的是其他彩票类型时返回错误
+//
+// @Param storePath string 历史文件路径
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return []lottery.Lottery 开奖号码列表，从新到旧排列
+//
+// @Return error 错误信息
+func loadDraws(storePath, lotteryType string) ([]lottery.Lottery, error) {
+	store, err := dlt.LoadGameStore(storePath, lotteryType)
+	if err != nil {
+		return nil, err
+	}
+
+	return store.GetLotteryList(lotteryType)
+}
+
+func runStats(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	storePath := fs.String("store", "", "历史文件路径，默认为 <彩票类型>_history.json")
+	lotteryType := fs.String("type", "DLT", "彩票类型")
+	window := fs.Int("window", 100, "统计的期数，为0时统计全部期数")
+	hotRatio := fs.Float64("hot", 1.2, "命中次数达到理论次数的倍数时为热号")
+	coldRatio := fs.Float64("cold", 0.8, "命中次数低于理论次数的倍数时为冷号")
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	fs.Parse(args)
+
+	draws, err := loadDraws(getStorePath(*storePath, *lotteryType), *lotteryType)
+	if err != nil {
+		return err
+	}
+
+	report, err := stats.GetFrequency(draws, stats.FrequencyOptions{Window: *window, HotRatio: *hotRatio, ColdRatio: *coldRatio})
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(report)
+	}
+
+	report.Print(os.Stdout)
+
+	return nil
+}
+
+func runPattern(args []string) error {
+	fs := flag.NewFlagSet("pattern", flag.ExitOnError)
+	storePath := fs.String("store", "", "历史文件路径，默认为 <彩票类型>_history.json")
+	lotteryType := fs.String("type", "DLT", "彩票类型")
+	window := fs.Int("window", 30, "统计的期数，为0时统计全部期数")
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	fs.Parse(args)
+
+	draws, err := loadDraws(getStorePath(*storePath, *lotteryType), *lotteryType)
+	if err != nil {
+		return err
+	}
+
+	report, err := stats.GetPatternReport(draws, *window)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(report)
+	}
+
+	report.Print(os.Stdout)
+
+	return nil
+}
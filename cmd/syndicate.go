@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+
+	"github.com/buggy-95/lott/internal/lottery/syndicate"
+)
+
+func runSyndicate(args []string) error {
+	fs := flag.NewFlagSet("syndicate", flag.ExitOnError)
+	configPath := fs.String("config", "syndicate.json", "合买方案文件")
+	storePath := fs.String("store", "", "历史文件路径，默认为 <彩票类型>_history.json")
+	lotteryType := fs.String("type", "DLT", "彩票类型")
+	csvPath := fs.String("csv", "", "将成员结算单导出为 CSV 文件")
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	fs.Parse(args)
+
+	s, err := syndicate.LoadSyndicate(*configPath)
+	if err != nil {
+		return err
+	}
+
+	draws, err := loadDraws(getStorePath(*storePath, *lotteryType), *lotteryType)
+	if err != nil {
+		return err
+	}
+
+	statement, err := s.Settle(draws)
+	if err != nil {
+		return err
+	}
+
+	if *csvPath != "" {
+		file, err := os.Create(*csvPath)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		if err := statement.WriteCSV(file); err != nil {
+			return err
+		}
+	}
+
+	if *asJSON {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(statement)
+	}
+
+	statement.Print(os.Stdout)
+
+	return nil
+}
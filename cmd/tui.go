@@ -0,0 +1,25 @@
+package main
+
+import (
+	"flag"
+
+	"github.com/buggy-95/lott/internal/tui"
+)
+
+func runTUI(args []string) error {
+	fs := flag.NewFlagSet("tui", flag.ExitOnError)
+	storePath := fs.String("store", "", "历史文件路径，默认为 <彩票类型>_history.json")
+	lotteryType := fs.String("type", "DLT", "彩票类型")
+	useColor := fs.Bool("color", true, "用颜色标记中奖号码")
+	fs.Parse(args)
+
+	draws, err := loadDraws(getStorePath(*storePath, *lotteryType), *lotteryType)
+	if err != nil {
+		return err
+	}
+
+	model := tui.NewModel(draws)
+	model.UseColor = *useColor
+
+	return tui.Run(model)
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/dlt"
+)
+
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	storePath := fs.String("store", "", "历史文件路径，默认为 <彩票类型>_history.json")
+	repair := fs.Bool("repair", false, "从数据源重新获取缺失和错误的期号并写回历史文件")
+	game, newSource := addSourceFlags(fs)
+	fs.Parse(args)
+
+	// 开奖号码按前区和后区的玩法规则校验
+	if _, err := lottery.GetGameRule(*game); err != nil {
+		return err
+	}
+
+	path := getStorePath(*storePath, *game)
+
+	store, err := dlt.LoadGameStore(path, *game)
+	if err != nil {
+		return err
+	}
+
+	report := store.Verify(*game)
+	report.Print(os.Stdout)
+
+	if report.IsValid() {
+		return nil
+	}
+
+	if !*repair {
+		return errors.New("历史数据校验失败，可以使用 -repair 重新获取缺失和错误的期号")
+	}
+
+	src, err := newSource()
+	if err != nil {
+		return err
+	}
+
+	repaired, repairErr := store.Repair(src, report)
+
+	if len(repaired) > 0 {
+		store.UpdateTime = time.Now().Format("2006-01-02 15:04:05")
+
+		if err := store.Save(path); err != nil {
+			return err
+		}
+
+		fmt.Printf("修复%d期: %v\n", len(repaired), repaired)
+	}
+
+	return repairErr
+}
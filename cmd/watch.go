@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/dlt"
+	"github.com/buggy-95/lott/internal/notify"
+)
+
+// readTickets
+//
+// @Description 读取彩票文件，每行一张彩票，忽略空行和以#开头的注释
+//
+// @Param path string 文件路径
+//
+// @Return []lottery.Lottery 彩票列表
+//
+// @Return error 错误信息
+func readTickets(path string) ([]lottery.Lottery, error) {
+	var tickets []lottery.Lottery
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for line := 1; scanner.Scan(); line++ {
+		str := strings.TrimSpace(scanner.Text())
+		if str == "" || strings.HasPrefix(str, "#") {
+			continue
+		}
+
+		ticket, err := lottery.GetLottery(str)
+		if err != nil {
+			return nil, lottery.NewError("", "parse.line", path, line, err)
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, scanner.Err()
+}
+
+func runWatch(args []string) error {
+	fs := flag.NewFlagSet("watch", flag.ExitOnError)
+	storePath := fs.String("store", "", "历史文件路径，默认为 <彩票类型>_history.json，为 - 时不保存新开奖数据")
+	ticketsPath := fs.String("tickets", "", "彩票文件，每行一张彩票")
+	interval := fs.Duration("interval", time.Minute, "开奖后的轮询间隔")
+	quiet := fs.Bool("quiet", false, "不在标准输出打印通知")
+	command := fs.String("cmd", "", "通知命令，通过 sh -c 执行，正文从标准输入传入，标题在环境变量 LOTT_SUBJECT 中")
+	webhook := fs.String("webhook", "", "通知 Webhook 地址")
+	smtpAddr := fs.String("smtp-addr", "", "SMTP 服务地址，例如 smtp.example.com:25")
+	smtpFrom := fs.String("smtp-from", "", "发件人")
+	smtpTo := fs.String("smtp-to", "", "收件人，多个收件人用逗号分隔")
+	smtpUser := fs.String("smtp-user", "", "SMTP 用户名，密码从环境变量 LOTT_SMTP_PASSWORD 读取")
+	game, newSource := addSourceFlags(fs)
+	fs.Parse(args)
+
+	// 只支持有固定开奖时间的彩票
+	if _, err := dlt.GetDrawSchedule(*game); err != nil {
+		return err
+	}
+
+	var (
+		tickets   []lottery.Lottery
+		notifiers notify.MultiNotifier
+	)
+
+	if *ticketsPath != "" {
+		list, err := readTickets(*ticketsPath)
+		if err != nil {
+			return err
+		}
+
+		for _, ticket := range list {
+			if ticket.Type != *game {
+				return fmt.Errorf("彩票类型 %s 与监听的彩票类型 %s 不一致: %s", ticket.Type, *game, ticket.String())
+			}
+		}
+
+		tickets = list
+	}
+
+	path := getStorePath(*storePath, *game)
+	if *storePath == "-" {
+		path = ""
+	}
+
+	if !*quiet {
+		notifiers = append(notifiers, &notify.StdoutNotifier{})
+	}
+
+	if *command != "" {
+		notifiers = append(notifiers, &notify.CommandNotifier{Name: "sh", Args: []string{"-c", *command}})
+	}
+
+	if *webhook != "" {
+		notifiers = append(notifiers, &notify.WebhookNotifier{URL: *webhook})
+	}
+
+	if *smtpAddr != "" {
+		if *smtpFrom == "" || *smtpTo == "" {
+			return errors.New("使用邮件通知时必须指定发件人和收件人")
+		}
+
+		notifiers = append(notifiers, &notify.SMTPNotifier{
+			Addr:     *smtpAddr,
+			From:     *smtpFrom,
+			To:       strings.Split(*smtpTo, ","),
+			Username: *smtpUser,
+			Password: os.Getenv("LOTT_SMTP_PASSWORD"),
+		})
+	}
+
+	src, err := newSource()
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	watcher := &dlt.Watcher{
+		Type:      *game,
+		Source:    src,
+		StorePath: path,
+		Tickets:   tickets,
+		Notifier:  notifiers,
+		Interval:  *interval,
+	}
+
+	if err := watcher.Run(ctx); err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/generate"
+)
+
+func runWheel(args []string) error {
+	fs := flag.NewFlagSet("wheel", flag.ExitOnError)
+	lotteryType := fs.String("type", "DLT", "彩票类型")
+	front := fs.String("front", "", "选中的前区号码，例如: 01,03,05,07,09,11,13,15,17,19")
+	back := fs.String("back", "", "后区号码，数量与单式票一致")
+	hit := fs.Int("hit", 5, "开出的选中号码个数")
+	match := fs.Int("match", 4, "保证命中的前区号码个数")
+	asJSON := fs.Bool("json", false, "以 JSON 格式输出")
+	fs.Parse(args)
+
+	options := generate.WheelOptions{Type: *lotteryType, Hit: *hit, Match: *match}
+
+	var err error
+
+	if options.Front, err = parseNums(*front); err != nil {
+		return err
+	}
+
+	if options.Back, err = parseNums(*back); err != nil {
+		return err
+	}
+
+	wheel, err := generate.NewWheel(options)
+	if err != nil {
+		return err
+	}
+
+	result, err := wheel.Check()
+	if err != nil {
+		return fmt.Errorf("旋转矩阵验证失败: %w", err)
+	}
+
+	if *asJSON {
+		var tickets []string
+
+		for _, lott := range wheel.List {
+			tickets = append(tickets, lott.String())
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(struct {
+			generate.Wheel
+			Guarantee string              `json:"guarantee"`
+			Check     generate.WheelCheck `json:"check"`
+			Tickets   []string            `json:"tickets"`
+		}{wheel, options.Guarantee(), result, tickets})
+	}
+
+	wheel.Print(os.Stdout)
+	fmt.Printf("验证: 穷举%d种开奖组合，最少命中%d个前区号码，后区全中时最低中奖等级: %s\n", result.Draws, result.WorstMatch, lottery.GetLevelLabel(result.WorstLevel))
+
+	return nil
+}
@@ -4,7 +4,10 @@ go 1.24.2
 
 require (
 	github.com/fatih/color v1.18.0
+	golang.org/x/sys v0.25.0
+)
+
+require (
 	github.com/mattn/go-colorable v0.1.13 // indirect
 	github.com/mattn/go-isatty v0.0.20 // indirect
-	golang.org/x/sys v0.25.0 // indirect
 )
@@ -0,0 +1,220 @@
+package backtest
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 投注策略，根据当期之前的开奖历史生成当期投注的彩票
+type Strategy interface {
+	GetTickets(issue int, history []lottery.Lottery) ([]lottery.Lottery, error)
+}
+
+// 函数形式的投注策略
+type StrategyFunc func(issue int, history []lottery.Lottery) ([]lottery.Lottery, error)
+
+// 固定投注策略，每期投注相同的彩票
+type FixedStrategy []lottery.Lottery
+
+// 回测参数
+type Options struct {
+	From int // 起始期号，为0时从最早一期开始
+	To   int // 结束期号，为0时到最新一期结束
+}
+
+// 单期回测结果
+type IssueResult struct {
+	Issue  int `json:"issue"`  // 期号
+	Cost   int `json:"cost"`   // 投注金额
+	Return int `json:"return"` // 中奖金额
+	Level  int `json:"level"`  // 最高中奖等级
+	Profit int `json:"profit"` // 截至当期的累计盈亏
+}
+
+// 回测报告
+type Report struct {
+	From                int           `json:"from"`                // 起始期号
+	To                  int           `json:"to"`                  // 结束期号
+	Issues              int           `json:"issues"`              // 回测期数
+	Bets                int           `json:"bets"`                // 总注数，包含倍投
+	Cost                int           `json:"cost"`                // 总投注金额
+	Return              int           `json:"return"`              // 总中奖金额
+	Profit              int           `json:"profit"`              // 总盈亏
+	ROI                 float64       `json:"roi"`                 // 投资回报率，(总中奖金额 - 总投注金额) / 总投注金额
+	MaxDrawdown         int           `json:"maxDrawdown"`         // 最大回撤，累计盈亏从最高点到之后最低点的最大跌幅
+	LevelHits           map[int]int   `json:"levelHits"`           // 各中奖等级的中奖注数，包含倍投
+	LongestLosingStreak int           `json:"longestLosingStreak"` // 最长连续未中奖期数
+	Results             []IssueResult `json:"results"`             // 每期结果，从旧到新排列
+}
+
+func (f StrategyFunc) GetTickets(issue int, history []lottery.Lottery) ([]lottery.Lottery, error) {
+	return f(issue, history)
+}
+
+func (s FixedStrategy) GetTickets(issue int, history []lottery.Lottery) ([]lottery.Lottery, error) {
+	return s, nil
+}
+
+// NewFixedStrategy
+//
+// @Description 通过彩票字符串创建固定投注策略
+//
+// @Param inputs []string 彩票字符串列表
+//
+// @Return FixedStrategy 固定投注策略
+//
+// @Return error 错误信息
+func NewFixedStrategy(inputs []string) (FixedStrategy, error) {
+	var strategy FixedStrategy
+
+	for _, input := range inputs {
+		lott, err := lottery.GetLottery(input)
+		if err != nil {
+			return nil, err
+		}
+
+		strategy = append(strategy, lott)
+	}
+
+	return strategy, nil
+}
+
+// Run
+//
+// @Description 在开奖历史上回放投注策略，逐期核对彩票并统计收益，彩票类型与开奖历史的类型不一致时返回错误
+//
+// @Param draws []lottery.Lottery 开奖号码列表，顺序不限，传给策略的历史按从新到旧排列
+//
+// @Param strategy Strategy 投注策略
+//
+// @Param options Options 回测参数
+//
+// @Return Report 回测报告
+//
+// @Return error 错误信息
+func Run(draws []lottery.Lottery, strategy Strategy, options Options) (Report, error) {
+	report := Report{LevelHits: make(map[int]int)}
+
+	// 从新到旧排列，第 i 期之前的历史为 sorted[i+1:]
+	sorted := append([]lottery.Lottery{}, draws...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Index > sorted[j].Index
+	})
+
+	for _, draw := range sorted {
+		if draw.Type != sorted[0].Type {
+			return report, fmt.Errorf("开奖历史的彩票类型不一致: %s, %s", sorted[0].Type, draw.Type)
+		}
+	}
+
+	var (
+		peak   int
+		streak int
+	)
+
+	for i := len(sorted) - 1; i >= 0; i-- {
+		draw := sorted[i]
+
+		if (options.From > 0 && draw.Index < options.From) || (options.To > 0 && draw.Index > options.To) {
+			continue
+		}
+
+		tickets, err := strategy.GetTickets(draw.Index, sorted[i+1:])
+		if err != nil {
+			return report, fmt.Errorf("期号 %d 生成彩票失败: %w", draw.Index, err)
+		}
+
+		issueResult := IssueResult{Issue: draw.Index}
+
+		for _, ticket := range tickets {
+			if ticket.Type != draw.Type {
+				return report, fmt.Errorf("期号 %d 彩票类型 %s 与开奖历史的类型 %s 不一致: %s", draw.Index, ticket.Type, draw.Type, ticket.String())
+			}
+
+			result, err := ticket.GetLotteryResult(draw)
+			if err != nil {
+				return report, fmt.Errorf("期号 %d 核对失败: %w", draw.Index, err)
+			}
+
+			issueResult.Cost += ticket.GetCost()
+			issueResult.Return += result.Price
+			report.Bets += ticket.GetBetCount() * max(ticket.Scale, 1)
+
+			if result.Level > 0 && (issueResult.Level == 0 || result.Level < issueResult.Level) {
+				issueResult.Level = result.Level
+			}
+
+			for _, count := range result.GetLevelCounts() {
+				report.LevelHits[count.Level] += count.Count
+			}
+		}
+
+		if report.Issues == 0 {
+			report.From = draw.Index
+		}
+
+		report.To = draw.Index
+		report.Issues++
+		report.Cost += issueResult.Cost
+		report.Return += issueResult.Return
+		report.Profit = report.Return - report.Cost
+		issueResult.Profit = report.Profit
+
+		peak = max(peak, report.Profit)
+		report.MaxDrawdown = max(report.MaxDrawdown, peak-report.Profit)
+
+		if issueResult.Return == 0 {
+			streak++
+			report.LongestLosingStreak = max(report.LongestLosingStreak, streak)
+		} else {
+			streak = 0
+		}
+
+		report.Results = append(report.Results, issueResult)
+	}
+
+	if report.Issues == 0 {
+		return report, errors.New("期号范围内没有开奖数据")
+	}
+
+	if report.Cost > 0 {
+		report.ROI = float64(report.Profit) / float64(report.Cost)
+	}
+
+	return report, nil
+}
+
+// Print
+//
+// @Description 输出回测报告
+//
+// @Param w io.Writer 输出
+func (report *Report) Print(w io.Writer) {
+	var levels []int
+
+	for level := range report.LevelHits {
+		levels = append(levels, level)
+	}
+
+	sort.Ints(levels)
+
+	var hits []string
+
+	for _, level := range levels {
+		hits = append(hits, fmt.Sprintf("%s×%d", lottery.GetLevelLabel(level), report.LevelHits[level]))
+	}
+
+	if len(hits) == 0 {
+		hits = append(hits, "无")
+	}
+
+	fmt.Fprintf(w, "期号范围: %d ~ %d，共%d期，%d注\n", report.From, report.To, report.Issues, report.Bets)
+	fmt.Fprintf(w, "投注金额: %d，中奖金额: %d，盈亏: %d，回报率: %.2f%%\n", report.Cost, report.Return, report.Profit, report.ROI*100)
+	fmt.Fprintf(w, "最大回撤: %d，最长连续未中奖: %d期\n", report.MaxDrawdown, report.LongestLosingStreak)
+	fmt.Fprintf(w, "中奖分布: %s\n", strings.Join(hits, ", "))
+}
@@ -0,0 +1,127 @@
+package backtest
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+func getDraws(t *testing.T, inputs ...string) []lottery.Lottery {
+	var draws []lottery.Lottery
+
+	for _, input := range inputs {
+		draw, err := lottery.GetLottery(input)
+		if err != nil {
+			t.Fatalf("解析失败: %s", err)
+		}
+
+		draws = append(draws, draw)
+	}
+
+	return draws
+}
+
+func TestRun(t *testing.T) {
+	draws := getDraws(t,
+		"DLT:01,02,03,06,07-01,02:25004",
+		"DLT:11,12,13,14,15-05,06:25003",
+		"DLT:06,07,08,09,10-03,04:25002",
+		"DLT:01,02,03,04,05-01,02:25001",
+	)
+
+	tests := []struct {
+		name    string
+		tickets []string
+		options Options
+		report  Report
+	}{
+		{"单式全部期号", []string{"DLT:01,02,03,04,05-01,02"}, Options{}, Report{
+			From: 25001, To: 25004, Issues: 4, Bets: 4, Cost: 8, Return: 10000200, Profit: 10000192, ROI: 10000192.0 / 8,
+			MaxDrawdown: 4, LevelHits: map[int]int{1: 1, 6: 1}, LongestLosingStreak: 2,
+			Results: []IssueResult{{25001, 2, 10000000, 1, 9999998}, {25002, 2, 0, 0, 9999996}, {25003, 2, 0, 0, 9999994}, {25004, 2, 200, 6, 10000192}},
+		}},
+		{"复式倍投指定期号", []string{"DLT:01,02,03,04,05,06-01,02x2"}, Options{From: 25002, To: 25004}, Report{
+			From: 25002, To: 25004, Issues: 3, Bets: 36, Cost: 72, Return: 13600, Profit: 13528, ROI: 13528.0 / 72,
+			MaxDrawdown: 48, LevelHits: map[int]int{4: 4, 6: 8}, LongestLosingStreak: 2,
+			Results: []IssueResult{{25002, 24, 0, 0, -24}, {25003, 24, 0, 0, -48}, {25004, 24, 13600, 4, 13528}},
+		}},
+		{"多张彩票", []string{"DLT:11,12,13,14,15-05,06", "DLT:06,07,08,09,10-03,04"}, Options{To: 25003}, Report{
+			From: 25001, To: 25003, Issues: 3, Bets: 6, Cost: 12, Return: 20000000, Profit: 19999988, ROI: 19999988.0 / 12,
+			MaxDrawdown: 4, LevelHits: map[int]int{1: 2}, LongestLosingStreak: 1,
+			Results: []IssueResult{{25001, 4, 0, 0, -4}, {25002, 4, 10000000, 1, 9999992}, {25003, 4, 10000000, 1, 19999988}},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			strategy, err := NewFixedStrategy(tt.tickets)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			report, err := Run(draws, strategy, tt.options)
+
+			if err != nil {
+				t.Errorf("错误信息: %s", err)
+			} else if !reflect.DeepEqual(report, tt.report) {
+				t.Errorf("期望: %+v, 实际: %+v", tt.report, report)
+			}
+		})
+	}
+
+	if _, err := Run(draws, FixedStrategy{}, Options{From: 26001}); err == nil {
+		t.Errorf("期号范围内没有开奖数据时应该失败")
+	}
+
+	for name, tickets := range map[string][]string{
+		"双色球": {"DLT:01,02,03,04,05-01,02", "SSQ:01,02,03,04,05,06-01"},
+		"七乐彩": {"QLC:01,02,03,04,05,06,07"},
+	} {
+		strategy, err := NewFixedStrategy(tickets)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := Run(draws, strategy, Options{}); err == nil {
+			t.Errorf("%s彩票与大乐透开奖历史的类型不一致时应该失败", name)
+		}
+	}
+
+	if _, err := Run(append(draws, getDraws(t, "SSQ:01,02,03,04,05,06-01:2025001")...), FixedStrategy{}, Options{}); err == nil {
+		t.Errorf("开奖历史的彩票类型不一致时应该失败")
+	}
+}
+
+func TestRunStrategyFunc(t *testing.T) {
+	draws := getDraws(t,
+		"DLT:01,02,03,04,05-01,02:25001",
+		"DLT:01,02,03,04,06-01,03:25002",
+		"DLT:11,12,13,14,15-05,06:25003",
+	)
+
+	// 每期投注上一期的开奖号码
+	strategy := StrategyFunc(func(issue int, history []lottery.Lottery) ([]lottery.Lottery, error) {
+		for i, draw := range history {
+			if draw.Index >= issue || (i > 0 && draw.Index > history[i-1].Index) {
+				t.Errorf("期号 %d 的历史数据错误: %+v", issue, history)
+			}
+		}
+
+		if len(history) == 0 {
+			return nil, nil
+		}
+
+		return []lottery.Lottery{history[0]}, nil
+	})
+
+	report, err := Run(draws, strategy, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 25002 投注 25001 的号码，前区4个后区1个，五等奖300
+	if report.Cost != 4 || report.Return != 300 || report.LevelHits[5] != 1 || report.LongestLosingStreak != 1 {
+		t.Errorf("回测结果错误: %+v", report)
+	}
+}
@@ -0,0 +1,385 @@
+package book
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 单期核对结果
+type IssueResult struct {
+	Issue       int                   `json:"issue"`                 // 期号
+	Level       int                   `json:"level"`                 // 最高中奖等级，0为未中奖
+	Price       int                   `json:"price"`                 // 中奖金额
+	Claimed     bool                  `json:"claimed"`               // 是否已兑奖
+	ClaimedDate string                `json:"claimedDate,omitempty"` // 兑奖日期
+	Result      lottery.LotteryResult `json:"result"`                // 核对结果
+}
+
+// 购彩记录
+type Entry struct {
+	ID      int           `json:"id"`                // 编号
+	Ticket  string        `json:"ticket"`            // 彩票字符串
+	Date    string        `json:"date"`              // 购买日期
+	Channel string        `json:"channel,omitempty"` // 购买渠道
+	From    int           `json:"from"`              // 起始期号
+	To      int           `json:"to"`                // 结束期号，与起始期号相同时为单期
+	Cost    int           `json:"cost"`              // 投注金额
+	Owner   string        `json:"owner,omitempty"`   // 购买人
+	Note    string        `json:"note,omitempty"`    // 备注
+	Results []IssueResult `json:"results,omitempty"` // 已开奖期的核对结果，按期号从旧到新排列
+}
+
+// 购彩记录本，对应 JSON 文件
+type Book struct {
+	UpdateTime string  `json:"updateTime"`
+	NextID     int     `json:"nextId"`
+	Entries    []Entry `json:"entries"`
+}
+
+// LoadBook
+//
+// @Description 读取购彩记录本文件，文件不存在时返回空的记录本
+//
+// @Param path string 文件路径
+//
+// @Return Book 购彩记录本
+//
+// @Return error 错误信息
+func LoadBook(path string) (Book, error) {
+	book := Book{NextID: 1}
+
+	jsonData, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return book, nil
+	} else if err != nil {
+		return book, fmt.Errorf("文件读取失败: %w", err)
+	}
+
+	if err := json.Unmarshal(jsonData, &book); err != nil {
+		return book, fmt.Errorf("json解析失败: %w", err)
+	}
+
+	return book, nil
+}
+
+// Save
+//
+// @Description 将购彩记录本写入文件
+//
+// @Param path string 文件路径
+//
+// @Return error 错误信息
+func (book *Book) Save(path string) error {
+	jsonData, err := json.MarshalIndent(book, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json解析失败: %w", err)
+	}
+
+	if err := os.WriteFile(path, jsonData, 0644); err != nil {
+		return fmt.Errorf("文件写入失败: %w", err)
+	}
+
+	return nil
+}
+
+// Add
+//
+// @Description 添加购彩记录。未指定期号时使用彩票中的期号，未指定金额时按注数、倍数和期数计算
+//
+// @Param entry Entry 购彩记录，编号和核对结果会被忽略
+//
+// @Return Entry 添加后的购彩记录
+//
+// @Return error 错误信息
+func (book *Book) Add(entry Entry) (Entry, error) {
+	lott, err := lottery.GetLottery(entry.Ticket)
+	if err != nil {
+		return entry, err
+	}
+
+	rule, err := lottery.GetGameRule(lott.Type)
+	if err != nil {
+		return entry, err
+	}
+
+	if err := rule.Check(lott.LotteryParts); err != nil {
+		return entry, err
+	}
+
+	if entry.From == 0 {
+		entry.From = lott.Index
+	}
+
+	if entry.To == 0 {
+		entry.To = entry.From
+	}
+
+	if entry.From == 0 {
+		return entry, errors.New("缺少期号，请在彩票中指定期号或者指定起始期号")
+	}
+
+	if entry.To < entry.From {
+		return entry, fmt.Errorf("结束期号 %d 小于起始期号 %d", entry.To, entry.From)
+	}
+
+	if lott.Index != 0 && (lott.Index < entry.From || lott.Index > entry.To) {
+		return entry, fmt.Errorf("彩票期号 %d 不在期号范围 %d ~ %d 内", lott.Index, entry.From, entry.To)
+	}
+
+	if entry.Cost == 0 {
+		count := getIssueCount(entry.From, entry.To)
+		if count == 0 {
+			return entry, errors.New("跨年的期号范围无法计算投注金额，请指定投注金额")
+		}
+
+		entry.Cost = lott.GetCost() * count
+	}
+
+	book.NextID = max(book.NextID, 1)
+	entry.ID = book.NextID
+	entry.Ticket = lott.String()
+	entry.Results = nil
+	book.NextID++
+	book.Entries = append(book.Entries, entry)
+
+	return entry, nil
+}
+
+// getIssueCount
+//
+// @Description 获取期号范围内的期数，每年的期数不固定，跨年时无法计算，返回0
+//
+// @Param from int 起始期号
+//
+// @Param to int 结束期号
+//
+// @Return int 期数
+func getIssueCount(from, to int) int {
+	if from/1000 != to/1000 {
+		return 0
+	}
+
+	return to - from + 1
+}
+
+// Remove
+//
+// @Description 删除购彩记录
+//
+// @Param id int 编号
+//
+// @Return error 错误信息
+func (book *Book) Remove(id int) error {
+	for i, entry := range book.Entries {
+		if entry.ID == id {
+			book.Entries = append(book.Entries[:i], book.Entries[i+1:]...)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("购彩记录不存在: %d", id)
+}
+
+// getEntry
+//
+// @Description 获取购彩记录
+//
+// @Param id int 编号
+//
+// @Return *Entry 购彩记录
+//
+// @Return error 错误信息
+func (book *Book) getEntry(id int) (*Entry, error) {
+	for i := range book.Entries {
+		if book.Entries[i].ID == id {
+			return &book.Entries[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("购彩记录不存在: %d", id)
+}
+
+// Check
+//
+// @Description 用开奖号码核对购彩记录，已核对过的期号不会重复核对
+//
+// @Param draws []lottery.Lottery 开奖号码列表，顺序不限
+//
+// @Return int 新核对的期数
+//
+// @Return error 错误信息
+func (book *Book) Check(draws []lottery.Lottery) (int, error) {
+	checked := 0
+
+	for i := range book.Entries {
+		entry := &book.Entries[i]
+
+		lott, err := lottery.GetLottery(entry.Ticket)
+		if err != nil {
+			return checked, fmt.Errorf("购彩记录 %d: %w", entry.ID, err)
+		}
+
+		issueMap := make(map[int]bool, len(entry.Results))
+		for _, result := range entry.Results {
+			issueMap[result.Issue] = true
+		}
+
+		for _, draw := range draws {
+			if draw.Type != lott.Type || draw.Index < entry.From || draw.Index > entry.To || issueMap[draw.Index] {
+				continue
+			}
+
+			result, err := lott.GetLotteryResult(draw)
+			if err != nil {
+				return checked, fmt.Errorf("购彩记录 %d: %w", entry.ID, err)
+			}
+
+			issueMap[draw.Index] = true
+			entry.Results = append(entry.Results, IssueResult{
+				Issue:  draw.Index,
+				Level:  result.Level,
+				Price:  result.Price,
+				Result: result,
+			})
+			checked++
+		}
+
+		sort.Slice(entry.Results, func(i, j int) bool {
+			return entry.Results[i].Issue < entry.Results[j].Issue
+		})
+	}
+
+	return checked, nil
+}
+
+// Claim
+//
+// @Description 将购彩记录某一期的中奖结果标记为已兑奖
+//
+// @Param id int 编号
+//
+// @Param issue int 期号
+//
+// @Param date string 兑奖日期
+//
+// @Return error 错误信息
+func (book *Book) Claim(id, issue int, date string) error {
+	entry, err := book.getEntry(id)
+	if err != nil {
+		return err
+	}
+
+	for i := range entry.Results {
+		result := &entry.Results[i]
+
+		if result.Issue != issue {
+			continue
+		}
+
+		if result.Price == 0 {
+			return fmt.Errorf("购彩记录 %d 第%d期未中奖", id, issue)
+		}
+
+		result.Claimed = true
+		result.ClaimedDate = date
+
+		return nil
+	}
+
+	return fmt.Errorf("购彩记录 %d 第%d期尚未核对", id, issue)
+}
+
+// GetUnclaimed
+//
+// @Description 获取未兑奖的中奖金额
+//
+// @Return int 未兑奖金额
+func (entry *Entry) GetUnclaimed() int {
+	total := 0
+
+	for _, result := range entry.Results {
+		if result.Price > 0 && !result.Claimed {
+			total += result.Price
+		}
+	}
+
+	return total
+}
+
+// GetPrice
+//
+// @Description 获取全部已核对期的中奖金额
+//
+// @Return int 中奖金额
+func (entry *Entry) GetPrice() int {
+	total := 0
+
+	for _, result := range entry.Results {
+		total += result.Price
+	}
+
+	return total
+}
+
+// getStatus
+//
+// @Description 获取购彩记录的状态，多期的记录在全部期号核对完之前为部分开奖，有未兑奖的中奖金额时优先显示未兑奖
+//
+// @Return string 状态
+func (entry *Entry) getStatus() string {
+	switch {
+	case len(entry.Results) == 0:
+		return "未开奖"
+	case entry.GetUnclaimed() > 0:
+		return "未兑奖"
+	case entry.Results[len(entry.Results)-1].Issue < entry.To:
+		return "部分开奖"
+	case entry.GetPrice() > 0:
+		return "已兑奖"
+	default:
+		return "未中奖"
+	}
+}
+
+// Print
+//
+// @Description 以表格形式输出购彩记录
+//
+// @Param w io.Writer 输出
+//
+// @Param entries []Entry 购彩记录
+func Print(w io.Writer, entries []Entry) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "编号\t彩票\t期号\t购买日期\t渠道\t购买人\t金额\t中奖\t未兑奖\t状态\t备注")
+
+	for _, entry := range entries {
+		issue := fmt.Sprint(entry.From)
+		if entry.To != entry.From {
+			issue += fmt.Sprintf("~%d", entry.To)
+		}
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%d\t%d\t%d\t%s\t%s\n", entry.ID, entry.Ticket, issue, entry.Date, entry.Channel, entry.Owner, entry.Cost, entry.GetPrice(), entry.GetUnclaimed(), entry.getStatus(), entry.Note)
+
+		for _, result := range entry.Results {
+			if result.Price == 0 {
+				continue
+			}
+
+			claimed := "未兑奖"
+			if result.Claimed {
+				claimed = "已兑奖 " + result.ClaimedDate
+			}
+
+			fmt.Fprintf(tw, "\t  第%d期\t%s\t\t\t\t\t%d\t\t%s\t\n", result.Issue, lottery.GetLevelLabel(result.Level), result.Price, claimed)
+		}
+	}
+
+	tw.Flush()
+}
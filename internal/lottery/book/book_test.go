@@ -0,0 +1,171 @@
+package book
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+func getDraws(t *testing.T, inputs ...string) []lottery.Lottery {
+	var draws []lottery.Lottery
+
+	for _, input := range inputs {
+		draw, err := lottery.GetLottery(input)
+		if err != nil {
+			t.Fatalf("开奖号码解析失败: %s", err)
+		}
+
+		draws = append(draws, draw)
+	}
+
+	return draws
+}
+
+func TestBookAdd(t *testing.T) {
+	tests := []struct {
+		name  string
+		entry Entry
+		from  int
+		to    int
+		cost  int
+	}{
+		{"彩票期号", Entry{Ticket: "DLT:02,04,11,29,31-02,08:25053"}, 25053, 25053, 2},
+		{"期号范围", Entry{Ticket: "DLT:01,02,03,04,05,06-01,02x2", From: 25050, To: 25053}, 25050, 25053, 96},
+		{"指定金额", Entry{Ticket: "SSQ:01,02,03,04,05,06-16", From: 25140, To: 26002, Cost: 100}, 25140, 26002, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var book Book
+
+			entry, err := book.Add(tt.entry)
+			if err != nil {
+				t.Fatalf("错误信息: %s", err)
+			}
+
+			if entry.ID != 1 || entry.From != tt.from || entry.To != tt.to || entry.Cost != tt.cost {
+				t.Errorf("购彩记录错误: %+v", entry)
+			}
+		})
+	}
+
+	errTests := []struct {
+		name  string
+		entry Entry
+	}{
+		{"彩票错误", Entry{Ticket: "DLT:01,02,03,04-01,02:25053"}},
+		{"缺少期号", Entry{Ticket: "DLT:01,02,03,04,05-01,02"}},
+		{"期号范围错误", Entry{Ticket: "DLT:01,02,03,04,05-01,02", From: 25053, To: 25050}},
+		{"彩票期号不在范围内", Entry{Ticket: "DLT:01,02,03,04,05-01,02:25060", From: 25050, To: 25053}},
+		{"跨年未指定金额", Entry{Ticket: "DLT:01,02,03,04,05-01,02", From: 25150, To: 26002}},
+	}
+
+	for _, tt := range errTests {
+		t.Run(tt.name, func(t *testing.T) {
+			var book Book
+
+			if _, err := book.Add(tt.entry); err == nil {
+				t.Errorf("应该失败")
+			}
+		})
+	}
+}
+
+func TestBookCheck(t *testing.T) {
+	var book Book
+
+	book.Add(Entry{Ticket: "DLT:02,04,11,29,31-02,08", From: 25052, To: 25054, Owner: "张三"})
+	book.Add(Entry{Ticket: "DLT:01,03,05,06,07-01,03:25053"})
+
+	draws := getDraws(t, "DLT:02,04,11,29,30-02,08:25053", "DLT:01,02,03,04,05-03,04:25052", "DLT:01,02,03,04,05-01,02:25051")
+
+	checked, err := book.Check(draws)
+	if err != nil {
+		t.Fatalf("错误信息: %s", err)
+	}
+
+	if checked != 3 {
+		t.Errorf("期望核对: 3, 实际: %d", checked)
+	}
+
+	if checked, _ := book.Check(draws); checked != 0 {
+		t.Errorf("重复核对: %d", checked)
+	}
+
+	first := book.Entries[0]
+
+	if len(first.Results) != 2 || first.Results[0].Issue != 25052 || first.Results[1].Level != 4 || first.GetPrice() != 3000 || first.GetUnclaimed() != 3000 {
+		t.Errorf("核对结果错误: %+v", first.Results)
+	}
+
+	if status := first.getStatus(); status != "未兑奖" {
+		t.Errorf("期望: 未兑奖, 实际: %s", status)
+	}
+
+	if status := book.Entries[1].getStatus(); status != "未中奖" {
+		t.Errorf("期望: 未中奖, 实际: %s", status)
+	}
+
+	if err := book.Claim(1, 25052, "2025-05-14"); err == nil {
+		t.Errorf("未中奖的期号应该不能兑奖")
+	}
+
+	if err := book.Claim(1, 25054, "2025-05-14"); err == nil {
+		t.Errorf("未核对的期号应该不能兑奖")
+	}
+
+	if err := book.Claim(1, 25053, "2025-05-14"); err != nil {
+		t.Errorf("错误信息: %s", err)
+	}
+
+	// 25054 期还没有开奖
+	if first := book.Entries[0]; first.GetUnclaimed() != 0 || first.getStatus() != "部分开奖" {
+		t.Errorf("兑奖失败: %s %+v", first.getStatus(), first.Results)
+	}
+
+	if _, err := book.Check(getDraws(t, "DLT:01,02,03,04,05-03,04:25054")); err != nil {
+		t.Fatalf("错误信息: %s", err)
+	}
+
+	if status := book.Entries[0].getStatus(); status != "已兑奖" {
+		t.Errorf("期望: 已兑奖, 实际: %s", status)
+	}
+}
+
+func TestBookSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "book.json")
+
+	book, err := LoadBook(path)
+	if err != nil {
+		t.Fatalf("错误信息: %s", err)
+	}
+
+	book.Add(Entry{Ticket: "DLT:02,04,11,29,31-02,08:25053", Channel: "体彩店"})
+	book.Add(Entry{Ticket: "DLT:01,02,03,04,05-01,02:25053"})
+
+	if err := book.Remove(1); err != nil {
+		t.Errorf("错误信息: %s", err)
+	}
+
+	if err := book.Remove(1); err == nil {
+		t.Errorf("删除不存在的记录应该失败")
+	}
+
+	if err := book.Save(path); err != nil {
+		t.Fatalf("错误信息: %s", err)
+	}
+
+	loaded, err := LoadBook(path)
+	if err != nil {
+		t.Fatalf("错误信息: %s", err)
+	}
+
+	if len(loaded.Entries) != 1 || loaded.Entries[0].ID != 2 {
+		t.Errorf("读取结果错误: %+v", loaded.Entries)
+	}
+
+	if entry, _ := loaded.Add(Entry{Ticket: "DLT:01,02,03,04,05-01,02:25054"}); entry.ID != 3 {
+		t.Errorf("期望编号: 3, 实际: %d", entry.ID)
+	}
+}
@@ -1,10 +1,11 @@
 package lottery
 
 import (
-	"errors"
 	"fmt"
+	"slices"
 	"sort"
 	"strconv"
+	"strings"
 
 	"github.com/fatih/color"
 )
@@ -88,12 +89,12 @@ func parseNumParts(input string) ([]int, []int, error) {
 	switchNextNumberType := func(next string) error {
 		if next == "tuo" {
 			if nextNumberType != "dan" {
-				return fmt.Errorf("号码区解析错误。拖区重复。输入: %s", input)
+				return NewError(ErrSyntax, "zone.duplicate_tilde", input)
 			}
 
 			nextNumberType = "tuo"
 		} else {
-			return fmt.Errorf("号码区解析错误，不支持的状态流转: %s。输入: %s", next, input)
+			return NewError(ErrSyntax, "zone.transition", next, input)
 		}
 
 		return nil
@@ -101,13 +102,13 @@ func parseNumParts(input string) ([]int, []int, error) {
 
 	dealNumber := func() error {
 		if len(str) < 1 {
-			return fmt.Errorf("号码至少为1位")
+			return NewError(ErrInvalidNumber, "zone.number_empty")
 		}
 
 		num, err := strconv.Atoi(str)
 
 		if err != nil {
-			return fmt.Errorf("号码解析失败，错误信息: %s", err)
+			return NewError(ErrInvalidNumber, "zone.number_invalid", err.Error())
 		}
 
 		switch nextNumberType {
@@ -116,7 +117,7 @@ func parseNumParts(input string) ([]int, []int, error) {
 		case "tuo":
 			tuo = append(tuo, num)
 		default:
-			return fmt.Errorf("号码解析失败，错误的类型: %s", nextNumberType)
+			return NewError(ErrSyntax, "zone.number_type", nextNumberType)
 		}
 
 		// 号码处理完成后清除缓冲区
@@ -127,31 +128,26 @@ func parseNumParts(input string) ([]int, []int, error) {
 
 	check := func() error {
 		if len(dan) == 0 {
-			return fmt.Errorf("号码区解析失败。输入: %s", input)
+			return NewError(ErrSyntax, "zone.empty", input)
 		}
 
 		if len(tuo) == 0 {
 			tuo, dan = dan, tuo
 		}
 
-		dupMsg := ""
+		dupDan := GetDupNums(dan)
+		dupTuo := GetDupNums(tuo)
 
-		if dupDan := GetDupNums(dan); len(dupDan) > 0 {
-			dupMsg += fmt.Sprintf("胆码区重复: %v。", dupDan)
-		}
-
-		if dupTuo := GetDupNums(tuo); len(dupTuo) > 0 {
-			dupMsg += fmt.Sprintf("拖码区重复: %v。", dupTuo)
-		}
-
-		if len(dupMsg) > 0 {
-			dupMsg = "号码区解析失败。" + dupMsg
-
-			return errors.New(dupMsg)
+		if len(dupDan) > 0 && len(dupTuo) > 0 {
+			return NewError(ErrDuplicateNumber, "zone.duplicate_both", dupDan, dupTuo)
+		} else if len(dupDan) > 0 {
+			return NewError(ErrDuplicateNumber, "zone.duplicate_dan", dupDan)
+		} else if len(dupTuo) > 0 {
+			return NewError(ErrDuplicateNumber, "zone.duplicate_tuo", dupTuo)
 		}
 
 		if cross := GetCrossNums(dan, tuo); len(cross) > 0 {
-			return fmt.Errorf("号码区解析失败。拖码区冲突: %v。", cross)
+			return NewError(ErrDanTuoConflict, "zone.conflict", cross)
 		}
 
 		return nil
@@ -164,7 +160,7 @@ func parseNumParts(input string) ([]int, []int, error) {
 
 			// 缓冲字符长度超过2抛错
 			if len(str) > 2 {
-				return nil, nil, errors.New("号码最多为2位数")
+				return nil, nil, NewError(ErrInvalidNumber, "zone.number_too_long")
 			}
 
 			continue
@@ -189,7 +185,7 @@ func parseNumParts(input string) ([]int, []int, error) {
 			continue
 		}
 
-		return nil, nil, fmt.Errorf("号码区解析失败，错误的字符: 【%c】。输入: %s", char, input)
+		return nil, nil, NewError(ErrSyntax, "zone.char", char, input)
 	}
 
 	if err := dealNumber(); err != nil {
@@ -213,7 +209,7 @@ func parseNumParts(input string) ([]int, []int, error) {
 //
 // @Return error 错误信息
 func parseLotteryParts(input string) (LotteryParts, error) {
-	nextTokenType := "type" // type -> front -> back -> scale | index -> index | scale
+	nextTokenType := "type" // type -> front -> back -> scale | index -> index | scale，没有后区的彩票为 front -> scale | index
 	lotteryParts := LotteryParts{}
 	lotteryParts.Scale = 1
 
@@ -224,7 +220,7 @@ func parseLotteryParts(input string) (LotteryParts, error) {
 	)
 
 	switchNextTokenType := func(next string) error {
-		commonErrorMsg := fmt.Errorf("解析失败。错误的token类型: %s, 当前token类型: %s。输入: %s", next, nextTokenType, input)
+		commonErrorMsg := NewError(ErrSyntax, "parse.token", next, nextTokenType, input)
 
 		switch next {
 		case "front":
@@ -237,18 +233,18 @@ func parseLotteryParts(input string) (LotteryParts, error) {
 			}
 		case "scale":
 			if scaleParsed {
-				return fmt.Errorf("解析失败。倍投已解析过。输入: %s", input)
+				return NewError(ErrInvalidScale, "parse.duplicate_scale", input)
 			}
 
-			if !(nextTokenType == "back" || nextTokenType == "index") {
+			if !(nextTokenType == "back" || nextTokenType == "index" || nextTokenType == "front") {
 				return commonErrorMsg
 			}
 		case "index":
 			if indexParsed {
-				return fmt.Errorf("解析失败。期号已解析过。输入: %s", input)
+				return NewError(ErrInvalidIndex, "parse.duplicate_index", input)
 			}
 
-			if !(nextTokenType == "back" || nextTokenType == "scale") {
+			if !(nextTokenType == "back" || nextTokenType == "scale" || nextTokenType == "front") {
 				return commonErrorMsg
 			}
 		}
@@ -264,16 +260,16 @@ func parseLotteryParts(input string) (LotteryParts, error) {
 
 		switch tokenType {
 		case "type":
-			if tmpToken == "SSQ" || tmpToken == "DLT" {
+			if _, ok := gameRules[tmpToken]; ok {
 				lotteryParts.Type = tmpToken
 			} else {
-				return fmt.Errorf("彩票类型解析失败。不支持的彩票类型: %s。输入: %s", tmpToken, input)
+				return NewError(ErrUnknownType, "parse.unknown_type", tmpToken, input)
 			}
 		case "front":
 			dan, tuo, err := parseNumParts(tmpToken)
 
 			if err != nil {
-				return fmt.Errorf("前区号码解析失败。原因: %s。输入: %s", err, input)
+				return NewError("", "parse.front", err, input)
 			} else {
 				lotteryParts.FrontDan = dan
 				lotteryParts.FrontTuo = tuo
@@ -282,7 +278,7 @@ func parseLotteryParts(input string) (LotteryParts, error) {
 			dan, tuo, err := parseNumParts(tmpToken)
 
 			if err != nil {
-				return fmt.Errorf("后区号码解析失败。原因: %s。输入: %s", err, input)
+				return NewError("", "parse.back", err, input)
 			} else {
 				lotteryParts.BackDan = dan
 				lotteryParts.BackTuo = tuo
@@ -291,7 +287,7 @@ func parseLotteryParts(input string) (LotteryParts, error) {
 			scale, err := strconv.Atoi(tmpToken)
 
 			if err != nil {
-				return fmt.Errorf("倍投倍数解析失败。倍数: %s。输入: %s", tmpToken, input)
+				return NewError(ErrInvalidScale, "parse.scale", tmpToken, input)
 			} else {
 				lotteryParts.Scale = scale
 				scaleParsed = true
@@ -300,7 +296,7 @@ func parseLotteryParts(input string) (LotteryParts, error) {
 			index, err := strconv.Atoi(tmpToken)
 
 			if err != nil {
-				return fmt.Errorf("期号解析失败。期号: %s。输入: %s", tmpToken, input)
+				return NewError(ErrInvalidIndex, "parse.index", tmpToken, input)
 			} else {
 				lotteryParts.Index = index
 				indexParsed = true
@@ -338,15 +334,22 @@ func parseLotteryParts(input string) (LotteryParts, error) {
 			} else if len(token) < 5 && isUpperAlpha(char) {
 				appendToken(char)
 			} else {
-				return fmt.Errorf("彩票类型解析失败。输入: %s", input)
+				return NewError(ErrSyntax, "parse.type", input)
 			}
 		case "front":
+			// 没有后区的彩票在前区之后直接是倍投或期号
+			noBack := gameRules[lotteryParts.Type].BackSize == 0
+
 			if char == '-' {
 				return handleTransition("back")
+			} else if noBack && char == 'x' {
+				return handleTransition("scale")
+			} else if noBack && char == ':' {
+				return handleTransition("index")
 			} else if char == ',' || char == '~' || isDigit(char) {
 				appendToken(char)
 			} else {
-				return fmt.Errorf("前区号码解析失败。当前字符: 【%c】。输入: %s", char, input)
+				return NewError(ErrSyntax, "parse.front_char", char, input)
 			}
 		case "back":
 			if char == 'x' {
@@ -356,7 +359,7 @@ func parseLotteryParts(input string) (LotteryParts, error) {
 			} else if char == ',' || char == '~' || isDigit(char) {
 				appendToken(char)
 			} else {
-				return fmt.Errorf("后区号码解析失败。输入: %s", input)
+				return NewError(ErrSyntax, "parse.back_char", input)
 			}
 		case "scale":
 			if char == ':' {
@@ -364,7 +367,7 @@ func parseLotteryParts(input string) (LotteryParts, error) {
 			} else if isDigit(char) {
 				appendToken(char)
 			} else {
-				return fmt.Errorf("倍投数解析失败。当前字符: 【%c】。输入: %s", char, input)
+				return NewError(ErrInvalidScale, "parse.scale_char", char, input)
 			}
 		case "index":
 			if char == 'x' {
@@ -372,7 +375,7 @@ func parseLotteryParts(input string) (LotteryParts, error) {
 			} else if isDigit(char) {
 				appendToken(char)
 			} else {
-				return fmt.Errorf("期数解析失败。当前字符: 【%c】。输入: %s", char, input)
+				return NewError(ErrInvalidIndex, "parse.index_char", char, input)
 			}
 		}
 
@@ -432,6 +435,29 @@ func genPermutation(nums []int, n int) [][]int {
 	return result
 }
 
+// GetCombinations
+//
+// @Description 从列表中选取n个数字的所有组合，n 超出列表长度时没有组合，每个组合都是新的切片
+//
+// @Param nums []int 数字列表，组合内的数字保持列表中的顺序
+//
+// @Param n int 组合的长度
+//
+// @Return [][]int 组合列表
+func GetCombinations(nums []int, n int) [][]int {
+	if n < 0 || n > len(nums) {
+		return nil
+	}
+
+	var result [][]int
+
+	for _, combination := range genPermutation(nums, n) {
+		result = append(result, slices.Clone(combination))
+	}
+
+	return result
+}
+
 // genLotteryList
 //
 // @Description 生成单式彩票列表
@@ -450,14 +476,9 @@ func (parts *LotteryParts) genLotteryList() []Lottery {
 	backDan := parts.BackDan
 	backTuo := parts.BackTuo
 
-	// TODO: 考虑这里的逻辑是否可以拆到外面去
-	switch baseInfo.Type {
-	case "DLT":
-		frontList = genPermutation(frontTuo, 5-len(frontDan))
-		backList = genPermutation(backTuo, 2-len(backDan))
-	case "SSQ":
-		frontList = genPermutation(frontTuo, 6-len(frontDan))
-		backList = genPermutation(backTuo, 1-len(backDan))
+	if rule, err := GetGameRule(baseInfo.Type); err == nil {
+		frontList = genPermutation(frontTuo, rule.FrontSize-len(frontDan))
+		backList = genPermutation(backTuo, rule.BackSize-len(backDan))
 	}
 
 	for _, front := range frontList {
@@ -516,6 +537,28 @@ func getMatchNums(source []int, target []int) ([]BingoNum, int) {
 	return result, matched
 }
 
+// markSpecialNums
+//
+// @Description 标记命中开奖号码特别号的前区号码
+//
+// @Param nums []ResultNum 中奖结果号码
+//
+// @Param special []int 开奖号码的特别号
+//
+// @Return int 命中特别号的个数
+func markSpecialNums(nums []ResultNum, special []int) int {
+	matched := 0
+
+	for i, num := range nums {
+		if (num.Type == "FrontDan" || num.Type == "FrontTuo") && slices.Contains(special, num.Num) {
+			nums[i].Special = true
+			matched++
+		}
+	}
+
+	return matched
+}
+
 // isSingleLottery
 //
 // @Description 判断当前彩票是否是单式票
@@ -525,6 +568,68 @@ func (lott *Lottery) IsSingleLottery() bool {
 	return len(lott.List) == 0
 }
 
+// GetBetCount
+//
+// @Description 获取彩票包含的单式注数，不包含倍投
+//
+// @Return int 注数
+func (lott *Lottery) GetBetCount() int {
+	if lott.IsSingleLottery() {
+		return 1
+	}
+
+	return len(lott.List)
+}
+
+// CountBets
+//
+// @Description 在不展开单式票的情况下解析彩票字符串并计算注数，用于在展开大复式票之前限制注数
+//
+// @Param input string 彩票字符串
+//
+// @Return int 注数，不包含倍投
+//
+// @Return error 错误信息
+func CountBets(input string) (int, error) {
+	parts, err := parseLotteryParts(input)
+	if err != nil {
+		return 0, err
+	}
+
+	rule, err := GetGameRule(parts.Type)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := rule.Check(parts); err != nil {
+		return 0, err
+	}
+
+	combination := func(n, k int) int {
+		result := 1
+
+		for i := 1; i <= k; i++ {
+			result = result * (n - k + i) / i
+		}
+
+		return result
+	}
+
+	front := combination(len(parts.FrontTuo), rule.FrontSize-len(parts.FrontDan))
+	back := combination(len(parts.BackTuo), rule.BackSize-len(parts.BackDan))
+
+	return front * back, nil
+}
+
+// GetCost
+//
+// @Description 获取彩票的投注金额，注数 × 倍投倍数 × 单注价格
+//
+// @Return int 投注金额
+func (lott *Lottery) GetCost() int {
+	return lott.GetBetCount() * max(lott.Scale, 1) * BetPrice
+}
+
 // getLotteryResult
 //
 // @Description 获取彩票的开奖结果
@@ -538,11 +643,10 @@ func (source *Lottery) GetLotteryResult(target Lottery) (LotteryResult, error) {
 	var (
 		result LotteryResult
 		nums   []ResultNum
-		level  int
 	)
 
 	if !target.IsSingleLottery() {
-		return result, fmt.Errorf("开奖彩票不是单式票: %s", target.Format(true))
+		return result, NewError(ErrNotSingle, "lottery.not_single", target.Format(true))
 	}
 
 	result.LotteryBaseInfo = source.LotteryBaseInfo
@@ -560,71 +664,32 @@ func (source *Lottery) GetLotteryResult(target Lottery) (LotteryResult, error) {
 			nums = append(nums, ResultNum{Type: "BackTuo", BingoNum: num})
 		}
 
-		switch frontMatched {
-		case 5:
-			switch backMatched {
-			case 2:
-				level = 1
-			case 1:
-				level = 2
-			case 0:
-				level = 3
-			}
-		case 4:
-			switch backMatched {
-			case 2:
-				level = 4
-			case 1:
-				level = 5
-			case 0:
-				level = 7
-			}
-		case 3:
-			switch backMatched {
-			case 2:
-				level = 6
-			case 1:
-				level = 8
-			case 0:
-				level = 9
-			}
-		case 2:
-			switch backMatched {
-			case 2:
-				level = 8
-			case 1:
-				level = 9
-			}
-		case 1:
-			switch backMatched {
-			case 2:
-				level = 9
-			}
-		case 0:
-			switch backMatched {
-			case 2:
-				level = 9
-			}
+		// 优先使用开奖号码的期号选择奖级规则
+		index := target.Index
+		if index == 0 {
+			index = source.Index
 		}
 
-		levelPriceMap := map[int]int{
-			1: 10000000,
-			2: 200000,
-			3: 10000,
-			4: 3000,
-			5: 300,
-			6: 200,
-			7: 100,
-			8: 15,
-			9: 5,
+		prizeRule, err := GetPrizeRule(source.Type, index)
+		if err != nil {
+			return result, err
+		}
+
+		// 有特别号的彩票，后区命中个数为前区号码命中特别号的个数
+		if rule, err := GetGameRule(source.Type); err == nil && rule.Special > 0 {
+			backMatched = markSpecialNums(nums, target.BackTuo)
 		}
 
+		level, unitPrice := prizeRule.GetLevel(frontMatched, backMatched)
+
 		result.LotteryBaseInfo = source.LotteryBaseInfo
 		result.FrontMatched = frontMatched
 		result.BackMatched = backMatched
 		result.Numbers = nums
 		result.Level = level
-		result.Price = levelPriceMap[level] * source.Scale
+		result.UnitPrice = unitPrice
+		result.Price = result.UnitPrice * source.Scale
+		result.ApplyTax(DefaultTaxRule)
 
 		return result, nil
 	}
@@ -654,6 +719,10 @@ func (source *Lottery) GetLotteryResult(target Lottery) (LotteryResult, error) {
 		nums = append(nums, ResultNum{Type: "BackTuo", BingoNum: num})
 	}
 
+	if rule, err := GetGameRule(source.Type); err == nil && rule.Special > 0 {
+		result.BackMatched = markSpecialNums(nums, target.BackTuo)
+	}
+
 	result.Numbers = nums
 	result.Level = 100
 
@@ -667,6 +736,8 @@ func (source *Lottery) GetLotteryResult(target Lottery) (LotteryResult, error) {
 
 		result.List = append(result.List, lottResult)
 		result.Price += lottResult.Price
+		result.Tax += lottResult.Tax
+		result.Net += lottResult.Net
 
 		if lottResult.Level > 0 {
 			result.Level = min(result.Level, lottResult.Level)
@@ -700,6 +771,19 @@ func GetLottery(input string) (Lottery, error) {
 		return result, err
 	}
 
+	return NewLottery(parts)
+}
+
+// NewLottery
+//
+// @Description 根据彩票构成部分生成彩票，复式票和胆拖票会展开单式票列表，不校验号码范围和数量
+//
+// @Param parts LotteryParts 彩票构成部分
+//
+// @Return Lottery 彩票结构体
+//
+// @Return error 错误信息
+func NewLottery(parts LotteryParts) (Lottery, error) {
 	list := parts.genLotteryList()
 
 	if len(list) > 1 {
@@ -707,7 +791,8 @@ func GetLottery(input string) (Lottery, error) {
 	} else if len(list) == 1 {
 		return Lottery{parts, nil}, nil
 	} else {
-		return result, fmt.Errorf("单式票生成失败，输入: %s", input)
+		lott := Lottery{LotteryParts: parts}
+		return Lottery{}, NewError(ErrSyntax, "lottery.expand", lott.String())
 	}
 }
 
@@ -745,7 +830,11 @@ func (lott *Lottery) Format(showExtra bool) string {
 			str += "~"
 		}
 
-		str += front[1:] + "-"
+		str += front[1:]
+	}
+
+	if len(lott.BackDan) > 0 || len(lott.BackTuo) > 0 {
+		str += "-"
 	}
 
 	// 后区胆码
@@ -786,6 +875,15 @@ func (lott *Lottery) Format(showExtra bool) string {
 	return str
 }
 
+// String
+//
+// @Description 获取彩票的完整字符串，可以被 GetLottery 重新解析，例如: DLT:01,02,03~04,05,06-07,08x3:25053
+//
+// @Return string 彩票字符串
+func (lott *Lottery) String() string {
+	return lott.Type + ":" + lott.Format(true)
+}
+
 // Format
 //
 // @Description 格式化彩票结果
@@ -805,6 +903,11 @@ func (result *LotteryResult) Format(useColor, showExtra bool) string {
 	for i, item := range result.Numbers {
 		num := fmt.Sprintf("%02d", item.Num)
 
+		if item.Special {
+			yellow := color.New(color.BgYellow).SprintFunc()
+			num = yellow(num)
+		}
+
 		if item.Bingo {
 			if item.Type == "FrontDan" || item.Type == "FrontTuo" {
 				red := color.New(color.BgRed).SprintFunc()
@@ -855,40 +958,128 @@ func (result *LotteryResult) Format(useColor, showExtra bool) string {
 	return str
 }
 
-func getLevelLabel(level int) string {
-	resultMap := map[int]string{
-		1: "一等奖",
-		2: "二等奖",
-		3: "三等奖",
-		4: "四等奖",
-		5: "五等奖",
-		6: "六等奖",
-		7: "七等奖",
-		8: "八等奖",
-		9: "九等奖",
+// GetLevelLabel
+//
+// @Description 使用当前语言获取中奖等级的名称，例如: 一等奖，未中奖时为: 无
+//
+// @Param level int 中奖等级
+//
+// @Return string 中奖等级名称
+func GetLevelLabel(level int) string {
+	return GetLocaleLevelLabel(currentLocale, level)
+}
+
+// GetLocaleLevelLabel
+//
+// @Description 使用指定语言获取中奖等级的名称
+//
+// @Param locale Locale 语言
+//
+// @Param level int 中奖等级
+//
+// @Return string 中奖等级名称
+func GetLocaleLevelLabel(locale Locale, level int) string {
+	if level < 1 || level > 9 {
+		level = 0
 	}
 
-	label := resultMap[level]
+	return Translate(locale, fmt.Sprintf("level.%d", level))
+}
 
-	if len(label) > 0 {
-		return label
-	} else {
-		return "无"
+// 各中奖等级的中奖注数和奖金小计
+type LevelCount struct {
+	Level int `json:"level"` // 中奖等级
+	Count int `json:"count"` // 中奖注数，包含倍投
+	Price int `json:"price"` // 税前奖金小计
+}
+
+// GetLevelCounts
+//
+// @Description 统计各中奖等级的中奖注数和奖金小计，倍投的每一倍计为一注
+//
+// @Return []LevelCount 各等级的统计，按等级从高到低排列，不包含未中奖
+func (result *LotteryResult) GetLevelCounts() []LevelCount {
+	var counts []LevelCount
+
+	if len(result.List) == 0 {
+		if result.Level > 0 {
+			counts = append(counts, LevelCount{result.Level, max(result.Scale, 1), result.Price})
+		}
+
+		return counts
+	}
+
+	countMap := make(map[int]int)
+
+	for _, item := range result.List {
+		for _, count := range item.GetLevelCounts() {
+			i, ok := countMap[count.Level]
+			if !ok {
+				i = len(counts)
+				countMap[count.Level] = i
+				counts = append(counts, LevelCount{Level: count.Level})
+			}
+
+			counts[i].Count += count.Count
+			counts[i].Price += count.Price
+		}
 	}
+
+	sort.Slice(counts, func(i, j int) bool {
+		return counts[i].Level < counts[j].Level
+	})
+
+	return counts
 }
 
-func (result *LotteryResult) PrintResult(useColor, showExtra bool) {
+// FormatLevelCounts
+//
+// @Description 格式化各中奖等级的中奖注数，例如: 二等奖×1, 五等奖×4, 九等奖×12，未中奖时为: 无
+//
+// @Return string 格式化后的字符串
+func (result *LotteryResult) FormatLevelCounts() string {
+	var items []string
+
+	for _, count := range result.GetLevelCounts() {
+		items = append(items, fmt.Sprintf("%s×%d", GetLevelLabel(count.Level), count.Count))
+	}
+
+	if len(items) == 0 {
+		return GetLevelLabel(0)
+	}
+
+	return strings.Join(items, ", ")
+}
+
+// FormatResult
+//
+// @Description 格式化彩票结果及中奖等级和奖金，复式票展示各等级的中奖注数和合计奖金
+//
+// @Param useColor bool 是否用颜色标记中奖号码
+//
+// @Param showExtra bool 是否展示除了号码区以外的额外信息，例如倍投倍数和期号
+//
+// @Return string 格式化后的字符串
+func (result *LotteryResult) FormatResult(useColor, showExtra bool) string {
 	str := result.Format(useColor, showExtra)
 
 	if len(result.List) > 1 {
-		str += fmt.Sprintf("\t最高奖: %s", getLevelLabel(result.Level))
-		str += fmt.Sprintf("\t合计奖金: %d", result.Price)
+		str += fmt.Sprintf("\t%s", result.FormatLevelCounts())
+		str += fmt.Sprintf("\t%s: %d", Translate(currentLocale, "label.total"), result.Price)
 	} else {
-		str += fmt.Sprintf("\t%s", getLevelLabel(result.Level))
-		str += fmt.Sprintf("\t奖金: %d", result.Price)
+		str += fmt.Sprintf("\t%s", GetLevelLabel(result.Level))
+		str += fmt.Sprintf("\t%s: %d", Translate(currentLocale, "label.price"), result.Price)
+	}
+
+	if result.Tax > 0 {
+		str += fmt.Sprintf("\t%s: %d", Translate(currentLocale, "label.net"), result.Net)
 	}
 
-	fmt.Println(str)
+	return str
+}
+
+func (result *LotteryResult) PrintResult(useColor, showExtra bool) {
+	fmt.Println(result.FormatResult(useColor, showExtra))
 }
 
 func (result *LotteryResult) PrintList(useColor, showExtra bool) {
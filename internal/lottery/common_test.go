@@ -363,167 +363,167 @@ func TestGetSingleDltResult(t *testing.T) {
 		source string
 		result LotteryResult
 	}{
-		{"一等奖", "01,02,03,04,05-01,02", LotteryResult{baseInfo, 5, 2, 1, 10000000, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{2, true}, "FrontTuo"},
-			{BingoNum{3, true}, "FrontTuo"},
-			{BingoNum{4, true}, "FrontTuo"},
-			{BingoNum{5, true}, "FrontTuo"},
-			{BingoNum{1, true}, "BackTuo"},
-			{BingoNum{2, true}, "BackTuo"},
+		{"一等奖", "01,02,03,04,05-01,02", LotteryResult{baseInfo, 5, 2, 1, 10000000, 10000000, 2000000, 8000000, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{2, true}, "FrontTuo", false},
+			{BingoNum{3, true}, "FrontTuo", false},
+			{BingoNum{4, true}, "FrontTuo", false},
+			{BingoNum{5, true}, "FrontTuo", false},
+			{BingoNum{1, true}, "BackTuo", false},
+			{BingoNum{2, true}, "BackTuo", false},
 		}, nil}},
-		{"二等奖", "01,02,03,04,05-01,03", LotteryResult{baseInfo, 5, 1, 2, 200000, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{2, true}, "FrontTuo"},
-			{BingoNum{3, true}, "FrontTuo"},
-			{BingoNum{4, true}, "FrontTuo"},
-			{BingoNum{5, true}, "FrontTuo"},
-			{BingoNum{1, true}, "BackTuo"},
-			{BingoNum{3, false}, "BackTuo"},
+		{"二等奖", "01,02,03,04,05-01,03", LotteryResult{baseInfo, 5, 1, 2, 200000, 200000, 40000, 160000, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{2, true}, "FrontTuo", false},
+			{BingoNum{3, true}, "FrontTuo", false},
+			{BingoNum{4, true}, "FrontTuo", false},
+			{BingoNum{5, true}, "FrontTuo", false},
+			{BingoNum{1, true}, "BackTuo", false},
+			{BingoNum{3, false}, "BackTuo", false},
 		}, nil}},
-		{"三等奖", "01,02,03,04,05-03,04", LotteryResult{baseInfo, 5, 0, 3, 10000, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{2, true}, "FrontTuo"},
-			{BingoNum{3, true}, "FrontTuo"},
-			{BingoNum{4, true}, "FrontTuo"},
-			{BingoNum{5, true}, "FrontTuo"},
-			{BingoNum{3, false}, "BackTuo"},
-			{BingoNum{4, false}, "BackTuo"},
+		{"三等奖", "01,02,03,04,05-03,04", LotteryResult{baseInfo, 5, 0, 3, 10000, 10000, 0, 10000, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{2, true}, "FrontTuo", false},
+			{BingoNum{3, true}, "FrontTuo", false},
+			{BingoNum{4, true}, "FrontTuo", false},
+			{BingoNum{5, true}, "FrontTuo", false},
+			{BingoNum{3, false}, "BackTuo", false},
+			{BingoNum{4, false}, "BackTuo", false},
 		}, nil}},
-		{"四等奖", "01,02,03,04,06-01,02", LotteryResult{baseInfo, 4, 2, 4, 3000, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{2, true}, "FrontTuo"},
-			{BingoNum{3, true}, "FrontTuo"},
-			{BingoNum{4, true}, "FrontTuo"},
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{1, true}, "BackTuo"},
-			{BingoNum{2, true}, "BackTuo"},
+		{"四等奖", "01,02,03,04,06-01,02", LotteryResult{baseInfo, 4, 2, 4, 3000, 3000, 0, 3000, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{2, true}, "FrontTuo", false},
+			{BingoNum{3, true}, "FrontTuo", false},
+			{BingoNum{4, true}, "FrontTuo", false},
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{1, true}, "BackTuo", false},
+			{BingoNum{2, true}, "BackTuo", false},
 		}, nil}},
-		{"五等奖", "01,02,03,04,06-01,03", LotteryResult{baseInfo, 4, 1, 5, 300, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{2, true}, "FrontTuo"},
-			{BingoNum{3, true}, "FrontTuo"},
-			{BingoNum{4, true}, "FrontTuo"},
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{1, true}, "BackTuo"},
-			{BingoNum{3, false}, "BackTuo"},
+		{"五等奖", "01,02,03,04,06-01,03", LotteryResult{baseInfo, 4, 1, 5, 300, 300, 0, 300, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{2, true}, "FrontTuo", false},
+			{BingoNum{3, true}, "FrontTuo", false},
+			{BingoNum{4, true}, "FrontTuo", false},
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{1, true}, "BackTuo", false},
+			{BingoNum{3, false}, "BackTuo", false},
 		}, nil}},
-		{"六等奖", "01,02,03,06,07-01,02", LotteryResult{baseInfo, 3, 2, 6, 200, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{2, true}, "FrontTuo"},
-			{BingoNum{3, true}, "FrontTuo"},
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{7, false}, "FrontTuo"},
-			{BingoNum{1, true}, "BackTuo"},
-			{BingoNum{2, true}, "BackTuo"},
+		{"六等奖", "01,02,03,06,07-01,02", LotteryResult{baseInfo, 3, 2, 6, 200, 200, 0, 200, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{2, true}, "FrontTuo", false},
+			{BingoNum{3, true}, "FrontTuo", false},
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{7, false}, "FrontTuo", false},
+			{BingoNum{1, true}, "BackTuo", false},
+			{BingoNum{2, true}, "BackTuo", false},
 		}, nil}},
-		{"七等奖", "01,02,03,04,06-03,04", LotteryResult{baseInfo, 4, 0, 7, 100, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{2, true}, "FrontTuo"},
-			{BingoNum{3, true}, "FrontTuo"},
-			{BingoNum{4, true}, "FrontTuo"},
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{3, false}, "BackTuo"},
-			{BingoNum{4, false}, "BackTuo"},
+		{"七等奖", "01,02,03,04,06-03,04", LotteryResult{baseInfo, 4, 0, 7, 100, 100, 0, 100, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{2, true}, "FrontTuo", false},
+			{BingoNum{3, true}, "FrontTuo", false},
+			{BingoNum{4, true}, "FrontTuo", false},
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{3, false}, "BackTuo", false},
+			{BingoNum{4, false}, "BackTuo", false},
 		}, nil}},
-		{"八等奖A", "01,02,03,06,07-01,03", LotteryResult{baseInfo, 3, 1, 8, 15, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{2, true}, "FrontTuo"},
-			{BingoNum{3, true}, "FrontTuo"},
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{7, false}, "FrontTuo"},
-			{BingoNum{1, true}, "BackTuo"},
-			{BingoNum{3, false}, "BackTuo"},
+		{"八等奖A", "01,02,03,06,07-01,03", LotteryResult{baseInfo, 3, 1, 8, 15, 15, 0, 15, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{2, true}, "FrontTuo", false},
+			{BingoNum{3, true}, "FrontTuo", false},
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{7, false}, "FrontTuo", false},
+			{BingoNum{1, true}, "BackTuo", false},
+			{BingoNum{3, false}, "BackTuo", false},
 		}, nil}},
-		{"八等奖B", "01,02,06,07,08-01,02", LotteryResult{baseInfo, 2, 2, 8, 15, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{2, true}, "FrontTuo"},
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{7, false}, "FrontTuo"},
-			{BingoNum{8, false}, "FrontTuo"},
-			{BingoNum{1, true}, "BackTuo"},
-			{BingoNum{2, true}, "BackTuo"},
+		{"八等奖B", "01,02,06,07,08-01,02", LotteryResult{baseInfo, 2, 2, 8, 15, 15, 0, 15, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{2, true}, "FrontTuo", false},
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{7, false}, "FrontTuo", false},
+			{BingoNum{8, false}, "FrontTuo", false},
+			{BingoNum{1, true}, "BackTuo", false},
+			{BingoNum{2, true}, "BackTuo", false},
 		}, nil}},
-		{"九等奖A", "01,02,03,06,07-03,04", LotteryResult{baseInfo, 3, 0, 9, 5, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{2, true}, "FrontTuo"},
-			{BingoNum{3, true}, "FrontTuo"},
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{7, false}, "FrontTuo"},
-			{BingoNum{3, false}, "BackTuo"},
-			{BingoNum{4, false}, "BackTuo"},
+		{"九等奖A", "01,02,03,06,07-03,04", LotteryResult{baseInfo, 3, 0, 9, 5, 5, 0, 5, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{2, true}, "FrontTuo", false},
+			{BingoNum{3, true}, "FrontTuo", false},
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{7, false}, "FrontTuo", false},
+			{BingoNum{3, false}, "BackTuo", false},
+			{BingoNum{4, false}, "BackTuo", false},
 		}, nil}},
-		{"九等奖B", "01,06,07,08,09-01,02", LotteryResult{baseInfo, 1, 2, 9, 5, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{7, false}, "FrontTuo"},
-			{BingoNum{8, false}, "FrontTuo"},
-			{BingoNum{9, false}, "FrontTuo"},
-			{BingoNum{1, true}, "BackTuo"},
-			{BingoNum{2, true}, "BackTuo"},
+		{"九等奖B", "01,06,07,08,09-01,02", LotteryResult{baseInfo, 1, 2, 9, 5, 5, 0, 5, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{7, false}, "FrontTuo", false},
+			{BingoNum{8, false}, "FrontTuo", false},
+			{BingoNum{9, false}, "FrontTuo", false},
+			{BingoNum{1, true}, "BackTuo", false},
+			{BingoNum{2, true}, "BackTuo", false},
 		}, nil}},
-		{"九等奖C", "01,02,06,07,08-01,03", LotteryResult{baseInfo, 2, 1, 9, 5, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{2, true}, "FrontTuo"},
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{7, false}, "FrontTuo"},
-			{BingoNum{8, false}, "FrontTuo"},
-			{BingoNum{1, true}, "BackTuo"},
-			{BingoNum{3, false}, "BackTuo"},
+		{"九等奖C", "01,02,06,07,08-01,03", LotteryResult{baseInfo, 2, 1, 9, 5, 5, 0, 5, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{2, true}, "FrontTuo", false},
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{7, false}, "FrontTuo", false},
+			{BingoNum{8, false}, "FrontTuo", false},
+			{BingoNum{1, true}, "BackTuo", false},
+			{BingoNum{3, false}, "BackTuo", false},
 		}, nil}},
-		{"九等奖D", "06,07,08,09,10-01,02", LotteryResult{baseInfo, 0, 2, 9, 5, []ResultNum{
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{7, false}, "FrontTuo"},
-			{BingoNum{8, false}, "FrontTuo"},
-			{BingoNum{9, false}, "FrontTuo"},
-			{BingoNum{10, false}, "FrontTuo"},
-			{BingoNum{1, true}, "BackTuo"},
-			{BingoNum{2, true}, "BackTuo"},
+		{"九等奖D", "06,07,08,09,10-01,02", LotteryResult{baseInfo, 0, 2, 9, 5, 5, 0, 5, []ResultNum{
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{7, false}, "FrontTuo", false},
+			{BingoNum{8, false}, "FrontTuo", false},
+			{BingoNum{9, false}, "FrontTuo", false},
+			{BingoNum{10, false}, "FrontTuo", false},
+			{BingoNum{1, true}, "BackTuo", false},
+			{BingoNum{2, true}, "BackTuo", false},
 		}, nil}},
-		{"无奖A", "06,07,08,09,10-03,04", LotteryResult{baseInfo, 0, 0, 0, 0, []ResultNum{
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{7, false}, "FrontTuo"},
-			{BingoNum{8, false}, "FrontTuo"},
-			{BingoNum{9, false}, "FrontTuo"},
-			{BingoNum{10, false}, "FrontTuo"},
-			{BingoNum{3, false}, "BackTuo"},
-			{BingoNum{4, false}, "BackTuo"},
+		{"无奖A", "06,07,08,09,10-03,04", LotteryResult{baseInfo, 0, 0, 0, 0, 0, 0, 0, []ResultNum{
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{7, false}, "FrontTuo", false},
+			{BingoNum{8, false}, "FrontTuo", false},
+			{BingoNum{9, false}, "FrontTuo", false},
+			{BingoNum{10, false}, "FrontTuo", false},
+			{BingoNum{3, false}, "BackTuo", false},
+			{BingoNum{4, false}, "BackTuo", false},
 		}, nil}},
-		{"无奖B", "01,06,07,08,09-03,04", LotteryResult{baseInfo, 1, 0, 0, 0, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{7, false}, "FrontTuo"},
-			{BingoNum{8, false}, "FrontTuo"},
-			{BingoNum{9, false}, "FrontTuo"},
-			{BingoNum{3, false}, "BackTuo"},
-			{BingoNum{4, false}, "BackTuo"},
+		{"无奖B", "01,06,07,08,09-03,04", LotteryResult{baseInfo, 1, 0, 0, 0, 0, 0, 0, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{7, false}, "FrontTuo", false},
+			{BingoNum{8, false}, "FrontTuo", false},
+			{BingoNum{9, false}, "FrontTuo", false},
+			{BingoNum{3, false}, "BackTuo", false},
+			{BingoNum{4, false}, "BackTuo", false},
 		}, nil}},
-		{"无奖C", "06,07,08,09,10-01,03", LotteryResult{baseInfo, 0, 1, 0, 0, []ResultNum{
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{7, false}, "FrontTuo"},
-			{BingoNum{8, false}, "FrontTuo"},
-			{BingoNum{9, false}, "FrontTuo"},
-			{BingoNum{10, false}, "FrontTuo"},
-			{BingoNum{1, true}, "BackTuo"},
-			{BingoNum{3, false}, "BackTuo"},
+		{"无奖C", "06,07,08,09,10-01,03", LotteryResult{baseInfo, 0, 1, 0, 0, 0, 0, 0, []ResultNum{
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{7, false}, "FrontTuo", false},
+			{BingoNum{8, false}, "FrontTuo", false},
+			{BingoNum{9, false}, "FrontTuo", false},
+			{BingoNum{10, false}, "FrontTuo", false},
+			{BingoNum{1, true}, "BackTuo", false},
+			{BingoNum{3, false}, "BackTuo", false},
 		}, nil}},
-		{"无奖D", "01,06,07,08,09-01,03", LotteryResult{baseInfo, 1, 1, 0, 0, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{7, false}, "FrontTuo"},
-			{BingoNum{8, false}, "FrontTuo"},
-			{BingoNum{9, false}, "FrontTuo"},
-			{BingoNum{1, true}, "BackTuo"},
-			{BingoNum{3, false}, "BackTuo"},
+		{"无奖D", "01,06,07,08,09-01,03", LotteryResult{baseInfo, 1, 1, 0, 0, 0, 0, 0, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{7, false}, "FrontTuo", false},
+			{BingoNum{8, false}, "FrontTuo", false},
+			{BingoNum{9, false}, "FrontTuo", false},
+			{BingoNum{1, true}, "BackTuo", false},
+			{BingoNum{3, false}, "BackTuo", false},
 		}, nil}},
-		{"无奖E", "01,02,06,07,08-03,04", LotteryResult{baseInfo, 2, 0, 0, 0, []ResultNum{
-			{BingoNum{1, true}, "FrontTuo"},
-			{BingoNum{2, true}, "FrontTuo"},
-			{BingoNum{6, false}, "FrontTuo"},
-			{BingoNum{7, false}, "FrontTuo"},
-			{BingoNum{8, false}, "FrontTuo"},
-			{BingoNum{3, false}, "BackTuo"},
-			{BingoNum{4, false}, "BackTuo"},
+		{"无奖E", "01,02,06,07,08-03,04", LotteryResult{baseInfo, 2, 0, 0, 0, 0, 0, 0, []ResultNum{
+			{BingoNum{1, true}, "FrontTuo", false},
+			{BingoNum{2, true}, "FrontTuo", false},
+			{BingoNum{6, false}, "FrontTuo", false},
+			{BingoNum{7, false}, "FrontTuo", false},
+			{BingoNum{8, false}, "FrontTuo", false},
+			{BingoNum{3, false}, "BackTuo", false},
+			{BingoNum{4, false}, "BackTuo", false},
 		}, nil}},
 	}
 
@@ -702,3 +702,70 @@ func TestIsSingleLottery(t *testing.T) {
 		})
 	}
 }
+
+func TestGetLevelCounts(t *testing.T) {
+	target, _ := GetLottery("DLT:01,02,03,04,05-01,02")
+
+	tests := []struct {
+		name   string
+		source string
+		counts []LevelCount
+		str    string
+	}{
+		{"复式倍投", "DLT:01,02,03,04,05,06-01,02,03x2", []LevelCount{{1, 2, 20000000}, {2, 4, 800000}, {4, 10, 30000}, {5, 20, 6000}}, "一等奖×2, 二等奖×4, 四等奖×10, 五等奖×20"},
+		{"胆拖", "DLT:01,02~06,07,08,09-01~03,04", []LevelCount{{9, 8, 40}}, "九等奖×8"},
+		{"单式", "DLT:01,02,03,04,06-01,03x3", []LevelCount{{5, 3, 900}}, "五等奖×3"},
+		{"未中奖", "DLT:06,07,08,09,10-03,04", nil, "无"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, _ := GetLottery(tt.source)
+			result, _ := source.GetLotteryResult(target)
+
+			if counts := result.GetLevelCounts(); !reflect.DeepEqual(counts, tt.counts) {
+				t.Errorf("期望: %v, 实际: %v", tt.counts, counts)
+			}
+
+			if str := result.FormatLevelCounts(); str != tt.str {
+				t.Errorf("期望: %s, 实际: %s", tt.str, str)
+			}
+		})
+	}
+}
+
+func TestCountBets(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		count int
+		err   bool
+	}{
+		{"单式", "DLT:01,02,03,04,05-06,07", 1, false},
+		{"复式", "DLT:01,02,03,04,05,06-06,07,08x3", 18, false},
+		{"胆拖", "DLT:01,02~06,07,08,09-01~03,04", 8, false},
+		{"大复式", "DLT:01,02,03,04,05,06,07,08,09,10,11,12-01,02,03,04,05,06,07,08,09,10,11,12", 792 * 66, false},
+		{"超出范围", "DLT:01,02,03,04,36-06,07", 0, true},
+		{"格式错误", "DLT:01,02", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			count, err := CountBets(tt.input)
+
+			if tt.err {
+				if err == nil {
+					t.Errorf("应该失败")
+				}
+			} else if err != nil {
+				t.Errorf("错误信息: %s", err)
+			} else if count != tt.count {
+				t.Errorf("期望: %d, 实际: %d", tt.count, count)
+			}
+
+			if lott, err := GetLottery(tt.input); err == nil && !tt.err && lott.GetBetCount() != count {
+				t.Errorf("与展开后的注数不一致: %d, %d", lott.GetBetCount(), count)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package digit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/dlt"
+)
+
+// 位置型彩票的开奖结果，固定奖金，每一注单独计税
+type Result struct {
+	Ticket Ticket       // 彩票
+	Draw   Ticket       // 开奖号码
+	Bets   int          // 注数，不包含倍投
+	Wins   int          // 中奖注数，包含倍投
+	Hits   map[Play]int // 展开后各单式玩法的中奖注数，不包含倍投
+	Price  int          // 税前奖金
+	Tax    int          // 个人所得税
+	Net    int          // 税后奖金
+}
+
+// ParseDraw
+//
+// @Description 解析开奖号码，开奖号码必须是直选单式，例如: PL3:1-2-3:25100
+//
+// @Param input string 开奖号码字符串
+//
+// @Return Ticket 开奖号码
+//
+// @Return error 错误信息
+func ParseDraw(input string) (Ticket, error) {
+	draw, err := Parse(input)
+	if err != nil {
+		return draw, err
+	}
+
+	if !draw.IsSingle() {
+		return Ticket{}, lottery.NewError(lottery.ErrNotSingle, "digit.not_single", input)
+	}
+
+	return draw, nil
+}
+
+// GetDraw
+//
+// @Description 将体彩接口的开奖数据转换为开奖号码，期号写入 Index
+//
+// @Param draw dlt.PoolDraw 开奖数据
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return Ticket 开奖号码
+//
+// @Return error 错误信息
+func GetDraw(draw dlt.PoolDraw, lotteryType string) (Ticket, error) {
+	rule, err := GetGameRule(lotteryType)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	issue, err := draw.GetIssue()
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	fields := strings.Fields(draw.LotteryDrawResult)
+	if len(fields) != rule.Size {
+		return Ticket{}, lottery.NewError(lottery.ErrNumberCount, "digit.draw_count", rule.Size, len(fields), draw.LotteryDrawNum)
+	}
+
+	return ParseDraw(fmt.Sprintf("%s:%s:%d", lotteryType, strings.Join(fields, "-"), issue))
+}
+
+// GetDigits
+//
+// @Description 获取直选单式的号码
+//
+// @Return []int 每一位的号码
+func (ticket *Ticket) GetDigits() []int {
+	digits := make([]int, 0, len(ticket.Positions))
+
+	for _, nums := range ticket.Positions {
+		digits = append(digits, nums[0])
+	}
+
+	return digits
+}
+
+// isWin
+//
+// @Description 判断单式票是否命中开奖号码
+//
+// @Param digits []int 开奖号码
+//
+// @Return bool 是否中奖
+func (ticket *Ticket) isWin(digits []int) bool {
+	switch ticket.Play {
+	case PlayDirect:
+		return slices.Equal(ticket.GetDigits(), digits)
+	case PlayGroup3:
+		sorted := slices.Sorted(slices.Values(digits))
+		return len(slices.Compact(slices.Clone(sorted))) == 2 && slices.Equal(ticket.Nums, sorted)
+	case PlayGroup6:
+		sorted := slices.Sorted(slices.Values(digits))
+		return len(slices.Compact(slices.Clone(sorted))) == 3 && slices.Equal(ticket.Nums, sorted)
+	}
+
+	return false
+}
+
+// GetResult
+//
+// @Description 用开奖号码核对彩票，彩票展开为单式票后逐注核对
+//
+// @Param draw Ticket 开奖号码，必须是直选单式
+//
+// @Return Result 开奖结果
+//
+// @Return error 错误信息
+func (ticket *Ticket) GetResult(draw Ticket) (Result, error) {
+	result := Result{Ticket: *ticket, Draw: draw, Bets: ticket.GetBetCount()}
+
+	if !draw.IsSingle() {
+		return result, lottery.NewError(lottery.ErrNotSingle, "digit.not_single", draw.String())
+	}
+
+	if ticket.Type != draw.Type {
+		return result, lottery.NewError(lottery.ErrUnknownType, "digit.type_mismatch", ticket.Type, draw.Type)
+	}
+
+	digits := draw.GetDigits()
+	prices := gameRules[ticket.Type].Prices
+	scale := max(ticket.Scale, 1)
+
+	for _, single := range ticket.Expand() {
+		if !single.isWin(digits) {
+			continue
+		}
+
+		if result.Hits == nil {
+			result.Hits = make(map[Play]int)
+		}
+
+		result.Hits[single.Play]++
+		result.Wins += scale
+		result.Price += prices[single.Play] * scale
+		result.Tax += lottery.DefaultTaxRule.GetTax(prices[single.Play]) * scale
+	}
+
+	result.Net = result.Price - result.Tax
+
+	return result, nil
+}
+
+// FormatResult
+//
+// @Description 格式化开奖结果，例如: PL3:12-3-456x2	直选×2	奖金: 2080，中奖的玩法为展开后的单式玩法
+//
+// @Return string 格式化后的字符串
+func (result *Result) FormatResult() string {
+	var labels []string
+
+	for _, play := range []Play{PlayDirect, PlayGroup3, PlayGroup6} {
+		if count := result.Hits[play]; count > 0 {
+			labels = append(labels, fmt.Sprintf("%s×%d", GetPlayLabel(play), count*max(result.Ticket.Scale, 1)))
+		}
+	}
+
+	label := lottery.GetLevelLabel(0)
+	if len(labels) > 0 {
+		label = strings.Join(labels, " ")
+	}
+
+	str := fmt.Sprintf("%s\t%s\t%s: %d", result.Ticket.String(), label, lottery.Translate(lottery.GetLocale(), "label.price"), result.Price)
+
+	if result.Tax > 0 {
+		str += fmt.Sprintf("\t%s: %d", lottery.Translate(lottery.GetLocale(), "label.net"), result.Net)
+	}
+
+	return str
+}
@@ -0,0 +1,667 @@
+package digit
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 玩法
+type Play string
+
+const (
+	PlayDirect Play = "ZX" // 直选，每一位的号码和顺序都相同
+	PlayGroup3 Play = "Z3" // 组选3，开奖号码有两位相同，不限顺序
+	PlayGroup6 Play = "Z6" // 组选6，开奖号码各不相同，不限顺序
+	PlaySum    Play = "HZ" // 直选和值，开奖号码之和相同
+	PlaySpan   Play = "KD" // 直选跨度，开奖号码最大值与最小值之差相同
+	PlayCombo  Play = "BH" // 组选包号，所选号码组成的全部组选3和组选6
+	PlayDanTuo Play = "DT" // 组选胆拖，包含全部胆码的组选6
+)
+
+// 不支持的玩法
+const ErrUnknownPlay lottery.ErrorKind = "unknown_play"
+
+// 位置型彩票的玩法规则
+type GameRule struct {
+	Type   string       // 彩票类型 (PL3: 排列三, PL5: 排列五, 3D: 福彩3D)
+	Name   string       // 彩票名称
+	Size   int          // 号码位数
+	Plays  []Play       // 支持的玩法
+	Prices map[Play]int // 单式的单注奖金，其他玩法展开为直选、组选3、组选6单式后计奖
+}
+
+var gameRules = map[string]GameRule{
+	"PL3": {"PL3", "排列三", 3, []Play{PlayDirect, PlayGroup3, PlayGroup6, PlaySum}, map[Play]int{PlayDirect: 1040, PlayGroup3: 346, PlayGroup6: 173}},
+	"PL5": {"PL5", "排列五", 5, []Play{PlayDirect}, map[Play]int{PlayDirect: 100000}},
+	"3D":  {"3D", "福彩3D", 3, []Play{PlayDirect, PlayGroup3, PlayGroup6, PlaySum, PlaySpan, PlayCombo, PlayDanTuo}, map[Play]int{PlayDirect: 1040, PlayGroup3: 346, PlayGroup6: 173}},
+}
+
+func init() {
+	lottery.RegisterMessages(lottery.LocaleZhCN, map[string]string{
+		"digit.play.ZX": "直选",
+		"digit.play.Z3": "组选3",
+		"digit.play.Z6": "组选6",
+		"digit.play.HZ": "和值",
+		"digit.play.KD": "跨度",
+		"digit.play.BH": "包号",
+		"digit.play.DT": "胆拖",
+
+		"digit.syntax":        "彩票解析失败，格式为: 类型[/玩法]:号码[x倍数][:期号]。输入: %s",
+		"digit.unknown_play":  "%s不支持的玩法: %s",
+		"digit.char":          "号码解析失败，错误的字符: 【%c】。输入: %s",
+		"digit.positions":     "直选号码应该有%d位，当前位数: %d",
+		"digit.empty":         "第%d位没有号码",
+		"digit.duplicate":     "号码重复: %v",
+		"digit.group_count":   "%s至少需要%d个不同的号码，当前数量: %d",
+		"digit.group3_single": "组选3单式应该有且只有两个号码相同: %s",
+		"digit.sum":           "和值解析失败: %s。输入: %s",
+		"digit.sum_range":     "和值范围为0~%d，当前和值: %d",
+		"digit.span":          "跨度解析失败: %s。输入: %s",
+		"digit.span_range":    "跨度范围为0~9，当前跨度: %d",
+		"digit.dan_tuo":       "胆拖号码格式为: 胆码~拖码。输入: %s",
+		"digit.dan_count":     "胆码数量应该为1~%d，当前数量: %d",
+		"digit.tuo_count":     "拖码至少需要%d个，当前数量: %d",
+		"digit.conflict":      "拖码与胆码重复: %v",
+		"digit.scale":         "倍投倍数解析失败: %s。输入: %s",
+		"digit.index":         "期号解析失败: %s。输入: %s",
+		"digit.not_single":    "开奖号码必须是直选单式: %s",
+		"digit.type_mismatch": "彩票类型与开奖号码不一致: %s, %s",
+		"digit.draw_count":    "开奖号码数量错误，期望: %d, 实际: %d。期号: %s",
+	})
+
+	lottery.RegisterMessages(lottery.LocaleEn, map[string]string{
+		"digit.play.ZX": "straight",
+		"digit.play.Z3": "group 3",
+		"digit.play.Z6": "group 6",
+		"digit.play.HZ": "sum",
+		"digit.play.KD": "span",
+		"digit.play.BH": "group combo",
+		"digit.play.DT": "group banker",
+
+		"digit.syntax":        "invalid ticket, the format is TYPE[/PLAY]:NUMBERS[xSCALE][:ISSUE]. input: %s",
+		"digit.unknown_play":  "%s does not support play %s",
+		"digit.char":          "invalid numbers: unexpected character '%c'. input: %s",
+		"digit.positions":     "straight numbers must have %d positions, got %d",
+		"digit.empty":         "position %d has no numbers",
+		"digit.duplicate":     "duplicate numbers: %v",
+		"digit.group_count":   "%s needs at least %d different numbers, got %d",
+		"digit.group3_single": "a single group 3 ticket must have exactly two equal numbers: %s",
+		"digit.sum":           "invalid sum: %s. input: %s",
+		"digit.sum_range":     "sum must be between 0 and %d, got %d",
+		"digit.span":          "invalid span: %s. input: %s",
+		"digit.span_range":    "span must be between 0 and 9, got %d",
+		"digit.dan_tuo":       "banker numbers must be written as BANKERS~OTHERS. input: %s",
+		"digit.dan_count":     "bankers must number 1 to %d, got %d",
+		"digit.tuo_count":     "at least %d other numbers are needed, got %d",
+		"digit.conflict":      "numbers are both bankers and others: %v",
+		"digit.scale":         "invalid multiplier: %s. input: %s",
+		"digit.index":         "invalid issue: %s. input: %s",
+		"digit.not_single":    "draw must be a single straight ticket: %s",
+		"digit.type_mismatch": "ticket type does not match the draw: %s, %s",
+		"digit.draw_count":    "wrong number of draw digits, expected %d, got %d. issue: %s",
+	})
+}
+
+// GetGameRule
+//
+// @Description 获取位置型彩票的玩法规则
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return GameRule 玩法规则
+//
+// @Return error 错误信息
+func GetGameRule(lotteryType string) (GameRule, error) {
+	rule, ok := gameRules[lotteryType]
+	if !ok {
+		return rule, lottery.NewError(lottery.ErrUnknownType, "rule.unknown_type", lotteryType)
+	}
+
+	return rule, nil
+}
+
+// IsDigitType
+//
+// @Description 判断彩票类型是否为位置型彩票
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return bool 是否为位置型彩票
+func IsDigitType(lotteryType string) bool {
+	_, ok := gameRules[lotteryType]
+	return ok
+}
+
+// GetPlayLabel
+//
+// @Description 获取玩法名称，例如: 直选
+//
+// @Param play Play 玩法
+//
+// @Return string 玩法名称
+func GetPlayLabel(play Play) string {
+	return lottery.Translate(lottery.GetLocale(), "digit.play."+string(play))
+}
+
+// 位置型彩票
+type Ticket struct {
+	Type      string  // 彩票类型
+	Play      Play    // 玩法
+	Positions [][]int // 直选每一位的号码，单式票每一位只有一个号码
+	Nums      []int   // 组选、包号号码和胆拖的拖码，组选3单式包含两个相同的号码
+	Dan       []int   // 胆拖的胆码
+	Sums      []int   // 和值
+	Spans     []int   // 跨度
+	Scale     int     // 倍投倍数
+	Index     int     // 期号
+}
+
+// parseDigits
+//
+// @Description 解析连续的一位数字，例如: 123
+//
+// @Param str string 数字字符串
+//
+// @Param input string 完整的彩票字符串，用于错误信息
+//
+// @Return []int 数字列表，保持原有顺序
+//
+// @Return error 错误信息
+func parseDigits(str, input string) ([]int, error) {
+	nums := make([]int, 0, len(str))
+
+	for _, char := range str {
+		if char < '0' || char > '9' {
+			return nil, lottery.NewError(lottery.ErrSyntax, "digit.char", char, input)
+		}
+
+		nums = append(nums, int(char-'0'))
+	}
+
+	return nums, nil
+}
+
+// parseExtra
+//
+// @Description 解析号码之后的倍投和期号，例如: x3:25100 或 :25100x3
+//
+// @Param ticket *Ticket 彩票
+//
+// @Param extra string 号码之后的字符串
+//
+// @Param input string 完整的彩票字符串，用于错误信息
+//
+// @Return error 错误信息
+func parseExtra(ticket *Ticket, extra, input string) error {
+	var scaleParsed, indexParsed bool
+
+	for len(extra) > 0 {
+		mark := extra[0]
+		end := strings.IndexAny(extra[1:], "x:") + 1
+		if end == 0 {
+			end = len(extra)
+		}
+
+		value := extra[1:end]
+		extra = extra[end:]
+
+		num, err := strconv.Atoi(value)
+
+		switch {
+		case mark == 'x' && (scaleParsed || err != nil || num < 1):
+			return lottery.NewError(lottery.ErrInvalidScale, "digit.scale", value, input)
+		case mark == 'x':
+			ticket.Scale, scaleParsed = num, true
+		case indexParsed || err != nil || num < 1:
+			return lottery.NewError(lottery.ErrInvalidIndex, "digit.index", value, input)
+		default:
+			ticket.Index, indexParsed = num, true
+		}
+	}
+
+	return nil
+}
+
+// parseValues
+//
+// @Description 解析用逗号分隔的和值或跨度，例如: 9,10
+//
+// @Param str string 和值或跨度字符串
+//
+// @Param key string 解析失败时的错误信息
+//
+// @Param input string 完整的彩票字符串，用于错误信息
+//
+// @Return []int 和值或跨度列表
+//
+// @Return error 错误信息
+func parseValues(str, key, input string) ([]int, error) {
+	var values []int
+
+	for _, part := range strings.Split(str, ",") {
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, lottery.NewError(lottery.ErrInvalidNumber, key, part, input)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// Parse
+//
+// @Description 解析位置型彩票字符串，格式为: 类型[/玩法]:号码[x倍数][:期号]，玩法默认为直选，例如:
+//
+//	PL3:1-2-3         直选单式，每一位之间用 - 分隔
+//	PL3:12-3-456x2    直选复式，每一位可以选多个号码
+//	PL3/Z3:112        组选3单式
+//	PL3/Z3:1234       组选3复式，任选两个号码组成组选3
+//	PL3/Z6:12345      组选6，任选三个号码组成组选6
+//	PL3/HZ:9,10:25100 直选和值，多个和值用逗号分隔
+//	3D/KD:2,3         直选跨度，多个跨度用逗号分隔
+//	3D/BH:1234        组选包号，所选号码组成的全部组选3和组选6
+//	3D/DT:1~2345      组选胆拖，胆码和拖码之间用 ~ 分隔
+//
+// @Param input string 彩票字符串
+//
+// @Return Ticket 彩票
+//
+// @Return error 错误信息
+func Parse(input string) (Ticket, error) {
+	ticket := Ticket{Play: PlayDirect, Scale: 1}
+
+	head, rest, ok := strings.Cut(input, ":")
+	if !ok || rest == "" {
+		return Ticket{}, lottery.NewError(lottery.ErrSyntax, "digit.syntax", input)
+	}
+
+	lotteryType, play, hasPlay := strings.Cut(head, "/")
+
+	rule, err := GetGameRule(lotteryType)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	ticket.Type = lotteryType
+
+	if hasPlay {
+		ticket.Play = Play(play)
+	}
+
+	if !slices.Contains(rule.Plays, ticket.Play) {
+		return Ticket{}, lottery.NewError(ErrUnknownPlay, "digit.unknown_play", rule.Name, play)
+	}
+
+	body := rest
+	if i := strings.IndexAny(rest, "x:"); i >= 0 {
+		body = rest[:i]
+
+		if err := parseExtra(&ticket, rest[i:], input); err != nil {
+			return Ticket{}, err
+		}
+	}
+
+	switch ticket.Play {
+	case PlayDirect:
+		for _, part := range strings.Split(body, "-") {
+			nums, err := parseDigits(part, input)
+			if err != nil {
+				return Ticket{}, err
+			}
+
+			ticket.Positions = append(ticket.Positions, nums)
+		}
+	case PlayGroup3, PlayGroup6, PlayCombo:
+		if ticket.Nums, err = parseDigits(body, input); err != nil {
+			return Ticket{}, err
+		}
+	case PlayDanTuo:
+		dan, tuo, ok := strings.Cut(body, "~")
+		if !ok {
+			return Ticket{}, lottery.NewError(lottery.ErrSyntax, "digit.dan_tuo", input)
+		}
+
+		if ticket.Dan, err = parseDigits(dan, input); err != nil {
+			return Ticket{}, err
+		}
+
+		if ticket.Nums, err = parseDigits(tuo, input); err != nil {
+			return Ticket{}, err
+		}
+	case PlaySum:
+		if ticket.Sums, err = parseValues(body, "digit.sum", input); err != nil {
+			return Ticket{}, err
+		}
+	case PlaySpan:
+		if ticket.Spans, err = parseValues(body, "digit.span", input); err != nil {
+			return Ticket{}, err
+		}
+	}
+
+	if err := rule.Check(&ticket); err != nil {
+		return Ticket{}, err
+	}
+
+	return ticket, nil
+}
+
+// Check
+//
+// @Description 按玩法规则检查彩票的号码，检查通过后将每一位的号码、组选号码、胆码、和值和跨度排序
+//
+// @Param ticket *Ticket 彩票
+//
+// @Return error 错误信息
+func (rule GameRule) Check(ticket *Ticket) error {
+	switch ticket.Play {
+	case PlayDirect:
+		if len(ticket.Positions) != rule.Size {
+			return lottery.NewError(lottery.ErrNumberCount, "digit.positions", rule.Size, len(ticket.Positions))
+		}
+
+		for i, nums := range ticket.Positions {
+			if len(nums) == 0 {
+				return lottery.NewError(lottery.ErrNumberCount, "digit.empty", i+1)
+			}
+
+			if dup := lottery.GetDupNums(nums); len(dup) > 0 {
+				return lottery.NewError(lottery.ErrDuplicateNumber, "digit.duplicate", dup)
+			}
+
+			sort.Ints(nums)
+		}
+	case PlayGroup3, PlayGroup6:
+		sort.Ints(ticket.Nums)
+
+		dup := lottery.GetDupNums(ticket.Nums)
+		single := ticket.Play == PlayGroup3 && len(ticket.Nums) == 3 && len(dup) == 1
+
+		if len(dup) > 0 && !single {
+			if ticket.Play == PlayGroup3 && len(ticket.Nums) == 3 {
+				return lottery.NewError(lottery.ErrDuplicateNumber, "digit.group3_single", formatDigits(ticket.Nums))
+			}
+
+			return lottery.NewError(lottery.ErrDuplicateNumber, "digit.duplicate", dup)
+		}
+
+		minCount := 2
+		if ticket.Play == PlayGroup6 {
+			minCount = 3
+		}
+
+		if count := len(ticket.Nums) - len(dup); count < minCount {
+			return lottery.NewError(lottery.ErrNumberCount, "digit.group_count", GetPlayLabel(ticket.Play), minCount, count)
+		}
+	case PlayCombo:
+		sort.Ints(ticket.Nums)
+
+		if dup := lottery.GetDupNums(ticket.Nums); len(dup) > 0 {
+			return lottery.NewError(lottery.ErrDuplicateNumber, "digit.duplicate", dup)
+		}
+
+		if len(ticket.Nums) < 2 {
+			return lottery.NewError(lottery.ErrNumberCount, "digit.group_count", GetPlayLabel(ticket.Play), 2, len(ticket.Nums))
+		}
+	case PlayDanTuo:
+		sort.Ints(ticket.Dan)
+		sort.Ints(ticket.Nums)
+
+		if len(ticket.Dan) < 1 || len(ticket.Dan) >= rule.Size {
+			return lottery.NewError(lottery.ErrDanCount, "digit.dan_count", rule.Size-1, len(ticket.Dan))
+		}
+
+		if dup := append(lottery.GetDupNums(ticket.Dan), lottery.GetDupNums(ticket.Nums)...); len(dup) > 0 {
+			return lottery.NewError(lottery.ErrDuplicateNumber, "digit.duplicate", dup)
+		}
+
+		if cross := lottery.GetCrossNums(ticket.Dan, ticket.Nums); len(cross) > 0 {
+			return lottery.NewError(lottery.ErrDanTuoConflict, "digit.conflict", cross)
+		}
+
+		if minCount := rule.Size - len(ticket.Dan); len(ticket.Nums) < minCount {
+			return lottery.NewError(lottery.ErrNumberCount, "digit.tuo_count", minCount, len(ticket.Nums))
+		}
+	case PlaySum:
+		sort.Ints(ticket.Sums)
+
+		for _, sum := range ticket.Sums {
+			if sum < 0 || sum > 9*rule.Size {
+				return lottery.NewError(lottery.ErrNumberRange, "digit.sum_range", 9*rule.Size, sum)
+			}
+		}
+
+		if dup := lottery.GetDupNums(ticket.Sums); len(dup) > 0 {
+			return lottery.NewError(lottery.ErrDuplicateNumber, "digit.duplicate", dup)
+		}
+	case PlaySpan:
+		sort.Ints(ticket.Spans)
+
+		for _, span := range ticket.Spans {
+			if span < 0 || span > 9 {
+				return lottery.NewError(lottery.ErrNumberRange, "digit.span_range", span)
+			}
+		}
+
+		if dup := lottery.GetDupNums(ticket.Spans); len(dup) > 0 {
+			return lottery.NewError(lottery.ErrDuplicateNumber, "digit.duplicate", dup)
+		}
+	}
+
+	return nil
+}
+
+// IsSingle
+//
+// @Description 判断彩票是否为直选单式
+//
+// @Return bool 是否为直选单式
+func (ticket *Ticket) IsSingle() bool {
+	if ticket.Play != PlayDirect {
+		return false
+	}
+
+	for _, nums := range ticket.Positions {
+		if len(nums) != 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// countSum
+//
+// @Description 计算 size 位数字之和为 sum 的直选注数
+//
+// @Param size int 位数
+//
+// @Param sum int 和值
+//
+// @Return int 注数
+func countSum(size, sum int) int {
+	counts := []int{1}
+
+	for i := 0; i < size; i++ {
+		next := make([]int, len(counts)+9)
+
+		for s, count := range counts {
+			for d := 0; d <= 9; d++ {
+				next[s+d] += count
+			}
+		}
+
+		counts = next
+	}
+
+	if sum < 0 || sum >= len(counts) {
+		return 0
+	}
+
+	return counts[sum]
+}
+
+// countSpan
+//
+// @Description 计算 size 位数字最大值与最小值之差为 span 的直选注数
+//
+// @Param size int 位数
+//
+// @Param span int 跨度
+//
+// @Return int 注数
+func countSpan(size, span int) int {
+	if span < 0 || span > 9 {
+		return 0
+	}
+
+	if span == 0 {
+		return 10
+	}
+
+	// 最小值为 low、最大值为 low+span 的注数，按容斥原理去掉不包含最小值或最大值的情况
+	pow := func(n int) int {
+		result := 1
+
+		for i := 0; i < size; i++ {
+			result *= n
+		}
+
+		return result
+	}
+
+	return (10 - span) * (pow(span+1) - 2*pow(span) + pow(span-1))
+}
+
+// GetBetCount
+//
+// @Description 获取彩票包含的注数，不包含倍投
+//
+// @Return int 注数
+func (ticket *Ticket) GetBetCount() int {
+	switch ticket.Play {
+	case PlayDirect:
+		count := 1
+
+		for _, nums := range ticket.Positions {
+			count *= len(nums)
+		}
+
+		return count
+	case PlayGroup3:
+		if n := len(ticket.Nums); n == 3 && len(lottery.GetDupNums(ticket.Nums)) == 1 {
+			return 1
+		} else {
+			return n * (n - 1)
+		}
+	case PlayGroup6:
+		n := len(ticket.Nums)
+		return n * (n - 1) * (n - 2) / 6
+	case PlayCombo:
+		n := len(ticket.Nums)
+		return n*(n-1) + n*(n-1)*(n-2)/6
+	case PlayDanTuo:
+		return len(lottery.GetCombinations(ticket.Nums, gameRules[ticket.Type].Size-len(ticket.Dan)))
+	case PlaySum:
+		count := 0
+
+		for _, sum := range ticket.Sums {
+			count += countSum(gameRules[ticket.Type].Size, sum)
+		}
+
+		return count
+	case PlaySpan:
+		count := 0
+
+		for _, span := range ticket.Spans {
+			count += countSpan(gameRules[ticket.Type].Size, span)
+		}
+
+		return count
+	}
+
+	return 0
+}
+
+// GetCost
+//
+// @Description 获取彩票的投注金额，注数 × 倍投倍数 × 单注价格
+//
+// @Return int 投注金额
+func (ticket *Ticket) GetCost() int {
+	return ticket.GetBetCount() * max(ticket.Scale, 1) * lottery.BetPrice
+}
+
+func formatDigits(nums []int) string {
+	var str string
+
+	for _, num := range nums {
+		str += strconv.Itoa(num)
+	}
+
+	return str
+}
+
+// Format
+//
+// @Description 格式化彩票的号码，例如: 12-3-456
+//
+// @Return string 格式化后的字符串
+func (ticket *Ticket) Format() string {
+	var parts []string
+
+	switch ticket.Play {
+	case PlayDirect:
+		for _, nums := range ticket.Positions {
+			parts = append(parts, formatDigits(nums))
+		}
+
+		return strings.Join(parts, "-")
+	case PlaySum, PlaySpan:
+		values := ticket.Sums
+		if ticket.Play == PlaySpan {
+			values = ticket.Spans
+		}
+
+		for _, value := range values {
+			parts = append(parts, strconv.Itoa(value))
+		}
+
+		return strings.Join(parts, ",")
+	case PlayDanTuo:
+		return formatDigits(ticket.Dan) + "~" + formatDigits(ticket.Nums)
+	default:
+		return formatDigits(ticket.Nums)
+	}
+}
+
+// String
+//
+// @Description 获取彩票的完整字符串，可以被 Parse 重新解析，例如: PL3/Z6:1234x2:25100
+//
+// @Return string 彩票字符串
+func (ticket *Ticket) String() string {
+	str := ticket.Type
+
+	if ticket.Play != PlayDirect {
+		str += "/" + string(ticket.Play)
+	}
+
+	str += ":" + ticket.Format()
+
+	if ticket.Scale > 1 {
+		str += fmt.Sprintf("x%d", ticket.Scale)
+	}
+
+	if ticket.Index > 0 {
+		str += fmt.Sprintf(":%d", ticket.Index)
+	}
+
+	return str
+}
@@ -0,0 +1,261 @@
+package digit
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/dlt"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		kind   lottery.ErrorKind
+		str    string
+		bets   int
+		single bool
+	}{
+		{"直选单式", "PL3:1-2-3", "", "PL3:1-2-3", 1, true},
+		{"直选复式", "PL3:21-3-654x2:25100", "", "PL3:12-3-456x2:25100", 6, false},
+		{"直选玩法", "PL3/ZX:1-2-3", "", "PL3:1-2-3", 1, true},
+		{"排列五直选", "PL5:1-2-3-4-56", "", "PL5:1-2-3-4-56", 2, false},
+		{"组选3单式", "PL3/Z3:121", "", "PL3/Z3:112", 1, false},
+		{"组选3复式", "PL3/Z3:1234", "", "PL3/Z3:1234", 12, false},
+		{"组选6", "PL3/Z6:54321", "", "PL3/Z6:12345", 10, false},
+		{"和值", "PL3/HZ:10,0:25100x3", "", "PL3/HZ:0,10x3:25100", 64, false},
+		{"福彩3D直选", "3D:0-9-12:2025280", "", "3D:0-9-12:2025280", 2, false},
+		{"福彩3D跨度", "3D/KD:3,0", "", "3D/KD:0,3", 136, false},
+		{"福彩3D包号", "3D/BH:4321", "", "3D/BH:1234", 16, false},
+		{"福彩3D胆拖", "3D/DT:1~5432", "", "3D/DT:1~2345", 6, false},
+		{"福彩3D双胆", "3D/DT:21~34", "", "3D/DT:12~34", 2, false},
+		{"不支持的类型", "PL4:1-2-3", lottery.ErrUnknownType, "", 0, false},
+		{"排列五不支持组选", "PL5/Z3:12", ErrUnknownPlay, "", 0, false},
+		{"缺少号码", "PL3", lottery.ErrSyntax, "", 0, false},
+		{"位数错误", "PL3:1-2", lottery.ErrNumberCount, "", 0, false},
+		{"空位", "PL3:1--3", lottery.ErrNumberCount, "", 0, false},
+		{"错误的字符", "PL3:1-a-3", lottery.ErrSyntax, "", 0, false},
+		{"直选号码重复", "PL3:11-2-3", lottery.ErrDuplicateNumber, "", 0, false},
+		{"组选3豹子", "PL3/Z3:111", lottery.ErrDuplicateNumber, "", 0, false},
+		{"组选3号码太少", "PL3/Z3:1", lottery.ErrNumberCount, "", 0, false},
+		{"组选6号码重复", "PL3/Z6:1123", lottery.ErrDuplicateNumber, "", 0, false},
+		{"组选6号码太少", "PL3/Z6:12", lottery.ErrNumberCount, "", 0, false},
+		{"和值超出范围", "PL3/HZ:28", lottery.ErrNumberRange, "", 0, false},
+		{"和值错误", "PL3/HZ:1a", lottery.ErrInvalidNumber, "", 0, false},
+		{"排列三不支持跨度", "PL3/KD:2", ErrUnknownPlay, "", 0, false},
+		{"跨度超出范围", "3D/KD:10", lottery.ErrNumberRange, "", 0, false},
+		{"跨度错误", "3D/KD:2,a", lottery.ErrInvalidNumber, "", 0, false},
+		{"包号号码重复", "3D/BH:112", lottery.ErrDuplicateNumber, "", 0, false},
+		{"包号号码太少", "3D/BH:1", lottery.ErrNumberCount, "", 0, false},
+		{"胆拖缺少分隔符", "3D/DT:1234", lottery.ErrSyntax, "", 0, false},
+		{"胆码太多", "3D/DT:123~45", lottery.ErrDanCount, "", 0, false},
+		{"胆码与拖码重复", "3D/DT:1~123", lottery.ErrDanTuoConflict, "", 0, false},
+		{"拖码太少", "3D/DT:1~2", lottery.ErrNumberCount, "", 0, false},
+		{"倍投重复", "PL3:1-2-3x2x3", lottery.ErrInvalidScale, "", 0, false},
+		{"期号错误", "PL3:1-2-3:abc", lottery.ErrInvalidIndex, "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket, err := Parse(tt.input)
+
+			if tt.kind != "" {
+				if kind := lottery.GetErrorKind(err); kind != tt.kind {
+					t.Errorf("错误类型期望: %s, 实际: %s (%v)", tt.kind, kind, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ticket.String() != tt.str || ticket.GetBetCount() != tt.bets || ticket.IsSingle() != tt.single {
+				t.Errorf("期望: %s %d注, 实际: %s %d注", tt.str, tt.bets, ticket.String(), ticket.GetBetCount())
+			}
+		})
+	}
+}
+
+func TestGetResult(t *testing.T) {
+	tests := []struct {
+		name   string
+		ticket string
+		draw   string
+		wins   int
+		price  int
+		net    int
+	}{
+		{"直选单式中奖", "PL3:1-2-3", "PL3:1-2-3", 1, 1040, 1040},
+		{"直选顺序不同", "PL3:3-2-1", "PL3:1-2-3", 0, 0, 0},
+		{"直选复式倍投", "PL3:12-3-456x2", "PL3:2-3-5", 2, 2080, 2080},
+		{"组选3单式", "PL3/Z3:112", "PL3:1-2-1", 1, 346, 346},
+		{"组选3单式不中", "PL3/Z3:122", "PL3:1-2-1", 0, 0, 0},
+		{"组选3复式", "PL3/Z3:1234", "PL3:4-4-2", 1, 346, 346},
+		{"组选3遇到组选6", "PL3/Z3:1234", "PL3:1-2-3", 0, 0, 0},
+		{"组选6", "PL3/Z6:12345", "PL3:5-1-3", 1, 173, 173},
+		{"组选6遇到豹子", "PL3/Z6:12345", "PL3:1-1-1", 0, 0, 0},
+		{"和值", "PL3/HZ:6,9", "PL3:1-2-3", 1, 1040, 1040},
+		{"排列五直选计税", "PL5:1-2-3-4-5x2", "PL5:1-2-3-4-5", 2, 200000, 160000},
+		{"福彩3D直选", "3D:1-2-3", "3D:1-2-3", 1, 1040, 1040},
+		{"福彩3D跨度", "3D/KD:2x2", "3D:3-1-3", 2, 2080, 2080},
+		{"福彩3D跨度不中", "3D/KD:3", "3D:3-1-3", 0, 0, 0},
+		{"福彩3D包号中组选3", "3D/BH:123", "3D:3-1-3", 1, 346, 346},
+		{"福彩3D包号中组选6", "3D/BH:123", "3D:3-1-2", 1, 173, 173},
+		{"福彩3D胆拖", "3D/DT:1~234", "3D:4-2-1", 1, 173, 173},
+		{"福彩3D胆拖未中胆码", "3D/DT:1~234", "3D:4-2-3", 0, 0, 0},
+		{"福彩3D胆拖遇到组选3", "3D/DT:1~234", "3D:1-2-2", 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket, err := Parse(tt.ticket)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			draw, err := ParseDraw(tt.draw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := ticket.GetResult(draw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.Wins != tt.wins || result.Price != tt.price || result.Net != tt.net {
+				t.Errorf("期望: %d注 %d %d, 实际: %+v", tt.wins, tt.price, tt.net, result)
+			}
+		})
+	}
+}
+
+func TestGetResultError(t *testing.T) {
+	ticket, _ := Parse("PL3:1-2-3")
+	pl5, _ := ParseDraw("PL5:1-2-3-4-5")
+	complex, _ := Parse("PL3:12-2-3")
+
+	if _, err := ticket.GetResult(pl5); err == nil {
+		t.Error("类型不一致时应该返回错误")
+	}
+
+	if _, err := ticket.GetResult(complex); lottery.GetErrorKind(err) != lottery.ErrNotSingle {
+		t.Errorf("开奖号码不是单式时应该返回错误: %v", err)
+	}
+
+	if _, err := ParseDraw("PL3/Z6:123"); lottery.GetErrorKind(err) != lottery.ErrNotSingle {
+		t.Errorf("开奖号码不是直选时应该返回错误: %v", err)
+	}
+
+	fc3d, _ := ParseDraw("3D:1-2-3")
+
+	if _, err := ticket.GetResult(fc3d); err == nil {
+		t.Error("排列三与福彩3D的号码不应该互相核对")
+	}
+}
+
+func TestExpand(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		first string
+		last  string
+		plays map[Play]int
+	}{
+		{"直选复式", "3D:12-3-45x2", "3D:1-3-4x2", "3D:2-3-5x2", map[Play]int{PlayDirect: 4}},
+		{"组选3单式", "3D/Z3:112", "3D/Z3:112", "3D/Z3:112", map[Play]int{PlayGroup3: 1}},
+		{"组选3复式", "3D/Z3:123", "3D/Z3:112", "3D/Z3:233", map[Play]int{PlayGroup3: 6}},
+		{"组选6", "3D/Z6:1234", "3D/Z6:123", "3D/Z6:234", map[Play]int{PlayGroup6: 4}},
+		{"和值", "3D/HZ:1", "3D:0-0-1", "3D:1-0-0", map[Play]int{PlayDirect: 3}},
+		{"跨度", "3D/KD:0,9:2025280", "3D:0-0-0:2025280", "3D:9-9-9:2025280", map[Play]int{PlayDirect: 10 + 54}},
+		{"包号", "3D/BH:123", "3D/Z3:112", "3D/Z6:123", map[Play]int{PlayGroup3: 6, PlayGroup6: 1}},
+		{"胆拖", "3D/DT:5~1234", "3D/Z6:125", "3D/Z6:345", map[Play]int{PlayGroup6: 6}},
+		{"双胆", "3D/DT:59~12", "3D/Z6:159", "3D/Z6:259", map[Play]int{PlayGroup6: 2}},
+		{"排列三和值", "PL3/HZ:27", "PL3:9-9-9", "PL3:9-9-9", map[Play]int{PlayDirect: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket, err := Parse(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			list := ticket.Expand()
+			if len(list) != ticket.GetBetCount() {
+				t.Fatalf("展开注数与注数不一致: %d, %d", len(list), ticket.GetBetCount())
+			}
+
+			plays := make(map[Play]int)
+			seen := make(map[string]bool)
+
+			for _, single := range list {
+				plays[single.Play]++
+
+				if seen[single.String()] {
+					t.Errorf("单式票重复: %s", single.String())
+				}
+
+				seen[single.String()] = true
+			}
+
+			if list[0].String() != tt.first || list[len(list)-1].String() != tt.last || !maps.Equal(plays, tt.plays) {
+				t.Errorf("期望: %s ... %s %v, 实际: %s ... %s %v", tt.first, tt.last, tt.plays, list[0].String(), list[len(list)-1].String(), plays)
+			}
+		})
+	}
+}
+
+func TestGetDraw(t *testing.T) {
+	tests := []struct {
+		name        string
+		lotteryType string
+		draw        dlt.PoolDraw
+		str         string
+	}{
+		{"排列三", "PL3", dlt.PoolDraw{LotteryDrawNum: "25100", LotteryDrawResult: "1 0 9"}, "PL3:1-0-9:25100"},
+		{"排列五", "PL5", dlt.PoolDraw{LotteryDrawNum: "25100", LotteryDrawResult: "1 0 9 8 8"}, "PL5:1-0-9-8-8:25100"},
+		{"数量错误", "PL5", dlt.PoolDraw{LotteryDrawNum: "25100", LotteryDrawResult: "1 0 9"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draw, err := GetDraw(tt.draw, tt.lotteryType)
+
+			if tt.str == "" {
+				if err == nil {
+					t.Error("应该返回错误")
+				}
+			} else if err != nil || draw.String() != tt.str {
+				t.Errorf("期望: %s, 实际: %s %v", tt.str, draw.String(), err)
+			}
+		})
+	}
+}
+
+func TestCountSum(t *testing.T) {
+	total := 0
+
+	for sum := 0; sum <= 27; sum++ {
+		total += countSum(3, sum)
+	}
+
+	if total != 1000 || countSum(3, 0) != 1 || countSum(3, 10) != 63 || countSum(3, 13) != 75 {
+		t.Errorf("和值注数错误: %d %d %d", total, countSum(3, 10), countSum(3, 13))
+	}
+}
+
+func TestCountSpan(t *testing.T) {
+	total := 0
+
+	for span := 0; span <= 9; span++ {
+		total += countSpan(3, span)
+	}
+
+	if total != 1000 || countSpan(3, 0) != 10 || countSpan(3, 1) != 54 || countSpan(3, 9) != 54 {
+		t.Errorf("跨度注数错误: %d %d %d", total, countSpan(3, 1), countSpan(3, 9))
+	}
+}
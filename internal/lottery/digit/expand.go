@@ -0,0 +1,145 @@
+package digit
+
+import (
+	"slices"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// newSingle
+//
+// @Description 生成与彩票类型、倍投和期号相同的单式票
+//
+// @Param play Play 单式的玩法，直选、组选3或组选6
+//
+// @Param nums []int 号码，直选为每一位的号码，组选为排序后的号码
+//
+// @Return Ticket 单式票
+func (ticket *Ticket) newSingle(play Play, nums []int) Ticket {
+	single := Ticket{Type: ticket.Type, Play: play, Scale: ticket.Scale, Index: ticket.Index}
+
+	if play == PlayDirect {
+		for _, num := range nums {
+			single.Positions = append(single.Positions, []int{num})
+		}
+	} else {
+		single.Nums = slices.Sorted(slices.Values(nums))
+	}
+
+	return single
+}
+
+// genDirectList
+//
+// @Description 生成每一位号码的全部排列
+//
+// @Param positions [][]int 每一位可选的号码
+//
+// @Return [][]int 直选号码列表
+func genDirectList(positions [][]int) [][]int {
+	result := [][]int{{}}
+
+	for _, nums := range positions {
+		var next [][]int
+
+		for _, prefix := range result {
+			for _, num := range nums {
+				next = append(next, append(slices.Clone(prefix), num))
+			}
+		}
+
+		result = next
+	}
+
+	return result
+}
+
+// genGroup3List
+//
+// @Description 生成号码组成的全部组选3，每两个号码组成两注，例如: 12 组成 112 和 122
+//
+// @Param nums []int 号码列表
+//
+// @Return [][]int 组选3号码列表
+func genGroup3List(nums []int) [][]int {
+	var result [][]int
+
+	for _, pair := range lottery.GetCombinations(nums, 2) {
+		result = append(result, []int{pair[0], pair[0], pair[1]}, []int{pair[0], pair[1], pair[1]})
+	}
+
+	return result
+}
+
+// Expand
+//
+// @Description 将彩票展开为直选、组选3或组选6单式票，和值和跨度展开为直选，包号展开为组选3和组选6，胆拖展开为组选6
+//
+// @Return []Ticket 单式票列表
+func (ticket *Ticket) Expand() []Ticket {
+	var (
+		play Play
+		list [][]int
+	)
+
+	size := gameRules[ticket.Type].Size
+
+	switch ticket.Play {
+	case PlayDirect:
+		play, list = PlayDirect, genDirectList(ticket.Positions)
+	case PlayGroup3:
+		play, list = PlayGroup3, genGroup3List(ticket.Nums)
+
+		if ticket.GetBetCount() == 1 {
+			list = [][]int{ticket.Nums}
+		}
+	case PlayGroup6:
+		play, list = PlayGroup6, lottery.GetCombinations(ticket.Nums, size)
+	case PlayCombo:
+		var result []Ticket
+
+		for _, nums := range genGroup3List(ticket.Nums) {
+			result = append(result, ticket.newSingle(PlayGroup3, nums))
+		}
+
+		for _, nums := range lottery.GetCombinations(ticket.Nums, size) {
+			result = append(result, ticket.newSingle(PlayGroup6, nums))
+		}
+
+		return result
+	case PlayDanTuo:
+		play = PlayGroup6
+
+		for _, tuo := range lottery.GetCombinations(ticket.Nums, size-len(ticket.Dan)) {
+			list = append(list, append(slices.Clone(ticket.Dan), tuo...))
+		}
+	case PlaySum, PlaySpan:
+		all := make([][]int, size)
+		for i := range all {
+			all[i] = []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}
+		}
+
+		play = PlayDirect
+
+		for _, nums := range genDirectList(all) {
+			sum := 0
+			for _, num := range nums {
+				sum += num
+			}
+
+			span := slices.Max(nums) - slices.Min(nums)
+
+			if slices.Contains(ticket.Sums, sum) || slices.Contains(ticket.Spans, span) {
+				list = append(list, nums)
+			}
+		}
+	}
+
+	result := make([]Ticket, 0, len(list))
+
+	for _, nums := range list {
+		result = append(result, ticket.newSingle(play, nums))
+	}
+
+	return result
+}
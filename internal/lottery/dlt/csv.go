@@ -0,0 +1,336 @@
+package dlt
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// CSV 列映射，通过表头名称指定各字段所在的列
+//
+// 前区、后区和特别号可以各自对应多列 (front1..front5)，也可以对应一列由空格或逗号分隔的号码
+type CSVMapping struct {
+	Type    string   // 彩票类型 (DLT: 大乐透, SSQ: 双色球, QLC: 七乐彩)
+	Issue   string   // 期号列
+	Date    string   // 开奖日期列，为空表示不导入日期
+	Front   []string // 前区号码列
+	Back    []string // 后区号码列
+	Special []string // 特别号列，只有七乐彩等有特别号的彩票使用
+	Comma   rune     // 分隔符，为0时使用逗号
+}
+
+// CSV 行错误，Row 为文件中的行号（从1开始，包含表头）
+type CSVRowError struct {
+	Row int
+	Err error
+}
+
+func (e *CSVRowError) Error() string {
+	return fmt.Sprintf("第%d行: %s", e.Row, e.Err)
+}
+
+func (e *CSVRowError) Unwrap() error {
+	return e.Err
+}
+
+// NewCSVMapping
+//
+// @Description 生成默认的列映射，列名为 issue, date, front1..frontN, back1..backN，有特别号的彩票还有 special1..specialN
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return CSVMapping 列映射
+//
+// @Return error 错误信息
+func NewCSVMapping(lotteryType string) (CSVMapping, error) {
+	mapping := CSVMapping{Type: lotteryType, Issue: "issue", Date: "date"}
+
+	rule, err := lottery.GetGameRule(lotteryType)
+	if err != nil {
+		return mapping, err
+	}
+
+	for i := 1; i <= rule.FrontSize; i++ {
+		mapping.Front = append(mapping.Front, fmt.Sprintf("front%d", i))
+	}
+
+	for i := 1; i <= rule.BackSize; i++ {
+		mapping.Back = append(mapping.Back, fmt.Sprintf("back%d", i))
+	}
+
+	for i := 1; i <= rule.Special; i++ {
+		mapping.Special = append(mapping.Special, fmt.Sprintf("special%d", i))
+	}
+
+	return mapping, nil
+}
+
+// getColumns
+//
+// @Description 根据表头获取列名对应的列号
+//
+// @Param header []string 表头
+//
+// @Param names []string 列名列表
+//
+// @Return []int 列号列表
+//
+// @Return error 错误信息
+func getColumns(header []string, names []string) ([]int, error) {
+	var columns []int
+
+	indexMap := make(map[string]int, len(header))
+
+	for i, name := range header {
+		name = strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")) // Excel 导出的文件可能带有 BOM
+		indexMap[strings.ToLower(name)] = i
+	}
+
+	for _, name := range names {
+		i, ok := indexMap[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, fmt.Errorf("表头中找不到列: %s", name)
+		}
+
+		columns = append(columns, i)
+	}
+
+	return columns, nil
+}
+
+// getCells
+//
+// @Description 读取号码列，单列时按分隔符拆分为多个号码
+//
+// @Param record []string 行数据
+//
+// @Param columns []int 列号列表
+//
+// @Return []int 号码列表
+//
+// @Return error 错误信息
+func getCells(record []string, columns []int) ([]int, error) {
+	var cells []string
+
+	for _, column := range columns {
+		cells = append(cells, record[column])
+	}
+
+	return parseDrawNums(strings.Join(cells, " "))
+}
+
+// ImportCSV
+//
+// @Description 按列映射导入 CSV 格式的开奖历史，每行都会按玩法规则校验。校验失败的行会被跳过，并通过 CSVRowError 汇总返回
+//
+// @Param r io.Reader CSV 内容，第一行必须是表头
+//
+// @Param mapping CSVMapping 列映射
+//
+// @Return []PoolDraw 校验通过的开奖数据
+//
+// @Return error 错误信息
+func ImportCSV(r io.Reader, mapping CSVMapping) ([]PoolDraw, error) {
+	var (
+		result  []PoolDraw
+		rowErrs []error
+	)
+
+	rule, err := lottery.GetGameRule(mapping.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if mapping.Comma != 0 {
+		reader.Comma = mapping.Comma
+	}
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("表头读取失败: %w", err)
+	}
+
+	names := append([]string{mapping.Issue}, mapping.Front...)
+	names = append(names, mapping.Back...)
+	names = append(names, mapping.Special...)
+	if mapping.Date != "" {
+		names = append(names, mapping.Date)
+	}
+
+	columns, err := getColumns(header, names)
+	if err != nil {
+		return nil, err
+	}
+
+	issueColumn := columns[0]
+	var numColumns [][]int
+
+	start := 1
+	for _, size := range []int{len(mapping.Front), len(mapping.Back), len(mapping.Special)} {
+		numColumns = append(numColumns, columns[start:start+size])
+		start += size
+	}
+	maxColumn := 0
+	for _, column := range columns {
+		maxColumn = max(maxColumn, column)
+	}
+
+	seen := make(map[string]int)
+
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return result, fmt.Errorf("第%d行读取失败: %w", row, err)
+		}
+
+		if len(record) <= maxColumn {
+			rowErrs = append(rowErrs, &CSVRowError{row, fmt.Errorf("列数不足，期望至少%d列，实际%d列", maxColumn+1, len(record))})
+			continue
+		}
+
+		draw, err := parseCSVRecord(record, rule, issueColumn, numColumns)
+		if err != nil {
+			rowErrs = append(rowErrs, &CSVRowError{row, err})
+			continue
+		}
+
+		if mapping.Date != "" {
+			draw.LotteryDrawTime = strings.TrimSpace(record[columns[len(columns)-1]])
+		}
+
+		if pre, ok := seen[draw.LotteryDrawNum]; ok {
+			rowErrs = append(rowErrs, &CSVRowError{row, fmt.Errorf("期号重复: %s，与第%d行重复", draw.LotteryDrawNum, pre)})
+			continue
+		}
+
+		seen[draw.LotteryDrawNum] = row
+		result = append(result, draw)
+	}
+
+	sortDraws(result)
+
+	return result, errors.Join(rowErrs...)
+}
+
+// parseCSVRecord
+//
+// @Description 将一行 CSV 数据转换为开奖数据并校验
+//
+// @Param numColumns [][]int 前区、后区和特别号的列号，号码按此顺序写入开奖号码
+//
+// @Return PoolDraw 开奖数据
+//
+// @Return error 错误信息
+func parseCSVRecord(record []string, rule lottery.GameRule, issueColumn int, numColumns [][]int) (PoolDraw, error) {
+	var nums []int
+
+	for _, columns := range numColumns {
+		cells, err := getCells(record, columns)
+		if err != nil {
+			return PoolDraw{}, err
+		}
+
+		nums = append(nums, cells...)
+	}
+
+	draw := PoolDraw{
+		LotteryDrawNum:    strings.TrimSpace(record[issueColumn]),
+		LotteryDrawResult: formatDrawNums(nums),
+	}
+
+	if _, err := draw.GetLottery(rule.Type); err != nil {
+		return PoolDraw{}, err
+	}
+
+	return draw, nil
+}
+
+// ExportCSV
+//
+// @Description 按列映射导出开奖历史为 CSV，前区、后区和特别号为单列时号码以空格分隔
+//
+// @Param w io.Writer 输出
+//
+// @Param list []PoolDraw 开奖数据列表
+//
+// @Param mapping CSVMapping 列映射
+//
+// @Return error 错误信息
+func ExportCSV(w io.Writer, list []PoolDraw, mapping CSVMapping) error {
+	rule, err := lottery.GetGameRule(mapping.Type)
+	if err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if mapping.Comma != 0 {
+		writer.Comma = mapping.Comma
+	}
+
+	header := append([]string{mapping.Issue}, mapping.Front...)
+	header = append(header, mapping.Back...)
+	header = append(header, mapping.Special...)
+	if mapping.Date != "" {
+		header = append(header, mapping.Date)
+	}
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+
+	for _, draw := range list {
+		lott, err := draw.GetLottery(rule.Type)
+		if err != nil {
+			return err
+		}
+
+		record := []string{draw.LotteryDrawNum}
+		record = append(record, getCSVCells(lott.FrontTuo, len(mapping.Front))...)
+		// 有特别号的彩票，特别号写在开奖号码的后区
+		record = append(record, getCSVCells(lott.BackTuo[:rule.BackSize], len(mapping.Back))...)
+		record = append(record, getCSVCells(lott.BackTuo[rule.BackSize:], len(mapping.Special))...)
+		if mapping.Date != "" {
+			record = append(record, draw.LotteryDrawTime)
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// getCSVCells
+//
+// @Description 将号码列表转换为指定列数的单元格，列数为1时合并为一列
+//
+// @Param nums []int 号码列表
+//
+// @Param size int 列数
+//
+// @Return []string 单元格列表
+func getCSVCells(nums []int, size int) []string {
+	if size == 1 && len(nums) > 1 {
+		return []string{formatDrawNums(nums)}
+	}
+
+	cells := make([]string, size)
+
+	for i := 0; i < size && i < len(nums); i++ {
+		cells[i] = fmt.Sprintf("%02d", nums[i])
+	}
+
+	return cells
+}
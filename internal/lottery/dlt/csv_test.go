@@ -0,0 +1,236 @@
+package dlt
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestImportCSV(t *testing.T) {
+	dltMapping, _ := NewCSVMapping("DLT")
+	noDateMapping := dltMapping
+	noDateMapping.Date = ""
+	ssqMapping := CSVMapping{Type: "SSQ", Issue: "期号", Date: "开奖日期", Front: []string{"红球"}, Back: []string{"蓝球"}}
+
+	tests := []struct {
+		name    string
+		mapping CSVMapping
+		input   string
+		issues  []string
+		results []string
+		errRows []int
+	}{
+		{"大乐透多列", dltMapping,
+			"issue,date,front1,front2,front3,front4,front5,back1,back2\n" +
+				"25052,2025-05-10,3,13,21,27,33,1,6\n" +
+				"25053,2025-05-12,02,04,11,29,30,02,08\n",
+			[]string{"25053", "25052"}, []string{"02 04 11 29 30 02 08", "03 13 21 27 33 01 06"}, nil},
+		{"大乐透乱序号码无日期", noDateMapping,
+			"front5,front4,front3,front2,front1,back2,back1,issue\n" +
+				"30,29,11,04,02,08,02,25053\n",
+			[]string{"25053"}, []string{"02 04 11 29 30 02 08"}, nil},
+		{"大乐透带BOM和多余列", dltMapping,
+			"\ufeffIssue,Date,Front1,Front2,Front3,Front4,Front5,Back1,Back2,Note\n" +
+				"25053,2025-05-12,02,04,11,29,30,02,08,备注\n",
+			[]string{"25053"}, []string{"02 04 11 29 30 02 08"}, nil},
+		{"大乐透校验失败的行", dltMapping,
+			"issue,date,front1,front2,front3,front4,front5,back1,back2\n" +
+				"25053,2025-05-12,02,04,11,29,30,02,08\n" +
+				"25052,2025-05-10,03,13,21,27,36,01,06\n" +
+				"25051,2025-05-07,05,09,18,22,22,04,11\n" +
+				"25050,2025-05-05,01,07,15,26,34,03,13\n" +
+				"25049,2025-05-03,08,12,19,23,31,05\n" +
+				"25053,2025-05-12,02,04,11,29,30,02,08\n" +
+				"2504a,2025-05-03,08,12,19,23,31,05,09\n",
+			[]string{"25053"}, []string{"02 04 11 29 30 02 08"}, []int{3, 4, 5, 6, 7, 8}},
+		{"双色球单列号码", ssqMapping,
+			"期号,开奖日期,红球,蓝球\n" +
+				"2025053,2025-05-11,01 05 12 22 31 33,16\n" +
+				"2025052,2025-05-08,\"02,06,13,23,30,32\",01\n" +
+				"2025051,2025-05-06,02 06 13 23 30 34,01\n",
+			[]string{"2025053", "2025052"}, []string{"01 05 12 22 31 33 16", "02 06 13 23 30 32 01"}, []int{4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := ImportCSV(strings.NewReader(tt.input), tt.mapping)
+
+			var (
+				results []string
+				errRows []int
+			)
+
+			for _, draw := range list {
+				results = append(results, draw.LotteryDrawResult)
+			}
+
+			if joinErr, ok := err.(interface{ Unwrap() []error }); ok {
+				for _, e := range joinErr.Unwrap() {
+					var rowErr *CSVRowError
+
+					if errors.As(e, &rowErr) {
+						errRows = append(errRows, rowErr.Row)
+					}
+				}
+			}
+
+			if issues := getIssues(list); !reflect.DeepEqual(issues, tt.issues) || !reflect.DeepEqual(results, tt.results) {
+				t.Errorf("期望: %v %v, 实际: %v %v", tt.issues, tt.results, issues, results)
+			}
+
+			if !reflect.DeepEqual(errRows, tt.errRows) || (err != nil && len(errRows) == 0) {
+				t.Errorf("错误行期望: %v, 实际: %v。错误信息: %v", tt.errRows, errRows, err)
+			}
+		})
+	}
+}
+
+func TestImportCSVMissingColumn(t *testing.T) {
+	mapping, _ := NewCSVMapping("DLT")
+
+	_, err := ImportCSV(strings.NewReader("issue,front1,front2,front3,front4,front5,back1,back2\n"), mapping)
+
+	if err == nil || err.Error() != "表头中找不到列: date" {
+		t.Errorf("错误信息错误: %v", err)
+	}
+}
+
+func TestExportCSV(t *testing.T) {
+	store, err := LoadStore(filepath.Join("testdata", "dlt_history.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, mapping := range []CSVMapping{
+		{Type: "DLT", Issue: "issue", Date: "date", Front: []string{"front1", "front2", "front3", "front4", "front5"}, Back: []string{"back1", "back2"}},
+		{Type: "DLT", Issue: "期号", Front: []string{"前区"}, Back: []string{"后区"}, Comma: ';'},
+	} {
+		var buf bytes.Buffer
+
+		if err := ExportCSV(&buf, store.List, mapping); err != nil {
+			t.Fatalf("导出失败: %s", err)
+		}
+
+		list, err := ImportCSV(&buf, mapping)
+		if err != nil {
+			t.Fatalf("导入失败: %s", err)
+		}
+
+		for i, draw := range list {
+			expected := store.List[i]
+
+			if draw.LotteryDrawNum != expected.LotteryDrawNum || draw.LotteryDrawResult != expected.LotteryDrawResult {
+				t.Errorf("期望: %s %s, 实际: %s %s", expected.LotteryDrawNum, expected.LotteryDrawResult, draw.LotteryDrawNum, draw.LotteryDrawResult)
+			}
+
+			if mapping.Date != "" && draw.LotteryDrawTime != expected.LotteryDrawTime {
+				t.Errorf("开奖日期期望: %s, 实际: %s", expected.LotteryDrawTime, draw.LotteryDrawTime)
+			}
+		}
+	}
+}
+
+func TestExportCSVSpecial(t *testing.T) {
+	list := []PoolDraw{
+		{LotteryDrawNum: "2025102", LotteryDrawResult: "01 05 09 12 18 22 30 07", LotteryDrawTime: "2025-09-03"},
+		{LotteryDrawNum: "2025101", LotteryDrawResult: "02 04 06 08 10 12 14 16", LotteryDrawTime: "2025-09-01"},
+	}
+
+	qlcMapping, err := NewCSVMapping("QLC")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		mapping CSVMapping
+		first   string
+	}{
+		{"七乐彩默认列", qlcMapping, "issue,front1,front2,front3,front4,front5,front6,front7,special1,date\n2025102,01,05,09,12,18,22,30,07,2025-09-03\n"},
+		{"七乐彩单列号码", CSVMapping{Type: "QLC", Issue: "期号", Front: []string{"基本号码"}, Special: []string{"特别号"}}, "期号,基本号码,特别号\n2025102,01 05 09 12 18 22 30,07\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := ExportCSV(&buf, list, tt.mapping); err != nil {
+				t.Fatalf("导出失败: %s", err)
+			}
+
+			if !strings.HasPrefix(buf.String(), tt.first) {
+				t.Errorf("导出内容期望: %q, 实际: %q", tt.first, buf.String())
+			}
+
+			imported, err := ImportCSV(&buf, tt.mapping)
+			if err != nil {
+				t.Fatalf("导入失败: %s", err)
+			}
+
+			for i, draw := range imported {
+				if draw.LotteryDrawNum != list[i].LotteryDrawNum || draw.LotteryDrawResult != list[i].LotteryDrawResult {
+					t.Errorf("期望: %s %s, 实际: %s %s", list[i].LotteryDrawNum, list[i].LotteryDrawResult, draw.LotteryDrawNum, draw.LotteryDrawResult)
+				}
+			}
+
+			if len(imported) != len(list) {
+				t.Errorf("导入期数期望: %d, 实际: %d", len(list), len(imported))
+			}
+		})
+	}
+}
+
+func TestStoreMerge(t *testing.T) {
+	store, _ := LoadStore(filepath.Join("testdata", "dlt_history.json"))
+	store.List = store.List[1:4]
+
+	added := store.Merge([]PoolDraw{
+		{LotteryDrawNum: "25049", LotteryDrawResult: "08 12 19 23 31 05 09"},
+		{LotteryDrawNum: "25053", LotteryDrawResult: "02 04 11 29 30 02 08"},
+		{LotteryDrawNum: "25051", LotteryDrawResult: "01 02 03 04 05 01 02"},
+	})
+
+	if added != 2 {
+		t.Errorf("新增期数期望: 2, 实际: %d", added)
+	}
+
+	if issues := getIssues(store.List); !reflect.DeepEqual(issues, fixtureIssues) {
+		t.Errorf("期望: %v, 实际: %v", fixtureIssues, issues)
+	}
+
+	if store.List[2].LotteryDrawResult != "01 02 03 04 05 01 02" {
+		t.Errorf("期号相同的数据应该被覆盖: %+v", store.List[2])
+	}
+}
+
+func TestStoreMergeNumbers(t *testing.T) {
+	store, _ := LoadStore(filepath.Join("testdata", "dlt_history.json"))
+	expected, _ := LoadStore(filepath.Join("testdata", "dlt_history.json"))
+	store.List = store.List[:4]
+
+	added := store.MergeNumbers([]PoolDraw{
+		{LotteryDrawNum: "25053", LotteryDrawResult: "2 4 11 29 30 2 8"},
+		{LotteryDrawNum: "25052", LotteryDrawResult: "01 02 03 04 05 01 02", LotteryDrawTime: "2025-05-10"},
+		{LotteryDrawNum: "25049", LotteryDrawResult: "08 12 19 23 31 05 09", LotteryDrawTime: "2025-05-03"},
+	})
+
+	if added != 1 {
+		t.Errorf("新增期数期望: 1, 实际: %d", added)
+	}
+
+	// 开奖号码一致时保留奖级和奖池数据
+	if !reflect.DeepEqual(store.List[0], expected.List[0]) {
+		t.Errorf("期望: %+v, 实际: %+v", expected.List[0], store.List[0])
+	}
+
+	if draw := store.List[1]; draw.LotteryDrawResult != "01 02 03 04 05 01 02" || len(draw.PrizeLevelList) != 0 {
+		t.Errorf("开奖号码不一致时应该被覆盖: %+v", draw)
+	}
+
+	if issues := getIssues(store.List); !reflect.DeepEqual(issues, fixtureIssues) {
+		t.Errorf("期望: %v, 实际: %v", fixtureIssues, issues)
+	}
+}
@@ -0,0 +1,97 @@
+package dlt
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newCWLServer 模拟福彩接口，共3期七乐彩开奖数据
+func newCWLServer(t *testing.T) *httptest.Server {
+	draws := []string{
+		`{"name":"七乐彩","code":"2025102","date":"2025-09-03(三)","red":"01,05,09,12,18,22,30","blue":"07","poolmoney":"0","prizegrades":[{"type":1,"typenum":"1","typemoney":"1,234,567"},{"type":4,"typenum":"120","typemoney":"200"}]}`,
+		`{"name":"七乐彩","code":"2025101","date":"2025-09-01(一)","red":"02,04,06,08,10,12,14","blue":"16","poolmoney":"0","prizegrades":[]}`,
+		`{"name":"七乐彩","code":"2025100","date":"2025-08-29(五)","red":"03,05,11,13,20,24,29","blue":"18","poolmoney":"0","prizegrades":[]}`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("name") != "qlc" {
+			http.Error(w, "bad name", http.StatusBadRequest)
+			return
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("pageNo"))
+		pageSize, _ := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		start := min((page-1)*pageSize, len(draws))
+		end := min(start+pageSize, len(draws))
+
+		result := "[" + strings.Join(draws[start:end], ",") + "]"
+
+		fmt.Fprintf(w, `{"state":0,"message":"查询成功","total":%d,"pageNo":%d,"pageSize":%d,"result":%s}`, len(draws), page, pageSize, result)
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestCWLSource(t *testing.T) {
+	src := &CWLSource{BaseURL: newCWLServer(t).URL, Name: "qlc", PageSize: 2}
+
+	list, err := getFullHistory(src, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if issues := getIssues(list); !reflect.DeepEqual(issues, []string{"2025102", "2025101", "2025100"}) {
+		t.Fatalf("期号错误: %v", issues)
+	}
+
+	draw := list[0]
+
+	if draw.LotteryDrawResult != "01 05 09 12 18 22 30 07" || draw.LotteryDrawTime != "2025-09-03" || draw.PrizeLevelList[0].PrizeLevel != "一等奖" {
+		t.Errorf("转换错误: %+v", draw)
+	}
+
+	lott, err := draw.GetLottery("QLC")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lott.String() != "QLC:01,05,09,12,18,22,30-07:2025102" {
+		t.Errorf("开奖号码错误: %s", lott.String())
+	}
+
+	if _, err := (&CWLSource{BaseURL: src.BaseURL, Name: "ssq"}).GetPage(1); err == nil {
+		t.Error("接口返回错误时应该失败")
+	}
+}
+
+func TestNewSource(t *testing.T) {
+	tests := []struct {
+		lotteryType string
+		ok          bool
+	}{
+		{"DLT", true},
+		{"QXC", true},
+		{"SSQ", true},
+		{"QLC", true},
+		{"KL8", false},
+	}
+
+	for _, tt := range tests {
+		src, err := NewSource(tt.lotteryType)
+
+		if (err == nil) != tt.ok || (tt.ok && src == nil) {
+			t.Errorf("%s: %v %v", tt.lotteryType, src, err)
+		}
+	}
+
+	if src, _ := NewSource("QLC"); src.(*CWLSource).Name != "qlc" {
+		t.Errorf("七乐彩应该使用福彩接口: %+v", src)
+	}
+}
@@ -0,0 +1,239 @@
+package dlt
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+func init() {
+	lottery.RegisterMessages(lottery.LocaleZhCN, map[string]string{
+		"dlt.draw_check": "开奖号码校验失败: %s。期号: %s",
+	})
+
+	lottery.RegisterMessages(lottery.LocaleEn, map[string]string{
+		"dlt.draw_check": "invalid draw numbers: %s. issue: %s",
+	})
+}
+
+// parseDrawNums
+//
+// @Description 解析开奖号码字符串，号码之间以空格或逗号分隔，例如: 02 04 11 29 30 02 08
+//
+// @Param input string 开奖号码字符串
+//
+// @Return []int 号码列表
+//
+// @Return error 错误信息
+func parseDrawNums(input string) ([]int, error) {
+	var nums []int
+
+	fields := strings.FieldsFunc(input, func(char rune) bool {
+		return char == ' ' || char == ',' || char == '+' || char == '|'
+	})
+
+	for _, field := range fields {
+		num, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, fmt.Errorf("开奖号码解析失败: %s。输入: %s", field, input)
+		}
+
+		nums = append(nums, num)
+	}
+
+	return nums, nil
+}
+
+// formatDrawNums
+//
+// @Description 将号码列表格式化为开奖号码字符串
+//
+// @Param nums []int 号码列表
+//
+// @Return string 开奖号码字符串
+func formatDrawNums(nums []int) string {
+	strs := make([]string, len(nums))
+
+	for i, num := range nums {
+		strs[i] = fmt.Sprintf("%02d", num)
+	}
+
+	return strings.Join(strs, " ")
+}
+
+// GetIssue
+//
+// @Description 获取开奖期号
+//
+// @Return int 期号
+//
+// @Return error 错误信息
+func (draw *PoolDraw) GetIssue() (int, error) {
+	issue, err := strconv.Atoi(draw.LotteryDrawNum)
+	if err != nil {
+		return 0, fmt.Errorf("期号解析失败: %s", draw.LotteryDrawNum)
+	}
+
+	return issue, nil
+}
+
+// GetLottery
+//
+// @Description 将开奖数据转换为单式票，期号写入 Index，并按玩法规则校验开奖号码
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return lottery.Lottery 开奖号码对应的单式票
+//
+// @Return error 错误信息
+func (draw *PoolDraw) GetLottery(lotteryType string) (lottery.Lottery, error) {
+	var result lottery.Lottery
+
+	rule, err := lottery.GetGameRule(lotteryType)
+	if err != nil {
+		return result, err
+	}
+
+	issue, err := draw.GetIssue()
+	if err != nil {
+		return result, err
+	}
+
+	nums, err := parseDrawNums(draw.LotteryDrawResult)
+	if err != nil {
+		return result, err
+	}
+
+	// 有特别号的彩票，特别号写在后区
+	if size := rule.FrontSize + rule.BackSize + rule.Special; len(nums) != size {
+		return result, fmt.Errorf("开奖号码数量错误，期望: %d, 实际: %d。期号: %s", size, len(nums), draw.LotteryDrawNum)
+	}
+
+	result.Type = lotteryType
+	result.Index = issue
+	result.Scale = 1
+	result.FrontTuo = nums[:rule.FrontSize]
+	result.BackTuo = nums[rule.FrontSize:]
+
+	if err := rule.CheckDraw(result.LotteryParts); err != nil {
+		return result, lottery.NewError("", "dlt.draw_check", err, draw.LotteryDrawNum)
+	}
+
+	return result, nil
+}
+
+// sortDraws
+//
+// @Description 将开奖数据按期号从新到旧排序，与体彩接口的返回顺序一致
+//
+// @Param list []PoolDraw 开奖数据列表
+func sortDraws(list []PoolDraw) {
+	sort.SliceStable(list, func(i, j int) bool {
+		a, _ := list[i].GetIssue()
+		b, _ := list[j].GetIssue()
+
+		return a > b
+	})
+}
+
+// Merge
+//
+// @Description 将开奖数据合并到历史数据中，期号相同的数据会被覆盖，合并后按期号从新到旧排序
+//
+// @Param list []PoolDraw 开奖数据列表
+//
+// @Return int 新增的期数
+func (store *Store) Merge(list []PoolDraw) int {
+	added := 0
+	indexMap := make(map[string]int, len(store.List))
+
+	for i, draw := range store.List {
+		indexMap[draw.LotteryDrawNum] = i
+	}
+
+	for _, draw := range list {
+		if i, ok := indexMap[draw.LotteryDrawNum]; ok {
+			store.List[i] = draw
+			continue
+		}
+
+		indexMap[draw.LotteryDrawNum] = len(store.List)
+		store.List = append(store.List, draw)
+		added++
+	}
+
+	sortDraws(store.List)
+
+	return added
+}
+
+// MergeNumbers
+//
+// @Description 合并只有开奖号码的数据 (例如从 CSV 导入)，期号已存在且开奖号码一致时保留原有的奖级和奖池数据，开奖号码不一致时覆盖
+//
+// @Param list []PoolDraw 开奖数据列表
+//
+// @Return int 新增的期数
+func (store *Store) MergeNumbers(list []PoolDraw) int {
+	existing := make(map[string]PoolDraw, len(store.List))
+
+	for _, draw := range store.List {
+		existing[draw.LotteryDrawNum] = draw
+	}
+
+	merged := make([]PoolDraw, 0, len(list))
+
+	for _, draw := range list {
+		if old, ok := existing[draw.LotteryDrawNum]; ok && sameDrawNums(old.LotteryDrawResult, draw.LotteryDrawResult) {
+			if old.LotteryDrawTime == "" {
+				old.LotteryDrawTime = draw.LotteryDrawTime
+			}
+
+			draw = old
+		}
+
+		merged = append(merged, draw)
+	}
+
+	return store.Merge(merged)
+}
+
+// sameDrawNums
+//
+// @Description 比较两个开奖号码字符串的号码是否一致，忽略分隔符和补零的差异
+//
+// @Return bool 是否一致
+func sameDrawNums(a, b string) bool {
+	numsA, errA := parseDrawNums(a)
+	numsB, errB := parseDrawNums(b)
+
+	return errA == nil && errB == nil && slices.Equal(numsA, numsB)
+}
+
+// GetLotteryList
+//
+// @Description 将历史数据转换为开奖号码列表，顺序与历史数据一致（从新到旧）
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return []lottery.Lottery 开奖号码列表
+//
+// @Return error 错误信息
+func (store *Store) GetLotteryList(lotteryType string) ([]lottery.Lottery, error) {
+	result := make([]lottery.Lottery, 0, len(store.List))
+
+	for _, draw := range store.List {
+		lott, err := draw.GetLottery(lotteryType)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, lott)
+	}
+
+	return result, nil
+}
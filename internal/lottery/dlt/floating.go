@@ -0,0 +1,92 @@
+package dlt
+
+import (
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// GetPoolBalance
+//
+// @Description 获取开奖后的奖池余额
+//
+// @Return int64 奖池余额 (元)，不足1元的部分舍去
+//
+// @Return error 错误信息
+func (draw *PoolDraw) GetPoolBalance() (int64, error) {
+	amount, err := parseAmount(draw.PoolBalanceAfterdraw)
+	if err != nil {
+		return 0, err
+	}
+
+	return amount / 100, nil
+}
+
+// GetFloatingPrizes
+//
+// @Description 统计最近若干期浮动奖的平均单注奖金，只统计有人中奖的期，奖级规则按期号选择
+//
+// @Param list []PoolDraw 开奖数据列表，从新到旧排列
+//
+// @Param lotteryType string 彩票类型
+//
+// @Param window int 统计的期数，为0时统计全部期数
+//
+// @Return map[int]int 中奖等级对应的平均单注奖金 (元)，没有人中奖的等级不包含在内
+//
+// @Return error 错误信息
+func GetFloatingPrizes(list []PoolDraw, lotteryType string, window int) (map[int]int, error) {
+	if window > 0 && window < len(list) {
+		list = list[:window]
+	}
+
+	totals := make(map[int]int64)
+	counts := make(map[int]int64)
+
+	for _, draw := range list {
+		issue, err := draw.GetIssue()
+		if err != nil {
+			return nil, err
+		}
+
+		rule, err := lottery.GetPrizeRule(lotteryType, issue)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, level := range rule.Levels {
+			if !level.Floating {
+				continue
+			}
+
+			label := lottery.GetLocaleLevelLabel(lottery.LocaleZhCN, level.Level)
+
+			for _, item := range draw.PrizeLevelList {
+				if item.PrizeLevel != label {
+					continue
+				}
+
+				count, err := parseAmount(item.StakeCount)
+				if err != nil {
+					return nil, err
+				}
+
+				amount, err := parseAmount(item.StakeAmount)
+				if err != nil {
+					return nil, err
+				}
+
+				if count > 0 && amount > 0 {
+					totals[level.Level] += amount
+					counts[level.Level]++
+				}
+			}
+		}
+	}
+
+	result := make(map[int]int, len(totals))
+
+	for level, total := range totals {
+		result[level] = int(total / counts[level] / 100)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,42 @@
+package dlt
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGetFloatingPrizes(t *testing.T) {
+	store, _ := LoadStore(filepath.Join("testdata", "dlt_history.json"))
+
+	list := append([]PoolDraw{}, store.List...)
+	list[0].PrizeLevelList = append(list[0].PrizeLevelList, PrizeLevel{PrizeLevel: "二等奖", StakeCount: "10", StakeAmount: "150,000.50"})
+	list[1].PrizeLevelList = append(list[1].PrizeLevelList, PrizeLevel{PrizeLevel: "二等奖", StakeCount: "5", StakeAmount: "250,000"})
+	list[2].PrizeLevelList = append(list[2].PrizeLevelList, PrizeLevel{PrizeLevel: "二等奖", StakeCount: "0", StakeAmount: "---"})
+
+	tests := []struct {
+		name   string
+		window int
+		result map[int]int
+	}{
+		{"全部期数", 0, map[int]int{1: 8000000, 2: 200000}},
+		{"最近一期", 1, map[int]int{1: 8000000, 2: 150000}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := GetFloatingPrizes(list, "DLT", tt.window)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !reflect.DeepEqual(result, tt.result) {
+				t.Errorf("期望: %v, 实际: %v", tt.result, result)
+			}
+		})
+	}
+
+	if balance, err := list[0].GetPoolBalance(); err != nil || balance != 812345678 {
+		t.Errorf("奖池余额错误: %d %v", balance, err)
+	}
+}
@@ -0,0 +1,250 @@
+package dlt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+const (
+	defaultBaseURL  = "https://webapi.sporttery.cn/gateway/lottery/getHistoryPageListV1.qry"
+	defaultGameNo   = "85"
+	defaultPageSize = 100
+)
+
+// 彩票类型对应的体彩接口游戏编号
+var GameNos = map[string]string{
+	"DLT": "85",     // 大乐透
+	"PL3": "35",     // 排列三
+	"PL5": "350133", // 排列五
+	"QXC": "04",     // 七星彩
+}
+
+// 开奖数据源，按页获取历史开奖数据，页码从1开始
+type Source interface {
+	GetPage(page int) (HistoryValue, error)
+}
+
+// 体彩官网接口数据源
+type HTTPSource struct {
+	BaseURL  string       // 接口地址，为空时使用体彩官网地址
+	GameNo   string       // 游戏编号，见 GameNos，为空时为大乐透
+	PageSize int          // 每页数量
+	Client   *http.Client // 为空时使用 http.DefaultClient
+}
+
+// 本地历史文件数据源，读取 CheckStore 保存的 dlt_history.json，文件只在第一次获取页面时读取一次，可以并发使用
+type FileSource struct {
+	Path     string // 历史文件路径
+	PageSize int    // 每页数量
+
+	once  sync.Once
+	store Store
+	err   error
+}
+
+// 录制数据源，将 Source 返回的每一页保存到 Dir 目录中
+type RecordSource struct {
+	Source Source // 被录制的数据源
+	Dir    string // 录制目录
+}
+
+// 回放数据源，读取 RecordSource 录制的页面
+type ReplaySource struct {
+	Dir string // 录制目录
+}
+
+// NewHTTPSource
+//
+// @Description 创建大乐透的体彩官网接口数据源
+//
+// @Return *HTTPSource 数据源
+func NewHTTPSource() *HTTPSource {
+	return &HTTPSource{
+		BaseURL:  defaultBaseURL,
+		GameNo:   defaultGameNo,
+		PageSize: defaultPageSize,
+	}
+}
+
+// NewGameSource
+//
+// @Description 创建指定彩票类型的体彩官网接口数据源
+//
+// @Param lotteryType string 彩票类型 (DLT, PL3, PL5, QXC)
+//
+// @Return *HTTPSource 数据源
+//
+// @Return error 错误信息
+func NewGameSource(lotteryType string) (*HTTPSource, error) {
+	gameNo, ok := GameNos[lotteryType]
+	if !ok {
+		return nil, fmt.Errorf("体彩接口不支持的彩票类型: %s", lotteryType)
+	}
+
+	src := NewHTTPSource()
+	src.GameNo = gameNo
+
+	return src, nil
+}
+
+// decodeHistory
+//
+// @Description 解析接口返回的历史数据
+//
+// @Param r io.Reader 接口响应内容
+//
+// @Return HistoryValue 历史数据
+//
+// @Return error 错误信息
+func decodeHistory(r io.Reader) (HistoryValue, error) {
+	var history HistoryResponse
+
+	if err := json.NewDecoder(r).Decode(&history); err != nil {
+		return HistoryValue{}, err
+	}
+
+	if !history.Success {
+		return HistoryValue{}, fmt.Errorf("接口返回失败。错误码: %s, 错误信息: %s", history.ErrorCode, history.ErrorMessage)
+	}
+
+	return history.Value, nil
+}
+
+func (src *HTTPSource) GetPage(page int) (HistoryValue, error) {
+	baseURL := src.BaseURL
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+
+	gameNo := src.GameNo
+	if gameNo == "" {
+		gameNo = defaultGameNo
+	}
+
+	pageSize := src.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	client := src.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	url := fmt.Sprintf("%s?gameNo=%s&provinceId=0&pageSize=%d&isVerify=1&pageNo=%d", baseURL, gameNo, pageSize, page)
+
+	resp, err := client.Get(url)
+	if err != nil {
+		return HistoryValue{}, fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return HistoryValue{}, fmt.Errorf("请求失败，状态码: %d, page: %d", resp.StatusCode, page)
+	}
+
+	history, err := decodeHistory(resp.Body)
+	if err != nil {
+		return HistoryValue{}, fmt.Errorf("解析失败, page: %d: %w", page, err)
+	}
+
+	return history, nil
+}
+
+func (src *FileSource) GetPage(page int) (HistoryValue, error) {
+	src.once.Do(func() {
+		src.store, src.err = LoadStore(src.Path)
+	})
+
+	if src.err != nil {
+		return HistoryValue{}, src.err
+	}
+
+	pageSize := src.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+
+	list := src.store.List
+	total := len(list)
+	pages := (total + pageSize - 1) / pageSize
+
+	if page < 1 || (page > pages && total > 0) {
+		return HistoryValue{}, fmt.Errorf("页码超出范围: %d, 总页数: %d", page, pages)
+	}
+
+	start := min((page-1)*pageSize, total)
+	end := min(start+pageSize, total)
+
+	value := HistoryValue{
+		List:     list[start:end],
+		PageNo:   page,
+		PageSize: pageSize,
+		Pages:    pages,
+		Total:    total,
+	}
+
+	if total > 0 {
+		value.LastPoolDraw = list[0]
+	}
+
+	return value, nil
+}
+
+// getPagePath
+//
+// @Description 获取录制页面的文件路径
+//
+// @Param dir string 录制目录
+//
+// @Param page int 页码
+//
+// @Return string 文件路径
+func getPagePath(dir string, page int) string {
+	return filepath.Join(dir, fmt.Sprintf("page_%03d.json", page))
+}
+
+func (src *RecordSource) GetPage(page int) (HistoryValue, error) {
+	value, err := src.Source.GetPage(page)
+	if err != nil {
+		return value, err
+	}
+
+	if err := os.MkdirAll(src.Dir, 0755); err != nil {
+		return value, err
+	}
+
+	jsonData, err := json.MarshalIndent(HistoryResponse{Success: true, Value: value}, "", "  ")
+	if err != nil {
+		return value, err
+	}
+
+	if err := os.WriteFile(getPagePath(src.Dir, page), jsonData, 0644); err != nil {
+		return value, err
+	}
+
+	return value, nil
+}
+
+func (src *ReplaySource) GetPage(page int) (HistoryValue, error) {
+	file, err := os.Open(getPagePath(src.Dir, page))
+	if errors.Is(err, os.ErrNotExist) {
+		return HistoryValue{}, fmt.Errorf("未录制的页面: %d, 目录: %s", page, src.Dir)
+	} else if err != nil {
+		return HistoryValue{}, err
+	}
+	defer file.Close()
+
+	history, err := decodeHistory(file)
+	if err != nil {
+		return HistoryValue{}, fmt.Errorf("解析失败, page: %d: %w", page, err)
+	}
+
+	return history, nil
+}
@@ -0,0 +1,188 @@
+package dlt
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+var fixtureIssues = []string{"25053", "25052", "25051", "25050", "25049"}
+
+func getIssues(list []PoolDraw) []string {
+	var issues []string
+
+	for _, draw := range list {
+		issues = append(issues, draw.LotteryDrawNum)
+	}
+
+	return issues
+}
+
+// newFixtureServer 使用 testdata/history 中录制的页面模拟体彩接口
+func newFixtureServer(t *testing.T) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("gameNo") != "85" {
+			http.Error(w, "bad gameNo", http.StatusBadRequest)
+			return
+		}
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("pageNo"))
+		http.ServeFile(w, r, getPagePath(filepath.Join("testdata", "history"), page))
+	}))
+
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestGetFullHistory(t *testing.T) {
+	tests := []struct {
+		name string
+		src  Source
+	}{
+		{"回放数据源", &ReplaySource{Dir: filepath.Join("testdata", "history")}},
+		{"文件数据源，每页2条", &FileSource{Path: filepath.Join("testdata", "dlt_history.json"), PageSize: 2}},
+		{"文件数据源，每页3条", &FileSource{Path: filepath.Join("testdata", "dlt_history.json"), PageSize: 3}},
+		{"文件数据源，单页", &FileSource{Path: filepath.Join("testdata", "dlt_history.json")}},
+		{"接口数据源", &HTTPSource{BaseURL: newFixtureServer(t).URL, GameNo: "85", PageSize: 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list, err := getFullHistory(tt.src, nil)
+
+			if err != nil {
+				t.Errorf("错误信息: %s", err)
+			} else if issues := getIssues(list); !reflect.DeepEqual(issues, fixtureIssues) {
+				t.Errorf("期望: %v, 实际: %v", fixtureIssues, issues)
+			}
+		})
+	}
+}
+
+func TestGetFullHistoryError(t *testing.T) {
+	// 只保留第一页，其余页面缺失
+	dir := t.TempDir()
+	jsonData, err := os.ReadFile(filepath.Join("testdata", "history", "page_001.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := os.WriteFile(getPagePath(dir, 1), jsonData, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := getFullHistory(&ReplaySource{Dir: dir}, nil); err == nil {
+		t.Errorf("缺少页面时应该失败")
+	}
+}
+
+func TestFileSourceConcurrent(t *testing.T) {
+	src := &FileSource{Path: filepath.Join("testdata", "dlt_history.json"), PageSize: 1}
+
+	var wg sync.WaitGroup
+
+	// 多个协程同时读取页面，文件只读取一次，使用 -race 运行时不应该报告数据竞争
+	for page := 1; page <= len(fixtureIssues); page++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+
+			value, err := src.GetPage(p)
+			if err != nil {
+				t.Errorf("页面%d读取失败: %s", p, err)
+			} else if issues := getIssues(value.List); !reflect.DeepEqual(issues, fixtureIssues[p-1:p]) {
+				t.Errorf("页面%d期望: %v, 实际: %v", p, fixtureIssues[p-1:p], issues)
+			}
+		}(page)
+	}
+
+	wg.Wait()
+}
+
+func TestRecordSource(t *testing.T) {
+	dir := t.TempDir()
+	src := &RecordSource{
+		Source: &HTTPSource{BaseURL: newFixtureServer(t).URL, PageSize: 2},
+		Dir:    dir,
+	}
+
+	recorded, err := getFullHistory(src, nil)
+	if err != nil {
+		t.Fatalf("录制失败: %s", err)
+	}
+
+	replayed, err := getFullHistory(&ReplaySource{Dir: dir}, nil)
+	if err != nil {
+		t.Fatalf("回放失败: %s", err)
+	}
+
+	if !reflect.DeepEqual(recorded, replayed) {
+		t.Errorf("回放结果与录制结果不一致。录制: %v, 回放: %v", getIssues(recorded), getIssues(replayed))
+	}
+}
+
+func TestSyncStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "dlt_history.json")
+
+	src := &ReplaySource{Dir: filepath.Join("testdata", "history")}
+
+	if _, err := SyncStore(src, "DLT", path); err != nil {
+		t.Fatalf("同步失败: %s", err)
+	}
+
+	store, err := LoadGameStore(path, "DLT")
+	if err != nil {
+		t.Fatalf("读取失败: %s", err)
+	}
+
+	expected, _ := LoadStore(filepath.Join("testdata", "dlt_history.json"))
+
+	if !reflect.DeepEqual(store.List, expected.List) || store.Type != "DLT" {
+		t.Errorf("期望: %v, 实际: %s %v", getIssues(expected.List), store.Type, getIssues(store.List))
+	}
+
+	// 不同彩票的开奖数据不能写入同一个文件
+	if _, err := SyncStore(src, "PL3", path); err == nil {
+		t.Error("覆盖其他彩票类型的历史文件时应该失败")
+	}
+
+	if _, err := LoadGameStore(path, "PL3"); err == nil {
+		t.Error("读取其他彩票类型的历史文件时应该失败")
+	}
+
+	// 没有保存彩票类型的旧文件不检查
+	if _, err := LoadGameStore(filepath.Join("testdata", "dlt_history.json"), "PL3"); err != nil {
+		t.Errorf("旧文件不应该检查彩票类型: %s", err)
+	}
+}
+
+func TestNewGameSource(t *testing.T) {
+	tests := []struct {
+		lotteryType string
+		gameNo      string
+	}{
+		{"DLT", "85"},
+		{"PL3", "35"},
+		{"PL5", "350133"},
+		{"QXC", "04"},
+		{"SSQ", ""},
+	}
+
+	for _, tt := range tests {
+		src, err := NewGameSource(tt.lotteryType)
+
+		if tt.gameNo == "" {
+			if err == nil {
+				t.Errorf("%s 应该不支持", tt.lotteryType)
+			}
+		} else if err != nil || src.GameNo != tt.gameNo {
+			t.Errorf("%s 期望: %s, 实际: %+v %v", tt.lotteryType, tt.gameNo, src, err)
+		}
+	}
+}
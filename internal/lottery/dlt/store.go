@@ -3,54 +3,128 @@ package dlt
 import (
 	"encoding/json"
 	"fmt"
-	"log"
-	"net/http"
 	"os"
 	"sync"
 	"time"
 )
 
-func getPageData(page int) (HistoryValue, error) {
-	url := fmt.Sprintf("https://webapi.sporttery.cn/gateway/lottery/getHistoryPageListV1.qry?gameNo=85&provinceId=0&pageSize=100&isVerify=1&pageNo=%d", page)
+// 历史开奖数据存储，对应 dlt_history.json 文件
+type Store struct {
+	UpdateTime string     `json:"updateTime"`
+	Type       string     `json:"type,omitempty"` // 彩票类型，旧版本的文件没有保存彩票类型
+	List       []PoolDraw `json:"list"`
+}
+
+// LoadStore
+//
+// @Description 读取历史开奖数据文件
+//
+// @Param path string 文件路径
+//
+// @Return Store 历史开奖数据
+//
+// @Return error 错误信息
+func LoadStore(path string) (Store, error) {
+	var store Store
+
+	jsonData, err := os.ReadFile(path)
+	if err != nil {
+		return store, fmt.Errorf("文件读取失败: %w", err)
+	}
+
+	if err := json.Unmarshal(jsonData, &store); err != nil {
+		return store, fmt.Errorf("json解析失败: %w", err)
+	}
+
+	return store, nil
+}
 
-	resp, err := http.Get(url)
+// LoadGameStore
+//
+// @Description 读取历史开奖数据文件，并检查文件中保存的彩票类型，没有保存彩票类型的旧文件不检查
+//
+// @Param path string 文件路径
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return Store 历史开奖数据
+//
+// @Return error 错误信息
+func LoadGameStore(path, lotteryType string) (Store, error) {
+	store, err := LoadStore(path)
 	if err != nil {
-		fmt.Println("请求失败:", err)
-		return HistoryValue{}, err
+		return store, err
 	}
-	defer resp.Body.Close()
 
-	// body, err := io.ReadAll(resp.Body)
-	// if err != nil {
-	// 	fmt.Println("读取响应失败:", err)
-	// 	return HistoryValue{}, err
-	// } else {
-	// 	fmt.Println("响应内容:", string(body))
-	// }
+	if err := store.checkType(path, lotteryType); err != nil {
+		return Store{}, err
+	}
+
+	return store, nil
+}
 
-	var history HistoryResponse
-	if err := json.NewDecoder(resp.Body).Decode(&history); err != nil {
-		fmt.Println("解析失败, page:", page, err)
-		return HistoryValue{}, err
+// checkType
+//
+// @Description 检查历史数据的彩票类型，防止不同彩票的开奖数据写入同一个文件
+//
+// @Param path string 文件路径
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return error 错误信息
+func (store *Store) checkType(path, lotteryType string) error {
+	if store.Type != "" && store.Type != lotteryType {
+		return fmt.Errorf("历史文件 %s 保存的是 %s 的开奖数据，不能用于 %s", path, store.Type, lotteryType)
 	}
 
-	return history.Value, nil
+	return nil
 }
 
-func getFullHistory(firstPageData *HistoryValue) ([]PoolDraw, error) {
+// Save
+//
+// @Description 将历史开奖数据写入文件
+//
+// @Param path string 文件路径
+//
+// @Return error 错误信息
+func (store *Store) Save(path string) error {
+	jsonData, err := json.MarshalIndent(store, "", "  ")
+	if err != nil {
+		return fmt.Errorf("json解析失败: %w", err)
+	}
+
+	if err := os.WriteFile(path, jsonData, 0644); err != nil {
+		return fmt.Errorf("文件写入失败: %w", err)
+	}
+
+	return nil
+}
+
+// getFullHistory
+//
+// @Description 并发获取全部页面的历史数据，结果按页码顺序拼接
+//
+// @Param src Source 数据源
+//
+// @Param firstPageData *HistoryValue 第一页数据，为空时从数据源获取
+//
+// @Return []PoolDraw 全部历史数据
+//
+// @Return error 错误信息
+func getFullHistory(src Source, firstPageData *HistoryValue) ([]PoolDraw, error) {
 	if firstPageData == nil {
-		firstPage, err := getPageData(1)
+		firstPage, err := src.GetPage(1)
 		if err != nil {
-			fmt.Println("历史数据获取失败:", err)
-			return nil, err
+			return nil, fmt.Errorf("历史数据获取失败: %w", err)
 		}
 
 		firstPageData = &firstPage
 	}
 
+	pages := max(firstPageData.Pages, 1)
 	concurrencyLimit := 5
 	semaphore := make(chan struct{}, concurrencyLimit)
-	resultChan := make(chan HistoryValue, firstPageData.Pages)
+	resultChan := make(chan HistoryValue, pages)
 
 	var (
 		wg     sync.WaitGroup
@@ -58,7 +132,7 @@ func getFullHistory(firstPageData *HistoryValue) ([]PoolDraw, error) {
 		errors []error
 	)
 
-	for pageNo := 2; pageNo <= firstPageData.Pages; pageNo++ {
+	for pageNo := 2; pageNo <= pages; pageNo++ {
 		wg.Add(1)
 		go func(p int) {
 			defer wg.Done()
@@ -66,8 +140,7 @@ func getFullHistory(firstPageData *HistoryValue) ([]PoolDraw, error) {
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
-			fmt.Printf("total: %d, fetching: %d\n", firstPageData.Pages, pageNo)
-			pageData, err := getPageData(pageNo)
+			pageData, err := src.GetPage(p)
 			if err != nil {
 				mutex.Lock()
 				errors = append(errors, fmt.Errorf("页面%d请求失败: %v", p, err))
@@ -75,6 +148,7 @@ func getFullHistory(firstPageData *HistoryValue) ([]PoolDraw, error) {
 				return
 			}
 
+			pageData.PageNo = p
 			resultChan <- pageData
 		}(pageNo)
 	}
@@ -85,21 +159,17 @@ func getFullHistory(firstPageData *HistoryValue) ([]PoolDraw, error) {
 	}()
 
 	// 收集结果并按页码排序
-	results := make([]HistoryValue, firstPageData.Total)
+	results := make([]HistoryValue, pages)
 	results[0] = *firstPageData
 	for resp := range resultChan {
 		results[resp.PageNo-1] = resp // 页码从1开始，索引从0开始
 	}
 
 	if len(errors) > 0 {
-		for _, e := range errors {
-			log.Println(e)
-		}
-
-		log.Fatal("部分页面请求失败")
+		return nil, fmt.Errorf("部分页面请求失败: %v", errors)
 	}
 
-	allData := make([]PoolDraw, firstPageData.Total)
+	allData := make([]PoolDraw, 0, firstPageData.Total)
 	for _, history := range results {
 		allData = append(allData, history.List...)
 	}
@@ -107,39 +177,51 @@ func getFullHistory(firstPageData *HistoryValue) ([]PoolDraw, error) {
 	return allData, nil
 }
 
-func CheckStore() {
-	firstPage, err := getPageData(1)
-	if err != nil {
-		fmt.Println("历史数据获取失败:", err)
-		return
+// SyncStore
+//
+// @Description 从数据源获取全部历史数据并写入文件，文件中已经保存了其他彩票类型的开奖数据时不会覆盖
+//
+// @Param src Source 数据源
+//
+// @Param lotteryType string 彩票类型
+//
+// @Param path string 文件路径
+//
+// @Return Store 历史开奖数据
+//
+// @Return error 错误信息
+func SyncStore(src Source, lotteryType, path string) (Store, error) {
+	if old, err := LoadStore(path); err == nil {
+		if err := old.checkType(path, lotteryType); err != nil {
+			return Store{}, err
+		}
 	}
 
-	fmt.Println("数据:", firstPage.Total)
-	list, listErr := getFullHistory(nil)
-	if listErr != nil {
-		fmt.Println("全部历史数据获取失败:", err)
-		return
+	list, err := getFullHistory(src, nil)
+	if err != nil {
+		return Store{}, fmt.Errorf("全部历史数据获取失败: %w", err)
 	}
 
-	fullData := struct {
-		UpdateTime string     `json:"updateTime"`
-		List       []PoolDraw `json:"list"`
-	}{
+	store := Store{
 		UpdateTime: time.Now().Format("2006-01-02 15:04:05"),
+		Type:       lotteryType,
 		List:       list,
 	}
 
-	jsonData, jsonErr := json.MarshalIndent(fullData, "", "  ")
-	if jsonErr != nil {
-		fmt.Println("json解析失败:", jsonErr)
-		return
+	if err := store.Save(path); err != nil {
+		return store, err
 	}
 
-	writeErr := os.WriteFile("dlt_history.json", jsonData, 0644)
-	if writeErr != nil {
-		fmt.Println("文件写入失败:", writeErr)
+	return store, nil
+}
+
+func CheckStore() {
+	store, err := SyncStore(NewHTTPSource(), "DLT", "dlt_history.json")
+	if err != nil {
+		fmt.Println(err)
 		return
 	}
 
+	fmt.Println("数据:", len(store.List))
 	fmt.Println("文件写入成功")
 }
@@ -0,0 +1,357 @@
+package dlt
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 校验问题类型
+const (
+	ProblemGap       = "Gap"       // 期号缺失
+	ProblemDuplicate = "Duplicate" // 期号重复
+	ProblemNumbers   = "Numbers"   // 开奖号码错误
+	ProblemPrize     = "Prize"     // 奖级数据错误
+)
+
+// 校验发现的问题
+type Problem struct {
+	Kind    string // 问题类型
+	Issue   int    // 期号，期号无法解析时为0
+	Message string // 问题描述
+}
+
+// 历史数据校验报告
+type VerifyReport struct {
+	Total    int       // 校验的期数
+	First    int       // 最早的期号
+	Last     int       // 最新的期号
+	Problems []Problem // 问题列表
+	Missing  []int     // 缺失的期号
+	Bad      []int     // 数据错误需要重新获取的期号
+}
+
+// getIssueGap
+//
+// @Description 获取两个相邻期号之间缺失的期号。期号的格式为年份+当年序号，例如 24151、25001，跨年时新一年的序号从1开始
+//
+// @Param pre int 前一期期号
+//
+// @Param next int 后一期期号
+//
+// @Return []int 缺失的期号
+//
+// @Return bool 是否能确定缺失的期号，跨越多年时无法确定
+func getIssueGap(pre, next int) ([]int, bool) {
+	var missing []int
+
+	preYear, nextYear := pre/1000, next/1000
+
+	switch {
+	case preYear == nextYear:
+		for issue := pre + 1; issue < next; issue++ {
+			missing = append(missing, issue)
+		}
+	case nextYear == preYear+1:
+		// 上一年的期数不固定，只能确定新一年从第1期开始
+		for issue := nextYear*1000 + 1; issue < next; issue++ {
+			missing = append(missing, issue)
+		}
+	default:
+		return nil, false
+	}
+
+	return missing, true
+}
+
+// parseAmount
+//
+// @Description 解析接口返回的金额或注数，例如 "8,000,000"、"1,234.50"，"---" 和空字符串视为0
+//
+// @Param input string 金额字符串
+//
+// @Return int64 金额，单位为分
+//
+// @Return error 错误信息
+func parseAmount(input string) (int64, error) {
+	input = strings.ReplaceAll(strings.TrimSpace(input), ",", "")
+
+	if input == "" || input == "---" {
+		return 0, nil
+	}
+
+	value, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		return 0, fmt.Errorf("金额解析失败: %s", input)
+	}
+
+	return int64(math.Round(value * 100)), nil
+}
+
+// formatAmount
+//
+// @Description 将金额格式化为与接口相同的格式，例如 "8,000,000"、"1,234.50"
+//
+// @Param amount int64 金额，单位为分
+//
+// @Return string 金额字符串
+func formatAmount(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	digits := strconv.FormatInt(amount/100, 10)
+
+	var builder strings.Builder
+
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			builder.WriteByte(',')
+		}
+
+		builder.WriteRune(digit)
+	}
+
+	if cents := amount % 100; cents != 0 {
+		fmt.Fprintf(&builder, ".%02d", cents)
+	}
+
+	return sign + builder.String()
+}
+
+// checkPrizeLevel
+//
+// @Description 检查奖级的中奖注数乘以单注奖金是否等于总奖金
+//
+// @Param level PrizeLevel 奖级数据
+//
+// @Return error 错误信息
+func checkPrizeLevel(level PrizeLevel) error {
+	count, err := parseAmount(level.StakeCount)
+	if err != nil {
+		return fmt.Errorf("%s注数错误: %w", level.PrizeLevel, err)
+	} else if count%100 != 0 {
+		return fmt.Errorf("%s注数不是整数: %s", level.PrizeLevel, level.StakeCount)
+	}
+
+	amount, err := parseAmount(level.StakeAmount)
+	if err != nil {
+		return fmt.Errorf("%s单注奖金错误: %w", level.PrizeLevel, err)
+	}
+
+	total, err := parseAmount(level.TotalPrizeamount)
+	if err != nil {
+		return fmt.Errorf("%s总奖金错误: %w", level.PrizeLevel, err)
+	}
+
+	if count < 0 || amount < 0 || total < 0 {
+		return fmt.Errorf("%s数据为负数", level.PrizeLevel)
+	}
+
+	if count/100*amount != total {
+		return fmt.Errorf("%s奖金不符: %s注 × %s ≠ %s", level.PrizeLevel, level.StakeCount, level.StakeAmount, level.TotalPrizeamount)
+	}
+
+	return nil
+}
+
+// Verify
+//
+// @Description 校验历史数据的完整性，包括期号缺失、期号重复、开奖号码错误以及奖级数据错误
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return VerifyReport 校验报告
+func (store *Store) Verify(lotteryType string) VerifyReport {
+	var (
+		report VerifyReport
+		issues []int
+	)
+
+	badMap := make(map[int]bool)
+	countMap := make(map[int]int)
+
+	addProblem := func(kind string, issue int, format string, args ...any) {
+		report.Problems = append(report.Problems, Problem{kind, issue, fmt.Sprintf(format, args...)})
+
+		if issue > 0 && kind != ProblemGap && !badMap[issue] {
+			badMap[issue] = true
+			report.Bad = append(report.Bad, issue)
+		}
+	}
+
+	for _, draw := range store.List {
+		issue, err := draw.GetIssue()
+		if err != nil {
+			addProblem(ProblemNumbers, 0, "%s", err)
+			continue
+		}
+
+		if countMap[issue]++; countMap[issue] == 2 {
+			addProblem(ProblemDuplicate, issue, "期号重复: %d", issue)
+		} else if countMap[issue] == 1 {
+			issues = append(issues, issue)
+		}
+
+		if _, err := draw.GetLottery(lotteryType); err != nil {
+			addProblem(ProblemNumbers, issue, "%s", err)
+		}
+
+		for _, level := range draw.PrizeLevelList {
+			if err := checkPrizeLevel(level); err != nil {
+				addProblem(ProblemPrize, issue, "%s。期号: %d", err, issue)
+			}
+		}
+	}
+
+	sort.Ints(issues)
+	report.Total = len(issues)
+
+	if len(issues) > 0 {
+		report.First = issues[0]
+		report.Last = issues[len(issues)-1]
+	}
+
+	for i := 1; i < len(issues); i++ {
+		missing, ok := getIssueGap(issues[i-1], issues[i])
+
+		if !ok {
+			addProblem(ProblemGap, issues[i], "期号跨越多年，无法确定缺失的期号: %d → %d", issues[i-1], issues[i])
+		} else if len(missing) > 0 {
+			addProblem(ProblemGap, missing[0], "期号缺失: %d → %d，缺失%d期", issues[i-1], issues[i], len(missing))
+			report.Missing = append(report.Missing, missing...)
+		}
+	}
+
+	sort.Ints(report.Bad)
+
+	return report
+}
+
+// IsValid
+//
+// @Description 判断校验报告中是否没有任何问题
+//
+// @Return bool 是否没有问题
+func (report *VerifyReport) IsValid() bool {
+	return len(report.Problems) == 0
+}
+
+// Print
+//
+// @Description 打印校验报告
+//
+// @Param w io.Writer 输出
+func (report *VerifyReport) Print(w io.Writer) {
+	fmt.Fprintf(w, "共%d期，期号范围: %d ~ %d\n", report.Total, report.First, report.Last)
+
+	if report.IsValid() {
+		fmt.Fprintln(w, "校验通过")
+		return
+	}
+
+	for _, problem := range report.Problems {
+		fmt.Fprintf(w, "[%s] %s\n", problem.Kind, problem.Message)
+	}
+
+	fmt.Fprintf(w, "缺失%d期，错误%d期\n", len(report.Missing), len(report.Bad))
+}
+
+// Repair
+//
+// @Description 从数据源重新获取缺失和错误的期号并合并到历史数据中。数据源按期号从新到旧分页，找到全部期号或翻过最早的期号后停止
+//
+// @Param src Source 数据源
+//
+// @Param report VerifyReport 校验报告
+//
+// @Return []int 修复的期号
+//
+// @Return error 错误信息
+func (store *Store) Repair(src Source, report VerifyReport) ([]int, error) {
+	var (
+		repaired []int
+		fetched  []PoolDraw
+	)
+
+	targetMap := make(map[int]bool)
+	minIssue := math.MaxInt
+
+	for _, issue := range append(append([]int{}, report.Missing...), report.Bad...) {
+		targetMap[issue] = true
+		minIssue = min(minIssue, issue)
+	}
+
+	if len(targetMap) == 0 {
+		return nil, nil
+	}
+
+	for page, pages := 1, 1; page <= pages && len(targetMap) > 0; page++ {
+		value, err := src.GetPage(page)
+		if err != nil {
+			return nil, fmt.Errorf("页面%d请求失败: %w", page, err)
+		}
+
+		pages = value.Pages
+		oldest := math.MaxInt
+
+		for _, draw := range value.List {
+			issue, err := draw.GetIssue()
+			if err != nil {
+				continue
+			}
+
+			oldest = min(oldest, issue)
+
+			if targetMap[issue] {
+				delete(targetMap, issue)
+				fetched = append(fetched, draw)
+				repaired = append(repaired, issue)
+			}
+		}
+
+		if oldest < minIssue {
+			break
+		}
+	}
+
+	// 去掉重复的期号后再合并
+	if len(report.Bad) > 0 {
+		badMap := make(map[string]bool)
+
+		for _, draw := range fetched {
+			badMap[draw.LotteryDrawNum] = true
+		}
+
+		list := store.List[:0]
+
+		for _, draw := range store.List {
+			if !badMap[draw.LotteryDrawNum] {
+				list = append(list, draw)
+			}
+		}
+
+		store.List = list
+	}
+
+	store.Merge(fetched)
+	sort.Ints(repaired)
+
+	if len(targetMap) > 0 {
+		var notFound []int
+
+		for issue := range targetMap {
+			notFound = append(notFound, issue)
+		}
+
+		sort.Ints(notFound)
+
+		return repaired, fmt.Errorf("数据源中找不到期号: %v", notFound)
+	}
+
+	return repaired, nil
+}
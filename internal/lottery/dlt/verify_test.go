@@ -0,0 +1,135 @@
+package dlt
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestGetIssueGap(t *testing.T) {
+	tests := []struct {
+		pre     int
+		next    int
+		missing []int
+		ok      bool
+	}{
+		{25052, 25053, nil, true},
+		{25050, 25053, []int{25051, 25052}, true},
+		{24151, 25001, nil, true},
+		{24149, 25001, nil, true},
+		{24151, 25003, []int{25001, 25002}, true},
+		{2024151, 2025002, []int{2025001}, true},
+		{23151, 25001, nil, false},
+	}
+
+	for _, tt := range tests {
+		missing, ok := getIssueGap(tt.pre, tt.next)
+
+		if !reflect.DeepEqual(missing, tt.missing) || ok != tt.ok {
+			t.Errorf("%d → %d 期望: %v %v, 实际: %v %v", tt.pre, tt.next, tt.missing, tt.ok, missing, ok)
+		}
+	}
+}
+
+func TestCheckPrizeLevel(t *testing.T) {
+	tests := []struct {
+		name  string
+		level PrizeLevel
+		valid bool
+	}{
+		{"正确", PrizeLevel{PrizeLevel: "三等奖", StakeCount: "1,234", StakeAmount: "10,000", TotalPrizeamount: "12,340,000"}, true},
+		{"小数金额", PrizeLevel{PrizeLevel: "一等奖", StakeCount: "2", StakeAmount: "6,543,210.50", TotalPrizeamount: "13,086,421.00"}, true},
+		{"无人中奖", PrizeLevel{PrizeLevel: "一等奖", StakeCount: "0", StakeAmount: "---", TotalPrizeamount: "0"}, true},
+		{"金额不符", PrizeLevel{PrizeLevel: "三等奖", StakeCount: "1,234", StakeAmount: "10,000", TotalPrizeamount: "12,340,001"}, false},
+		{"注数不是整数", PrizeLevel{PrizeLevel: "三等奖", StakeCount: "1.5", StakeAmount: "10,000", TotalPrizeamount: "15,000"}, false},
+		{"注数无法解析", PrizeLevel{PrizeLevel: "三等奖", StakeCount: "abc", StakeAmount: "10,000", TotalPrizeamount: "0"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := checkPrizeLevel(tt.level); (err == nil) != tt.valid {
+				t.Errorf("期望: %v, 错误信息: %v", tt.valid, err)
+			}
+		})
+	}
+}
+
+func TestVerify(t *testing.T) {
+	store, err := LoadStore(filepath.Join("testdata", "dlt_history.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report := store.Verify("DLT"); !report.IsValid() || report.Total != 5 || report.First != 25049 || report.Last != 25053 {
+		t.Errorf("测试数据应该校验通过: %+v", report)
+	}
+
+	// 删除 25051，25052 号码越界，25050 重复，25049 奖金不符
+	broken := Store{List: []PoolDraw{store.List[0], store.List[1], store.List[3], store.List[3], store.List[4]}}
+	broken.List[1].LotteryDrawResult = "03 13 21 27 36 01 06"
+	broken.List[4].PrizeLevelList = []PrizeLevel{{PrizeLevel: "九等奖", StakeCount: "10", StakeAmount: "5", TotalPrizeamount: "49"}}
+
+	report := broken.Verify("DLT")
+
+	var kinds []string
+	for _, problem := range report.Problems {
+		kinds = append(kinds, problem.Kind)
+	}
+
+	if expected := []string{ProblemNumbers, ProblemDuplicate, ProblemPrize, ProblemGap}; !reflect.DeepEqual(kinds, expected) {
+		t.Errorf("问题类型期望: %v, 实际: %v。%+v", expected, kinds, report.Problems)
+	}
+
+	if !reflect.DeepEqual(report.Missing, []int{25051}) || !reflect.DeepEqual(report.Bad, []int{25049, 25050, 25052}) {
+		t.Errorf("缺失期号: %v, 错误期号: %v", report.Missing, report.Bad)
+	}
+
+	repaired, err := broken.Repair(&ReplaySource{Dir: filepath.Join("testdata", "history")}, report)
+	if err != nil {
+		t.Fatalf("修复失败: %s", err)
+	}
+
+	if !reflect.DeepEqual(repaired, []int{25049, 25050, 25051, 25052}) {
+		t.Errorf("修复期号错误: %v", repaired)
+	}
+
+	if !reflect.DeepEqual(broken.List, store.List) {
+		t.Errorf("修复后的数据应该与原始数据一致。期望: %v, 实际: %v", getIssues(store.List), getIssues(broken.List))
+	}
+}
+
+func TestVerifyCWL(t *testing.T) {
+	src := &CWLSource{BaseURL: newCWLServer(t).URL, Name: "qlc", PageSize: 10}
+
+	list, err := getFullHistory(src, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if level := list[0].PrizeLevelList[1]; level.TotalPrizeamount != "24,000" {
+		t.Errorf("总奖金期望: 24,000, 实际: %s", level.TotalPrizeamount)
+	}
+
+	store := Store{List: list}
+
+	if report := store.Verify("QLC"); !report.IsValid() || report.Total != 3 {
+		t.Errorf("福彩数据应该校验通过: %+v", report)
+	}
+}
+
+func TestFormatAmount(t *testing.T) {
+	tests := map[int64]string{
+		0:            "0",
+		500:          "5",
+		123456700:    "1,234,567",
+		100000000000: "1,000,000,000",
+		123450:       "1,234.50",
+		-1000000:     "-10,000",
+	}
+
+	for amount, expected := range tests {
+		if result := formatAmount(amount); result != expected {
+			t.Errorf("%d 期望: %s, 实际: %s", amount, expected, result)
+		}
+	}
+}
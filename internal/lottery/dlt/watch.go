@@ -0,0 +1,362 @@
+package dlt
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/notify"
+)
+
+// 开奖时区 (北京时间)
+var drawLocation = time.FixedZone("CST", 8*60*60)
+
+// 开奖时间，每周的 Weekdays 在 Hour:Minute 开奖 (北京时间)
+type DrawSchedule struct {
+	Weekdays []time.Weekday
+	Hour     int
+	Minute   int
+}
+
+// 各彩票的开奖时间
+var DrawSchedules = map[string]DrawSchedule{
+	"DLT": {[]time.Weekday{time.Monday, time.Wednesday, time.Saturday}, 21, 25}, // 大乐透: 每周一、三、六 21:25
+	"SSQ": {[]time.Weekday{time.Tuesday, time.Thursday, time.Sunday}, 21, 15},   // 双色球: 每周二、四、日 21:15
+	"QLC": {[]time.Weekday{time.Monday, time.Wednesday, time.Friday}, 21, 15},   // 七乐彩: 每周一、三、五 21:15
+}
+
+// 开奖监听器，在开奖时间之后轮询数据源，出现新一期开奖数据后核对彩票并发送通知
+type Watcher struct {
+	Type      string            // 彩票类型，见 DrawSchedules，为空时为大乐透
+	Source    Source            // 数据源，应该返回 Type 对应的开奖数据
+	StorePath string            // 历史文件路径，为空时不保存新开奖数据
+	Tickets   []lottery.Lottery // 需要核对的彩票，期号为0的彩票每期都会核对
+	Notifier  notify.Notifier   // 通知器
+	Interval  time.Duration     // 开奖后的轮询间隔，为0时使用1分钟
+	Now       func() time.Time  // 当前时间，为空时使用 time.Now
+}
+
+// 单张彩票的核对结果，用于 Webhook 的结构化数据
+type TicketResult struct {
+	Ticket string `json:"ticket"`
+	Level  int    `json:"level"`
+	Price  int    `json:"price"`
+	Net    int    `json:"net"`
+}
+
+// 开奖通知的结构化数据
+type DrawNotice struct {
+	Issue   int            `json:"issue"`
+	Result  string         `json:"result"`
+	Time    string         `json:"time"`
+	Tickets []TicketResult `json:"tickets"`
+	Price   int            `json:"price"`
+	Net     int            `json:"net"`
+}
+
+// GetDrawSchedule
+//
+// @Description 获取彩票类型对应的开奖时间
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return DrawSchedule 开奖时间
+//
+// @Return error 错误信息
+func GetDrawSchedule(lotteryType string) (DrawSchedule, error) {
+	schedule, ok := DrawSchedules[lotteryType]
+	if !ok {
+		return schedule, fmt.Errorf("不支持监听开奖的彩票类型: %s", lotteryType)
+	}
+
+	return schedule, nil
+}
+
+// Next
+//
+// @Description 获取指定时间之后的下一次开奖时间
+//
+// @Param after time.Time 指定时间
+//
+// @Return time.Time 下一次开奖时间
+func (schedule DrawSchedule) Next(after time.Time) time.Time {
+	local := after.In(drawLocation)
+
+	for day := 0; day <= 7; day++ {
+		date := local.AddDate(0, 0, day)
+		drawTime := time.Date(date.Year(), date.Month(), date.Day(), schedule.Hour, schedule.Minute, 0, 0, drawLocation)
+
+		if !drawTime.After(after) {
+			continue
+		}
+
+		for _, weekday := range schedule.Weekdays {
+			if drawTime.Weekday() == weekday {
+				return drawTime
+			}
+		}
+	}
+
+	return time.Time{}
+}
+
+func (w *Watcher) getType() string {
+	if w.Type == "" {
+		return "DLT"
+	}
+
+	return w.Type
+}
+
+func (w *Watcher) now() time.Time {
+	if w.Now != nil {
+		return w.Now()
+	}
+
+	return time.Now()
+}
+
+// sleep
+//
+// @Description 等待指定时长，context 取消时提前返回
+//
+// @Return error 错误信息
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// getLatest
+//
+// @Description 获取数据源中最新一期的开奖数据
+//
+// @Return PoolDraw 开奖数据
+//
+// @Return error 错误信息
+func (w *Watcher) getLatest() (PoolDraw, error) {
+	value, err := w.Source.GetPage(1)
+	if err != nil {
+		return PoolDraw{}, err
+	}
+
+	if len(value.List) == 0 {
+		return PoolDraw{}, fmt.Errorf("数据源没有开奖数据")
+	}
+
+	return value.List[0], nil
+}
+
+// WaitNewDraw
+//
+// @Description 轮询数据源，直到出现期号大于 lastIssue 的开奖数据
+//
+// @Param ctx context.Context 取消轮询
+//
+// @Param lastIssue int 已知的最新期号
+//
+// @Return PoolDraw 新一期的开奖数据
+//
+// @Return error 错误信息
+func (w *Watcher) WaitNewDraw(ctx context.Context, lastIssue int) (PoolDraw, error) {
+	interval := w.Interval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	for {
+		draw, err := w.getLatest()
+		if err != nil {
+			log.Println("开奖数据获取失败:", err)
+		} else if issue, err := draw.GetIssue(); err == nil && issue > lastIssue {
+			return draw, nil
+		}
+
+		if err := sleep(ctx, interval); err != nil {
+			return PoolDraw{}, err
+		}
+	}
+}
+
+// CheckTickets
+//
+// @Description 用开奖数据核对彩票，生成通知内容
+//
+// @Param lotteryType string 彩票类型
+//
+// @Param draw PoolDraw 开奖数据
+//
+// @Param tickets []lottery.Lottery 彩票列表，只核对类型一致且期号为0或与开奖期号一致的彩票
+//
+// @Return notify.Message 通知内容
+//
+// @Return error 错误信息
+func CheckTickets(lotteryType string, draw PoolDraw, tickets []lottery.Lottery) (notify.Message, error) {
+	rule, err := lottery.GetGameRule(lotteryType)
+	if err != nil {
+		return notify.Message{}, err
+	}
+
+	target, err := draw.GetLottery(lotteryType)
+	if err != nil {
+		return notify.Message{}, err
+	}
+
+	notice := DrawNotice{
+		Issue:  target.Index,
+		Result: target.Format(false),
+		Time:   draw.LotteryDrawTime,
+	}
+
+	var lines []string
+
+	for _, ticket := range tickets {
+		if ticket.Type != lotteryType || (ticket.Index != 0 && ticket.Index != target.Index) {
+			continue
+		}
+
+		result, err := ticket.GetLotteryResult(target)
+		if err != nil {
+			return notify.Message{}, err
+		}
+
+		lines = append(lines, result.FormatResult(false, true))
+		notice.Price += result.Price
+		notice.Net += result.Net
+		notice.Tickets = append(notice.Tickets, TicketResult{
+			Ticket: ticket.String(),
+			Level:  result.Level,
+			Price:  result.Price,
+			Net:    result.Net,
+		})
+	}
+
+	if len(notice.Tickets) == 0 {
+		lines = append(lines, "没有需要核对的彩票")
+	} else {
+		lines = append(lines, fmt.Sprintf("合计奖金: %d，税后: %d", notice.Price, notice.Net))
+	}
+
+	return notify.Message{
+		Subject: fmt.Sprintf("%s第%d期开奖: %s", rule.Name, notice.Issue, notice.Result),
+		Body:    strings.Join(lines, "\n"),
+		Data:    notice,
+	}, nil
+}
+
+// getDrawTime
+//
+// @Description 获取开奖数据的开奖时间，开奖日期无法解析时返回零值
+//
+// @Param schedule DrawSchedule 开奖时间
+//
+// @Return time.Time 开奖时间
+func (draw *PoolDraw) getDrawTime(schedule DrawSchedule) time.Time {
+	date, err := time.ParseInLocation("2006-01-02", draw.LotteryDrawTime, drawLocation)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return date.Add(time.Duration(schedule.Hour)*time.Hour + time.Duration(schedule.Minute)*time.Minute)
+}
+
+// Run
+//
+// @Description 持续监听开奖，每期开奖后核对彩票、发送通知并保存开奖数据，直到 context 取消
+//
+// @Param ctx context.Context 取消监听
+//
+// @Return error 错误信息
+func (w *Watcher) Run(ctx context.Context) error {
+	schedule, err := GetDrawSchedule(w.getType())
+	if err != nil {
+		return err
+	}
+
+	latest, err := w.getLatest()
+	if err != nil {
+		return fmt.Errorf("最新开奖数据获取失败: %w", err)
+	}
+
+	lastIssue, err := latest.GetIssue()
+	if err != nil {
+		return err
+	}
+
+	lastDrawTime := latest.getDrawTime(schedule)
+
+	for {
+		after := lastDrawTime
+		if after.IsZero() {
+			after = w.now()
+		}
+
+		next := schedule.Next(after)
+		log.Printf("最新期号: %d，下次开奖时间: %s", lastIssue, next.Format("2006-01-02 15:04"))
+
+		if err := sleep(ctx, next.Sub(w.now())); err != nil {
+			return err
+		}
+
+		draw, err := w.WaitNewDraw(ctx, lastIssue)
+		if err != nil {
+			return err
+		}
+
+		msg, err := CheckTickets(w.getType(), draw, w.Tickets)
+		if err != nil {
+			log.Println("彩票核对失败:", err)
+		} else if w.Notifier != nil {
+			if err := w.Notifier.Notify(msg); err != nil {
+				log.Println("通知发送失败:", err)
+			}
+		}
+
+		if w.StorePath != "" {
+			if err := w.saveDraw(draw); err != nil {
+				log.Println("开奖数据保存失败:", err)
+			}
+		}
+
+		lastIssue, _ = draw.GetIssue()
+		lastDrawTime = draw.getDrawTime(schedule)
+		if lastDrawTime.IsZero() {
+			lastDrawTime = w.now()
+		}
+	}
+}
+
+// saveDraw
+//
+// @Description 将新开奖数据合并到历史文件中，历史文件保存的是其他彩票类型时不写入
+//
+// @Return error 错误信息
+func (w *Watcher) saveDraw(draw PoolDraw) error {
+	store, err := LoadStore(w.StorePath)
+	if err != nil {
+		store = Store{}
+	}
+
+	if err := store.checkType(w.StorePath, w.getType()); err != nil {
+		return err
+	}
+
+	store.Type = w.getType()
+	store.Merge([]PoolDraw{draw})
+	store.UpdateTime = w.now().Format("2006-01-02 15:04:05")
+
+	return store.Save(w.StorePath)
+}
@@ -0,0 +1,153 @@
+package dlt
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/notify"
+)
+
+func TestNextDrawTime(t *testing.T) {
+	tests := []struct {
+		lotteryType string
+		after       string
+		result      string
+	}{
+		{"DLT", "2025-05-12 10:00", "2025-05-12 21:25"}, // 周一开奖前
+		{"DLT", "2025-05-12 21:25", "2025-05-14 21:25"}, // 周一开奖时
+		{"DLT", "2025-05-12 22:00", "2025-05-14 21:25"}, // 周一开奖后
+		{"DLT", "2025-05-15 09:00", "2025-05-17 21:25"}, // 周四
+		{"DLT", "2025-05-17 23:00", "2025-05-19 21:25"}, // 周六开奖后
+		{"DLT", "2025-05-18 12:00", "2025-05-19 21:25"}, // 周日
+		{"SSQ", "2025-05-12 22:00", "2025-05-13 21:15"}, // 周一
+		{"SSQ", "2025-05-15 21:20", "2025-05-18 21:15"}, // 周四开奖后
+		{"QLC", "2025-05-14 22:00", "2025-05-16 21:15"}, // 周三开奖后
+	}
+
+	for _, tt := range tests {
+		after, _ := time.ParseInLocation("2006-01-02 15:04", tt.after, drawLocation)
+
+		schedule, err := GetDrawSchedule(tt.lotteryType)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if result := schedule.Next(after).Format("2006-01-02 15:04"); result != tt.result {
+			t.Errorf("%s %s 期望: %s, 实际: %s", tt.lotteryType, tt.after, tt.result, result)
+		}
+	}
+
+	if _, err := GetDrawSchedule("PL3"); err == nil {
+		t.Error("排列三应该不支持监听开奖")
+	}
+}
+
+// sequenceSource 每次请求返回下一个页面，用于模拟开奖数据的更新
+type sequenceSource struct {
+	pages []HistoryValue
+	calls int
+}
+
+func (src *sequenceSource) GetPage(page int) (HistoryValue, error) {
+	value := src.pages[min(src.calls, len(src.pages)-1)]
+	src.calls++
+
+	return value, nil
+}
+
+// notifierFunc 将函数包装为通知器
+type notifierFunc func(msg notify.Message) error
+
+func (f notifierFunc) Notify(msg notify.Message) error {
+	return f(msg)
+}
+
+func TestCheckTickets(t *testing.T) {
+	store, _ := LoadStore(filepath.Join("testdata", "dlt_history.json"))
+
+	var tickets []lottery.Lottery
+
+	for _, str := range []string{
+		"DLT:02,04,11,29,30-02,08",
+		"DLT:02,04,11,29,31-02,09x2:25053",
+		"DLT:01,02,03,04,05-01,02:25052",
+		"SSQ:02,04,11,29,30,31-08",
+	} {
+		ticket, _ := lottery.GetLottery(str)
+		tickets = append(tickets, ticket)
+	}
+
+	msg, err := CheckTickets("DLT", store.List[0], tickets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	notice := msg.Data.(DrawNotice)
+	expected := []TicketResult{
+		{"DLT:02,04,11,29,30-02,08", 1, 10000000, 8000000},
+		{"DLT:02,04,11,29,31-02,09x2:25053", 5, 600, 600},
+	}
+
+	if !reflect.DeepEqual(notice.Tickets, expected) || notice.Price != 10000600 || notice.Net != 8000600 || notice.Issue != 25053 {
+		t.Errorf("期望: %v, 实际: %+v", expected, notice)
+	}
+
+	if msg.Subject != "大乐透第25053期开奖: 02,04,11,29,30-02,08" || !strings.HasSuffix(msg.Body, "合计奖金: 10000600，税后: 8000600") {
+		t.Errorf("通知内容错误: %+v", msg)
+	}
+}
+
+func TestWatcherRun(t *testing.T) {
+	store, _ := LoadStore(filepath.Join("testdata", "dlt_history.json"))
+	old := HistoryValue{List: store.List[1:], Pages: 1}
+	latest := HistoryValue{List: store.List, Pages: 1}
+	src := &sequenceSource{pages: []HistoryValue{old, old, old, latest}}
+	storePath := filepath.Join(t.TempDir(), "dlt_history.json")
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var messages []notify.Message
+
+	ticket, _ := lottery.GetLottery("DLT:02,04,11,29,30-02,08")
+	watcher := &Watcher{
+		Source:    src,
+		StorePath: storePath,
+		Tickets:   []lottery.Lottery{ticket},
+		Interval:  time.Millisecond,
+		Now:       func() time.Time { return time.Date(2025, 5, 12, 22, 0, 0, 0, drawLocation) },
+		Notifier: notifierFunc(func(msg notify.Message) error {
+			messages = append(messages, msg)
+			cancel()
+			return nil
+		}),
+	}
+
+	if err := watcher.Run(ctx); err != context.Canceled {
+		t.Fatalf("应该在通知后取消: %v", err)
+	}
+
+	if len(messages) != 1 || messages[0].Data.(DrawNotice).Issue != 25053 {
+		t.Fatalf("通知错误: %+v", messages)
+	}
+
+	if src.calls != 4 {
+		t.Errorf("轮询次数期望: 4, 实际: %d", src.calls)
+	}
+
+	saved, err := LoadStore(storePath)
+	if err != nil || len(saved.List) != 1 || saved.List[0].LotteryDrawNum != "25053" || saved.Type != "DLT" {
+		t.Errorf("新开奖数据应该被保存: %+v %v", saved, err)
+	}
+
+	// 历史文件保存的是其他彩票类型时不写入
+	watcher.Type = "SSQ"
+
+	if err := watcher.saveDraw(store.List[1]); err == nil {
+		t.Error("彩票类型不一致时应该失败")
+	}
+}
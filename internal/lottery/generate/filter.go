@@ -0,0 +1,154 @@
+package generate
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/stats"
+)
+
+// 包含条件，号码集合中至少有 Min 个、至多有 Max 个号码被选中
+type ContainFilter struct {
+	Nums []int `json:"nums"` // 号码集合
+	Min  int   `json:"min"`  // 最少包含的个数
+	Max  int   `json:"max"`  // 最多包含的个数，为0时不限制
+}
+
+// 过滤条件，为空的条件不做限制
+type FilterOptions struct {
+	Pattern       stats.PatternFilter `json:"pattern"`                 // 前区形态条件
+	Prev          *lottery.Lottery    `json:"-"`                       // 上一期开奖号码，用于重号条件
+	FrontExclude  []int               `json:"frontExclude,omitempty"`  // 前区杀号
+	BackExclude   []int               `json:"backExclude,omitempty"`   // 后区杀号
+	FrontContains []ContainFilter     `json:"frontContains,omitempty"` // 前区包含条件
+	BackContains  []ContainFilter     `json:"backContains,omitempty"`  // 后区包含条件
+}
+
+// 单个过滤条件去掉的注数
+type FilterCount struct {
+	Name    string `json:"name"`    // 条件名称
+	Removed int    `json:"removed"` // 去掉的注数
+}
+
+// 过滤结果
+type FilterReport struct {
+	Total   int               `json:"total"`   // 过滤前的注数
+	Kept    int               `json:"kept"`    // 保留的注数
+	Removed []FilterCount     `json:"removed"` // 每个条件去掉的注数，按检查顺序排列，每注只计入第一个不满足的条件
+	List    []lottery.Lottery `json:"-"`       // 保留的单式票
+}
+
+// 过滤条件的检查顺序
+var filterNames = []string{"frontExclude", "backExclude", "frontContains", "backContains", "sum", "span", "odd", "big", "consecutive", "maxRun", "repeat", "ac"}
+
+// Contains
+//
+// @Description 判断号码列表是否满足包含条件
+//
+// @Param nums []int 号码列表
+//
+// @Return bool 是否满足
+func (filter *ContainFilter) Contains(nums []int) bool {
+	count := len(lottery.GetCrossNums(filter.Nums, nums))
+
+	return count >= filter.Min && (filter.Max == 0 || count <= filter.Max)
+}
+
+// Check
+//
+// @Description 检查单式票是否满足全部过滤条件
+//
+// @Param lott lottery.Lottery 单式票
+//
+// @Return string 第一个不满足的条件名称，全部满足时为空
+//
+// @Return error 错误信息
+func (options *FilterOptions) Check(lott lottery.Lottery) (string, error) {
+	if len(lottery.GetCrossNums(lott.FrontTuo, options.FrontExclude)) > 0 {
+		return "frontExclude", nil
+	}
+
+	if len(lottery.GetCrossNums(lott.BackTuo, options.BackExclude)) > 0 {
+		return "backExclude", nil
+	}
+
+	for _, filter := range options.FrontContains {
+		if !filter.Contains(lott.FrontTuo) {
+			return "frontContains", nil
+		}
+	}
+
+	for _, filter := range options.BackContains {
+		if !filter.Contains(lott.BackTuo) {
+			return "backContains", nil
+		}
+	}
+
+	pattern, err := stats.GetPattern(lott, options.Prev)
+	if err != nil {
+		return "", err
+	}
+
+	return options.Pattern.Check(pattern), nil
+}
+
+// Filter
+//
+// @Description 将彩票展开为单式票，按过滤条件去掉不满足的单式票
+//
+// @Param lott lottery.Lottery 彩票，通常为大复式票
+//
+// @Param options FilterOptions 过滤条件
+//
+// @Return FilterReport 过滤结果
+//
+// @Return error 错误信息
+func Filter(lott lottery.Lottery, options FilterOptions) (FilterReport, error) {
+	var report FilterReport
+
+	list := lott.List
+	if len(list) == 0 {
+		list = []lottery.Lottery{lott}
+	}
+
+	removed := make(map[string]int)
+
+	for _, item := range list {
+		name, err := options.Check(item)
+		if err != nil {
+			return report, err
+		}
+
+		if name != "" {
+			removed[name]++
+			continue
+		}
+
+		report.List = append(report.List, item)
+	}
+
+	report.Total = len(list)
+	report.Kept = len(report.List)
+
+	for _, name := range filterNames {
+		if removed[name] > 0 {
+			report.Removed = append(report.Removed, FilterCount{name, removed[name]})
+		}
+	}
+
+	return report, nil
+}
+
+// Print
+//
+// @Description 输出过滤前后的注数以及每个条件去掉的注数
+//
+// @Param w io.Writer 输出
+func (report *FilterReport) Print(w io.Writer) {
+	fmt.Fprintf(w, "过滤前: %d注，保留: %d注\n", report.Total, report.Kept)
+
+	for _, item := range report.Removed {
+		fmt.Fprintf(w, "  %-14s 去掉%d注\n", item.Name, item.Removed)
+	}
+}
@@ -0,0 +1,74 @@
+package generate
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/stats"
+)
+
+func TestFilter(t *testing.T) {
+	lott, _ := lottery.GetLottery("DLT:01,02,03,04,05,06,07-01,02,03")
+
+	tests := []struct {
+		name    string
+		options FilterOptions
+		kept    []string
+		removed []FilterCount
+	}{
+		{"无条件", FilterOptions{}, nil, nil},
+		{
+			"组合条件",
+			FilterOptions{
+				FrontExclude:  []int{7},
+				BackExclude:   []int{3},
+				FrontContains: []ContainFilter{{Nums: []int{1, 2}, Min: 2}},
+				Pattern:       stats.PatternFilter{Sum: &stats.Range{Min: 16, Max: 17}},
+			},
+			[]string{"01,02,03,04,06-01,02", "01,02,03,05,06-01,02"},
+			[]FilterCount{{"frontExclude", 45}, {"backExclude", 12}, {"frontContains", 2}, {"sum", 2}},
+		},
+		{
+			"包含条件上限",
+			FilterOptions{
+				FrontContains: []ContainFilter{{Nums: []int{1, 2, 3, 4, 5}, Max: 3}},
+				BackContains:  []ContainFilter{{Nums: []int{3}, Min: 1}},
+				Pattern:       stats.PatternFilter{MaxRun: &stats.Range{Min: 1, Max: 2}},
+			},
+			[]string{"01,02,04,06,07-01,03", "01,02,04,06,07-02,03", "01,03,04,06,07-01,03", "01,03,04,06,07-02,03"},
+			[]FilterCount{{"frontContains", 33}, {"backContains", 10}, {"maxRun", 16}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Filter(lott, tt.options)
+			if err != nil {
+				t.Fatalf("错误信息: %s", err)
+			}
+
+			if report.Total != 63 || report.Kept != len(report.List) {
+				t.Errorf("注数错误: %+v", report)
+			}
+
+			if tt.kept != nil {
+				var kept []string
+
+				for _, item := range report.List {
+					kept = append(kept, item.Format(false))
+				}
+
+				if !reflect.DeepEqual(kept, tt.kept) {
+					t.Errorf("期望: %v, 实际: %v", tt.kept, kept)
+				}
+			} else if report.Kept != 63 {
+				t.Errorf("期望保留全部，实际: %d", report.Kept)
+			}
+
+			if !reflect.DeepEqual(report.Removed, tt.removed) {
+				t.Errorf("期望: %v, 实际: %v", tt.removed, report.Removed)
+			}
+		})
+	}
+}
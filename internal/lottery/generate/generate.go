@@ -0,0 +1,302 @@
+package generate
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	mrand "math/rand/v2"
+	"slices"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 机选参数
+type Options struct {
+	Type         string // 彩票类型
+	FrontSize    int    // 前区号码数量 (包含胆码)，为0时使用单式票数量
+	BackSize     int    // 后区号码数量 (包含胆码)，为0时使用单式票数量
+	FrontDan     []int  // 前区指定号码，复式票时作为胆码，单式票时直接选入
+	BackDan      []int  // 后区指定号码，复式票时作为胆码，单式票时直接选入
+	FrontExclude []int  // 前区排除的号码
+	BackExclude  []int  // 后区排除的号码
+	Scale        int    // 倍投倍数，为0时不倍投
+	Index        int    // 期号，为0时不指定期号
+}
+
+// 基于 crypto/rand 的随机数源
+type cryptoSource struct{}
+
+func (cryptoSource) Uint64() uint64 {
+	var buf [8]byte
+
+	if _, err := rand.Read(buf[:]); err != nil {
+		panic(fmt.Sprintf("随机数生成失败: %s", err))
+	}
+
+	return binary.LittleEndian.Uint64(buf[:])
+}
+
+// NewSeededRand
+//
+// @Description 创建指定种子的随机数生成器，相同种子生成相同的彩票，用于复现
+//
+// @Param seed uint64 随机种子
+//
+// @Return *mrand.Rand 随机数生成器
+func NewSeededRand(seed uint64) *mrand.Rand {
+	return mrand.New(mrand.NewPCG(seed, seed))
+}
+
+// NewCryptoRand
+//
+// @Description 创建基于 crypto/rand 的随机数生成器，用于实际投注
+//
+// @Return *mrand.Rand 随机数生成器
+func NewCryptoRand() *mrand.Rand {
+	return mrand.New(cryptoSource{})
+}
+
+// pickZone
+//
+// @Description 在一个号码区内机选号码
+//
+// @Param r *mrand.Rand 随机数生成器
+//
+// @Param name string 号码区名称，用于错误信息
+//
+// @Param minNum int 最小号码
+//
+// @Param maxNum int 最大号码
+//
+// @Param size int 需要的号码数量 (包含指定号码)
+//
+// @Param dan []int 指定号码
+//
+// @Param exclude []int 排除的号码
+//
+// @Return []int 机选的号码 (不包含指定号码)，从小到大排列
+//
+// @Return error 错误信息
+func pickZone(r *mrand.Rand, name string, minNum, maxNum, size int, dan, exclude []int) ([]int, error) {
+	if arr := lottery.GetCrossNums(dan, exclude); len(arr) > 0 {
+		return nil, fmt.Errorf("%s指定号码与排除号码重复: %v", name, arr)
+	}
+
+	if len(dan) > size {
+		return nil, fmt.Errorf("%s指定号码数量超过号码数量%d，当前数量: %d", name, size, len(dan))
+	}
+
+	var pool []int
+
+	for num := minNum; num <= maxNum; num++ {
+		if !slices.Contains(dan, num) && !slices.Contains(exclude, num) {
+			pool = append(pool, num)
+		}
+	}
+
+	count := size - len(dan)
+
+	if count > len(pool) {
+		return nil, fmt.Errorf("%s可选号码不足，需要: %d, 可选: %d", name, count, len(pool))
+	}
+
+	r.Shuffle(len(pool), func(i, j int) {
+		pool[i], pool[j] = pool[j], pool[i]
+	})
+
+	result := pool[:count]
+	slices.Sort(result)
+
+	return result, nil
+}
+
+// Generate
+//
+// @Description 机选一张彩票，号码数量等于单式票时生成单式票，否则生成复式票，指定了胆码时生成胆拖票
+//
+// @Param r *mrand.Rand 随机数生成器
+//
+// @Param options Options 机选参数
+//
+// @Return lottery.Lottery 机选的彩票，彩票字符串可以被 lottery.GetLottery 解析
+//
+// @Return error 错误信息
+func Generate(r *mrand.Rand, options Options) (lottery.Lottery, error) {
+	rule, err := lottery.GetGameRule(options.Type)
+	if err != nil {
+		return lottery.Lottery{}, err
+	}
+
+	frontSize := options.FrontSize
+	if frontSize == 0 {
+		frontSize = rule.FrontSize
+	}
+
+	backSize := options.BackSize
+	if backSize == 0 {
+		backSize = rule.BackSize
+	}
+
+	if frontSize < rule.FrontSize {
+		return lottery.Lottery{}, fmt.Errorf("前区最少需要%d个数字", rule.FrontSize)
+	}
+
+	if backSize < rule.BackSize {
+		return lottery.Lottery{}, fmt.Errorf("后区最少需要%d个数字", rule.BackSize)
+	}
+
+	frontDan := slices.Sorted(slices.Values(options.FrontDan))
+	backDan := slices.Sorted(slices.Values(options.BackDan))
+
+	frontTuo, err := pickZone(r, "前区", rule.FrontMin, rule.FrontMax, frontSize, frontDan, options.FrontExclude)
+	if err != nil {
+		return lottery.Lottery{}, err
+	}
+
+	backTuo, err := pickZone(r, "后区", rule.BackMin, rule.BackMax, backSize, backDan, options.BackExclude)
+	if err != nil {
+		return lottery.Lottery{}, err
+	}
+
+	var parts lottery.LotteryParts
+
+	parts.Type = rule.Type
+	parts.Scale = options.Scale
+	parts.Index = options.Index
+	parts.FrontDan, parts.FrontTuo = mergeDan(frontDan, frontTuo, rule.FrontSize)
+	parts.BackDan, parts.BackTuo = mergeDan(backDan, backTuo, rule.BackSize)
+
+	if err := rule.Check(parts); err != nil {
+		return lottery.Lottery{}, err
+	}
+
+	// 通过彩票字符串重新解析，保证结果与手工输入的彩票一致
+	lott := lottery.Lottery{LotteryParts: parts}
+
+	return lottery.GetLottery(lott.String())
+}
+
+// mergeDan
+//
+// @Description 号码数量等于单式票数量时没有拖码可选，将胆码并入拖码
+//
+// @Param dan []int 胆码
+//
+// @Param tuo []int 机选的拖码
+//
+// @Param singleSize int 单式票号码数量
+//
+// @Return []int 胆码
+//
+// @Return []int 拖码
+func mergeDan(dan, tuo []int, singleSize int) ([]int, []int) {
+	if len(dan)+len(tuo) > singleSize {
+		return dan, tuo
+	}
+
+	return nil, slices.Sorted(slices.Values(append(append([]int{}, dan...), tuo...)))
+}
+
+// countSingles
+//
+// @Description 计算可以机选出的不同单式票数量，超过 limit 时返回 limit
+//
+// @Param rule lottery.GameRule 玩法规则
+//
+// @Param options Options 机选参数
+//
+// @Param limit int 上限
+//
+// @Return int 单式票数量
+func countSingles(rule lottery.GameRule, options Options, limit int) int {
+	combination := func(n, k int) int {
+		if k < 0 || k > n {
+			return 0
+		}
+
+		result := 1
+
+		for i := 1; i <= k; i++ {
+			result = result * (n - k + i) / i
+
+			if result > limit {
+				return limit + 1
+			}
+		}
+
+		return result
+	}
+
+	poolSize := func(minNum, maxNum int, dan, exclude []int) int {
+		size := 0
+
+		for num := minNum; num <= maxNum; num++ {
+			if !slices.Contains(dan, num) && !slices.Contains(exclude, num) {
+				size++
+			}
+		}
+
+		return size
+	}
+
+	frontPool := poolSize(rule.FrontMin, rule.FrontMax, options.FrontDan, options.FrontExclude)
+	backPool := poolSize(rule.BackMin, rule.BackMax, options.BackDan, options.BackExclude)
+
+	return min(combination(frontPool, rule.FrontSize-len(options.FrontDan))*combination(backPool, rule.BackSize-len(options.BackDan)), limit)
+}
+
+// GenerateSingles
+//
+// @Description 机选多张互不相同的单式票
+//
+// @Param r *mrand.Rand 随机数生成器
+//
+// @Param options Options 机选参数，号码数量必须为0或等于单式票数量
+//
+// @Param n int 彩票数量
+//
+// @Return []lottery.Lottery 机选的彩票
+//
+// @Return error 错误信息
+func GenerateSingles(r *mrand.Rand, options Options, n int) ([]lottery.Lottery, error) {
+	rule, err := lottery.GetGameRule(options.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	if (options.FrontSize != 0 && options.FrontSize != rule.FrontSize) || (options.BackSize != 0 && options.BackSize != rule.BackSize) {
+		return nil, errors.New("机选多注只支持单式票")
+	}
+
+	if n <= 0 {
+		return nil, fmt.Errorf("彩票数量应该大于0，当前数量: %d", n)
+	}
+
+	// 指定号码或排除号码有误时由 Generate 返回具体的错误信息
+	if _, err := Generate(r, options); err != nil {
+		return nil, err
+	}
+
+	if count := countSingles(rule, options, n); count < n {
+		return nil, fmt.Errorf("可选的单式票数量不足，需要: %d, 可选: %d", n, count)
+	}
+
+	var result []lottery.Lottery
+
+	seen := make(map[string]bool, n)
+
+	for len(result) < n {
+		lott, err := Generate(r, options)
+		if err != nil {
+			return nil, err
+		}
+
+		if key := lott.Format(false); !seen[key] {
+			seen[key] = true
+			result = append(result, lott)
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,147 @@
+package generate
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+func TestGenerate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+		front   int
+		back    int
+		dan     bool
+	}{
+		{"大乐透单式", Options{Type: "DLT"}, 5, 2, false},
+		{"双色球单式", Options{Type: "SSQ"}, 6, 1, false},
+		{"大乐透复式", Options{Type: "DLT", FrontSize: 7, BackSize: 3, Scale: 2, Index: 25053}, 7, 3, false},
+		{"大乐透胆拖", Options{Type: "DLT", FrontSize: 8, FrontDan: []int{7, 3}, BackSize: 3, BackDan: []int{12}}, 8, 3, true},
+		{"单式指定号码", Options{Type: "DLT", FrontDan: []int{1, 35}, BackDan: []int{6}}, 5, 2, false},
+		{"排除号码", Options{Type: "DLT", FrontExclude: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, BackExclude: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}}, 5, 2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewSeededRand(1)
+
+			for range 20 {
+				lott, err := Generate(r, tt.options)
+				if err != nil {
+					t.Fatalf("错误信息: %s", err)
+				}
+
+				front := append(append([]int{}, lott.FrontDan...), lott.FrontTuo...)
+				back := append(append([]int{}, lott.BackDan...), lott.BackTuo...)
+
+				if len(front) != tt.front || len(back) != tt.back {
+					t.Fatalf("号码数量错误: %s", lott.String())
+				}
+
+				if (len(lott.FrontDan) > 0) != tt.dan {
+					t.Fatalf("胆码错误: %s", lott.String())
+				}
+
+				for _, num := range tt.options.FrontDan {
+					if !slices.Contains(front, num) {
+						t.Fatalf("缺少前区指定号码 %d: %s", num, lott.String())
+					}
+				}
+
+				for _, num := range tt.options.BackDan {
+					if !slices.Contains(back, num) {
+						t.Fatalf("缺少后区指定号码 %d: %s", num, lott.String())
+					}
+				}
+
+				if arr := lottery.GetCrossNums(front, tt.options.FrontExclude); len(arr) > 0 {
+					t.Fatalf("包含前区排除号码 %v: %s", arr, lott.String())
+				}
+
+				if arr := lottery.GetCrossNums(back, tt.options.BackExclude); len(arr) > 0 {
+					t.Fatalf("包含后区排除号码 %v: %s", arr, lott.String())
+				}
+
+				parsed, err := lottery.GetLottery(lott.String())
+				if err != nil {
+					t.Fatalf("彩票字符串解析失败: %s", err)
+				} else if parsed.String() != lott.String() {
+					t.Fatalf("期望: %s, 实际: %s", lott.String(), parsed.String())
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateError(t *testing.T) {
+	tests := []struct {
+		name    string
+		options Options
+	}{
+		{"不支持的类型", Options{Type: "ABC"}},
+		{"号码数量不足", Options{Type: "DLT", FrontSize: 4}},
+		{"指定号码与排除号码重复", Options{Type: "DLT", FrontDan: []int{1}, FrontExclude: []int{1}}},
+		{"指定号码过多", Options{Type: "DLT", FrontDan: []int{1, 2, 3, 4, 5, 6}}},
+		{"指定号码超出范围", Options{Type: "DLT", FrontDan: []int{36}}},
+		{"可选号码不足", Options{Type: "SSQ", BackExclude: []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16}}},
+		{"双色球后区胆码", Options{Type: "SSQ", BackSize: 2, BackDan: []int{1}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if lott, err := Generate(NewSeededRand(1), tt.options); err == nil {
+				t.Errorf("应该失败: %s", lott.String())
+			}
+		})
+	}
+}
+
+func TestGenerateSeed(t *testing.T) {
+	a, _ := GenerateSingles(NewSeededRand(42), Options{Type: "DLT"}, 5)
+	b, _ := GenerateSingles(NewSeededRand(42), Options{Type: "DLT"}, 5)
+
+	for i := range a {
+		if a[i].String() != b[i].String() {
+			t.Errorf("相同种子的结果不一致: %s, %s", a[i].String(), b[i].String())
+		}
+	}
+
+	if _, err := Generate(NewCryptoRand(), Options{Type: "SSQ"}); err != nil {
+		t.Errorf("错误信息: %s", err)
+	}
+}
+
+func TestGenerateSingles(t *testing.T) {
+	// 前区只剩6个号码，后区只剩2个号码，共6种单式票
+	options := Options{
+		Type:         "DLT",
+		FrontExclude: []int{7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31, 32, 33, 34, 35},
+		BackDan:      []int{1},
+		BackExclude:  []int{3, 4, 5, 6, 7, 8, 9, 10, 11, 12},
+	}
+
+	list, err := GenerateSingles(NewSeededRand(7), options, 6)
+	if err != nil {
+		t.Fatalf("错误信息: %s", err)
+	}
+
+	seen := make(map[string]bool)
+
+	for _, lott := range list {
+		if !lott.IsSingleLottery() || seen[lott.String()] {
+			t.Errorf("单式票错误或重复: %s", lott.String())
+		}
+
+		seen[lott.String()] = true
+	}
+
+	if _, err := GenerateSingles(NewSeededRand(7), options, 7); err == nil {
+		t.Errorf("单式票数量不足时应该失败")
+	}
+
+	if _, err := GenerateSingles(NewSeededRand(7), Options{Type: "DLT", FrontSize: 6}, 2); err == nil {
+		t.Errorf("复式票应该失败")
+	}
+}
@@ -0,0 +1,311 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/bits"
+	"slices"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 旋转矩阵最多支持的前区号码数量，超过后组合数量过大
+const maxWheelNums = 18
+
+// 旋转矩阵参数
+//
+// 保证条件: 开奖前区号码中至少有 Hit 个在 Front 中时，至少有一注命中 Match 个前区号码
+type WheelOptions struct {
+	Type  string // 彩票类型
+	Front []int  // 选中的前区号码
+	Back  []int  // 后区号码，数量必须等于单式票数量，每注使用相同的后区号码
+	Hit   int    // 开出的选中号码个数
+	Match int    // 保证命中的前区号码个数，不能大于 Hit
+}
+
+// 旋转矩阵
+type Wheel struct {
+	Options  WheelOptions      `json:"options"`  // 旋转矩阵参数
+	List     []lottery.Lottery `json:"-"`        // 单式票列表
+	Bets     int               `json:"bets"`     // 注数
+	Cost     int               `json:"cost"`     // 投注金额
+	FullBets int               `json:"fullBets"` // 相同号码全复式的注数
+	FullCost int               `json:"fullCost"` // 相同号码全复式的投注金额
+}
+
+// 旋转矩阵的验证结果
+type WheelCheck struct {
+	Draws      int `json:"draws"`      // 验证的开奖组合数量
+	WorstMatch int `json:"worstMatch"` // 所有开奖组合中最好一注命中前区个数的最小值
+	WorstLevel int `json:"worstLevel"` // 后区全中时，所有开奖组合中最高中奖等级的最低值
+}
+
+// getCombinations
+//
+// @Description 获取 n 个元素中选 k 个的全部组合，组合以位掩码表示，按字典序排列
+//
+// @Param n int 元素数量
+//
+// @Param k int 选取数量
+//
+// @Return []uint32 组合列表
+func getCombinations(n, k int) []uint32 {
+	var (
+		result    []uint32
+		backtrack func(start, count int, mask uint32)
+	)
+
+	backtrack = func(start, count int, mask uint32) {
+		if count == k {
+			result = append(result, mask)
+			return
+		}
+
+		for i := start; i < n; i++ {
+			backtrack(i+1, count+1, mask|1<<i)
+		}
+	}
+
+	backtrack(0, 0, 0)
+
+	return result
+}
+
+// check
+//
+// @Description 检查旋转矩阵参数是否符合玩法规则
+//
+// @Param rule lottery.GameRule 玩法规则
+//
+// @Return error 错误信息
+func (options *WheelOptions) check(rule lottery.GameRule) error {
+	if len(options.Front) < rule.FrontSize || len(options.Front) > maxWheelNums {
+		return fmt.Errorf("前区号码数量应该为%d~%d，当前数量: %d", rule.FrontSize, maxWheelNums, len(options.Front))
+	}
+
+	if options.Hit < 1 || options.Hit > rule.FrontSize {
+		return fmt.Errorf("开出的选中号码个数应该为1~%d，当前: %d", rule.FrontSize, options.Hit)
+	}
+
+	if options.Match < 1 || options.Match > options.Hit {
+		return fmt.Errorf("保证命中个数应该为1~%d，当前: %d", options.Hit, options.Match)
+	}
+
+	var parts lottery.LotteryParts
+
+	parts.Type = rule.Type
+	parts.FrontTuo = options.Front
+	parts.BackTuo = options.Back
+
+	if len(options.Back) != rule.BackSize {
+		return fmt.Errorf("后区号码数量应该为%d，当前数量: %d", rule.BackSize, len(options.Back))
+	}
+
+	return rule.Check(parts)
+}
+
+// Guarantee
+//
+// @Description 获取旋转矩阵的保证条件说明
+//
+// @Return string 保证条件
+func (options *WheelOptions) Guarantee() string {
+	return fmt.Sprintf("选中的%d个前区号码开出%d个时，至少一注命中%d个前区号码", len(options.Front), options.Hit, options.Match)
+}
+
+// NewWheel
+//
+// @Description 用贪心算法生成旋转矩阵：每次选取能覆盖最多未覆盖开奖组合的一注，直到全部开奖组合满足保证条件
+//
+// @Param options WheelOptions 旋转矩阵参数
+//
+// @Return Wheel 旋转矩阵
+//
+// @Return error 错误信息
+func NewWheel(options WheelOptions) (Wheel, error) {
+	wheel := Wheel{Options: options}
+
+	rule, err := lottery.GetGameRule(options.Type)
+	if err != nil {
+		return wheel, err
+	}
+
+	if err := options.check(rule); err != nil {
+		return wheel, err
+	}
+
+	front := slices.Sorted(slices.Values(options.Front))
+	back := slices.Sorted(slices.Values(options.Back))
+
+	// 候选注为选中号码的全部单式组合，开奖组合为选中号码中任意 Hit 个号码
+	candidates := getCombinations(len(front), rule.FrontSize)
+	targets := getCombinations(len(front), options.Hit)
+
+	// covers[i] 为候选注 i 覆盖的开奖组合，coveredBy[j] 为覆盖开奖组合 j 的候选注
+	covers := make([][]int, len(candidates))
+	coveredBy := make([][]int, len(targets))
+
+	for i, candidate := range candidates {
+		for j, target := range targets {
+			if bits.OnesCount32(candidate&target) >= options.Match {
+				covers[i] = append(covers[i], j)
+				coveredBy[j] = append(coveredBy[j], i)
+			}
+		}
+	}
+
+	gains := make([]int, len(candidates))
+	for i := range candidates {
+		gains[i] = len(covers[i])
+	}
+
+	covered := make([]bool, len(targets))
+	remaining := len(targets)
+
+	for remaining > 0 {
+		best := 0
+
+		for i, gain := range gains {
+			if gain > gains[best] {
+				best = i
+			}
+		}
+
+		for _, j := range covers[best] {
+			if covered[j] {
+				continue
+			}
+
+			covered[j] = true
+			remaining--
+
+			for _, i := range coveredBy[j] {
+				gains[i]--
+			}
+		}
+
+		var lott lottery.Lottery
+
+		lott.Type = rule.Type
+		lott.Scale = 1
+		lott.BackTuo = back
+
+		for i, num := range front {
+			if candidates[best]&(1<<i) != 0 {
+				lott.FrontTuo = append(lott.FrontTuo, num)
+			}
+		}
+
+		wheel.List = append(wheel.List, lott)
+	}
+
+	wheel.Bets = len(wheel.List)
+	wheel.Cost = wheel.Bets * lottery.BetPrice
+	wheel.FullBets = len(candidates)
+	wheel.FullCost = wheel.FullBets * lottery.BetPrice
+
+	return wheel, nil
+}
+
+// Check
+//
+// @Description 穷举选中号码中任意 Hit 个号码开出的情况，用开奖核对逻辑验证旋转矩阵满足保证条件。
+// 其余开奖号码优先使用未选中的号码，对应命中最少的最坏情况
+//
+// @Return WheelCheck 验证结果
+//
+// @Return error 不满足保证条件时返回第一个反例
+func (wheel *Wheel) Check() (WheelCheck, error) {
+	var result WheelCheck
+
+	options := wheel.Options
+
+	rule, err := lottery.GetGameRule(options.Type)
+	if err != nil {
+		return result, err
+	}
+
+	if len(wheel.List) == 0 {
+		return result, errors.New("旋转矩阵没有彩票")
+	}
+
+	front := slices.Sorted(slices.Values(options.Front))
+
+	var others []int
+
+	for num := rule.FrontMin; num <= rule.FrontMax; num++ {
+		if !slices.Contains(front, num) {
+			others = append(others, num)
+		}
+	}
+
+	result.WorstMatch = rule.FrontSize
+	result.WorstLevel = -1
+
+	for _, target := range getCombinations(len(front), options.Hit) {
+		var draw lottery.Lottery
+
+		draw.Type = rule.Type
+		draw.BackTuo = slices.Sorted(slices.Values(options.Back))
+
+		for i, num := range front {
+			if target&(1<<i) != 0 {
+				draw.FrontTuo = append(draw.FrontTuo, num)
+			}
+		}
+
+		// 未选中的号码不足时用剩余的选中号码补足，此时实际开出的选中号码多于 Hit 个
+		fillers := append([]int{}, others...)
+		for i, num := range front {
+			if target&(1<<i) == 0 {
+				fillers = append(fillers, num)
+			}
+		}
+
+		draw.FrontTuo = slices.Sorted(slices.Values(append(draw.FrontTuo, fillers[:rule.FrontSize-options.Hit]...)))
+
+		bestMatch, bestLevel := 0, 0
+
+		for _, lott := range wheel.List {
+			lottResult, err := lott.GetLotteryResult(draw)
+			if err != nil {
+				return result, err
+			}
+
+			bestMatch = max(bestMatch, lottResult.FrontMatched)
+
+			if lottResult.Level > 0 && (bestLevel == 0 || lottResult.Level < bestLevel) {
+				bestLevel = lottResult.Level
+			}
+		}
+
+		if bestMatch < options.Match {
+			return result, fmt.Errorf("不满足保证条件，开奖号码: %s，最多命中%d个前区号码", draw.Format(false), bestMatch)
+		}
+
+		// 等级数字越大奖金越低，0为未中奖
+		if bestLevel == 0 || (result.WorstLevel != 0 && bestLevel > result.WorstLevel) {
+			result.WorstLevel = bestLevel
+		}
+
+		result.WorstMatch = min(result.WorstMatch, bestMatch)
+		result.Draws++
+	}
+
+	return result, nil
+}
+
+// Print
+//
+// @Description 输出旋转矩阵的保证条件、投注金额和全部彩票
+//
+// @Param w io.Writer 输出
+func (wheel *Wheel) Print(w io.Writer) {
+	fmt.Fprintf(w, "保证条件: %s\n", wheel.Options.Guarantee())
+	fmt.Fprintf(w, "注数: %d，投注金额: %d (全复式 %d注，%d)\n", wheel.Bets, wheel.Cost, wheel.FullBets, wheel.FullCost)
+
+	for _, lott := range wheel.List {
+		fmt.Fprintln(w, lott.String())
+	}
+}
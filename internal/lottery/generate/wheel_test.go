@@ -0,0 +1,95 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+func TestNewWheel(t *testing.T) {
+	tests := []struct {
+		name    string
+		options WheelOptions
+		maxBets int
+		level   int
+	}{
+		{"5个号码", WheelOptions{"DLT", []int{1, 2, 3, 4, 5}, []int{1, 2}, 5, 5}, 1, 1},
+		{"全中保全中", WheelOptions{"DLT", []int{1, 2, 3, 4, 5, 6}, []int{1, 2}, 5, 5}, 6, 1},
+		{"中5保4", WheelOptions{"DLT", []int{1, 3, 5, 7, 9, 11, 13, 15, 17, 19}, []int{3, 9}, 5, 4}, 60, 4},
+		{"中4保3", WheelOptions{"DLT", []int{2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24}, []int{1, 12}, 4, 3}, 100, 6},
+		{"双色球中6保5", WheelOptions{"SSQ", []int{1, 2, 3, 4, 5, 6, 7, 8}, []int{16}, 6, 5}, 28, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			wheel, err := NewWheel(tt.options)
+			if err != nil {
+				t.Fatalf("错误信息: %s", err)
+			}
+
+			if wheel.Bets > tt.maxBets || wheel.Bets > wheel.FullBets || wheel.Cost != wheel.Bets*lottery.BetPrice {
+				t.Errorf("注数错误: %d，全复式: %d", wheel.Bets, wheel.FullBets)
+			}
+
+			result, err := wheel.Check()
+			if err != nil {
+				t.Fatalf("错误信息: %s", err)
+			}
+
+			if result.WorstMatch < tt.options.Match {
+				t.Errorf("期望至少命中: %d, 实际: %d", tt.options.Match, result.WorstMatch)
+			}
+
+			if tt.level > 0 && result.WorstLevel != tt.level {
+				t.Errorf("期望保证等级: %d, 实际: %d", tt.level, result.WorstLevel)
+			}
+
+			for _, lott := range wheel.List {
+				if _, err := lottery.GetLottery(lott.String()); err != nil || !lott.IsSingleLottery() {
+					t.Errorf("彩票错误: %s", lott.String())
+				}
+			}
+		})
+	}
+}
+
+func TestWheelCheck(t *testing.T) {
+	options := WheelOptions{"DLT", []int{1, 2, 3, 4, 5, 6}, []int{1, 2}, 5, 5}
+	lott, _ := lottery.GetLottery("DLT:01,02,03,04,05-01,02")
+	wheel := Wheel{Options: options, List: []lottery.Lottery{lott}}
+
+	if _, err := wheel.Check(); err == nil {
+		t.Errorf("不满足保证条件时应该失败")
+	}
+
+	options.Match = 4
+
+	wheel.Options = options
+
+	if result, err := wheel.Check(); err != nil {
+		t.Errorf("错误信息: %s", err)
+	} else if result.Draws != 6 || result.WorstMatch != 4 {
+		t.Errorf("验证结果错误: %+v", result)
+	}
+}
+
+func TestNewWheelError(t *testing.T) {
+	tests := []struct {
+		name    string
+		options WheelOptions
+	}{
+		{"号码不足", WheelOptions{"DLT", []int{1, 2, 3, 4}, []int{1, 2}, 4, 3}},
+		{"保证个数大于开出个数", WheelOptions{"DLT", []int{1, 2, 3, 4, 5, 6}, []int{1, 2}, 4, 5}},
+		{"开出个数过多", WheelOptions{"DLT", []int{1, 2, 3, 4, 5, 6}, []int{1, 2}, 6, 5}},
+		{"后区数量错误", WheelOptions{"DLT", []int{1, 2, 3, 4, 5, 6}, []int{1}, 5, 4}},
+		{"号码重复", WheelOptions{"DLT", []int{1, 1, 3, 4, 5, 6}, []int{1, 2}, 5, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := NewWheel(tt.options); err == nil {
+				t.Errorf("应该失败")
+			}
+		})
+	}
+}
@@ -0,0 +1,397 @@
+package lottery
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 语言
+type Locale string
+
+const (
+	LocaleZhCN Locale = "zh-CN" // 简体中文，默认语言
+	LocaleEn   Locale = "en"    // 英文
+)
+
+// 当前语言，应该在程序启动时通过 SetLocale 设置
+var currentLocale = LocaleZhCN
+
+// 消息目录，键为消息键，值为 fmt 格式字符串
+var catalogues = map[Locale]map[string]string{
+	LocaleZhCN: {
+		"level.0":     "无",
+		"level.1":     "一等奖",
+		"level.2":     "二等奖",
+		"level.3":     "三等奖",
+		"level.4":     "四等奖",
+		"level.5":     "五等奖",
+		"level.6":     "六等奖",
+		"level.7":     "七等奖",
+		"level.8":     "八等奖",
+		"level.9":     "九等奖",
+		"label.price": "奖金",
+		"label.total": "合计奖金",
+		"label.net":   "税后",
+
+		"zone.duplicate_tilde": "号码区解析错误。拖区重复。输入: %s",
+		"zone.transition":      "号码区解析错误，不支持的状态流转: %s。输入: %s",
+		"zone.number_empty":    "号码至少为1位",
+		"zone.number_too_long": "号码最多为2位数",
+		"zone.number_invalid":  "号码解析失败，错误信息: %s",
+		"zone.number_type":     "号码解析失败，错误的类型: %s",
+		"zone.empty":           "号码区解析失败。输入: %s",
+		"zone.duplicate_dan":   "号码区解析失败。胆码区重复: %v。",
+		"zone.duplicate_tuo":   "号码区解析失败。拖码区重复: %v。",
+		"zone.duplicate_both":  "号码区解析失败。胆码区重复: %v。拖码区重复: %v。",
+		"zone.conflict":        "号码区解析失败。拖码区冲突: %v。",
+		"zone.char":            "号码区解析失败，错误的字符: 【%c】。输入: %s",
+
+		"parse.token":           "解析失败。错误的token类型: %s, 当前token类型: %s。输入: %s",
+		"parse.duplicate_scale": "解析失败。倍投已解析过。输入: %s",
+		"parse.duplicate_index": "解析失败。期号已解析过。输入: %s",
+		"parse.unknown_type":    "彩票类型解析失败。不支持的彩票类型: %s。输入: %s",
+		"parse.type":            "彩票类型解析失败。输入: %s",
+		"parse.front":           "前区号码解析失败。原因: %s。输入: %s",
+		"parse.back":            "后区号码解析失败。原因: %s。输入: %s",
+		"parse.front_char":      "前区号码解析失败。当前字符: 【%c】。输入: %s",
+		"parse.back_char":       "后区号码解析失败。输入: %s",
+		"parse.scale":           "倍投倍数解析失败。倍数: %s。输入: %s",
+		"parse.scale_char":      "倍投数解析失败。当前字符: 【%c】。输入: %s",
+		"parse.index":           "期号解析失败。期号: %s。输入: %s",
+		"parse.index_char":      "期数解析失败。当前字符: 【%c】。输入: %s",
+		"parse.draw":            "开奖号码解析失败: %s",
+		"parse.line":            "%s 第%d行: %s",
+
+		"lottery.expand":     "单式票生成失败，输入: %s",
+		"lottery.not_single": "开奖彩票不是单式票: %s",
+
+		"rule.unknown_type":        "不支持的彩票类型: %s",
+		"rule.front_dan_count":     "前区胆码数量应该小于%d，当前数量: %d",
+		"rule.back_dan_count":      "后区胆码数量应该小于%d，当前数量: %d",
+		"rule.front_dan_duplicate": "前区胆码重复: %v",
+		"rule.front_tuo_duplicate": "前区拖码重复: %v",
+		"rule.back_tuo_duplicate":  "后区拖码重复: %v",
+		"rule.front_conflict":      "前区拖码与胆码重复: %v",
+		"rule.back_conflict":       "后区拖码与胆码重复: %v",
+		"rule.front_min":           "前区最少需要%d个数字",
+		"rule.back_min":            "后区最少需要%d个数字",
+		"rule.front_range":         "前区数字范围为%d~%d",
+		"rule.back_range":          "后区数字范围为%d~%d",
+		"rule.single_dan":          "单式票不能包含胆码",
+		"rule.front_size":          "前区号码数量应该为%d，当前数量: %d",
+		"rule.back_size":           "后区号码数量应该为%d，当前数量: %d",
+		"rule.back_none":           "%s没有后区号码",
+		"rule.special_size":        "特别号数量应该为%d，当前数量: %d",
+		"rule.special_conflict":    "特别号与基本号码重复: %v",
+
+		"prize.unknown_issue": "没有收录期号 %d 的奖级规则: %s",
+	},
+	LocaleEn: {
+		"level.0":     "none",
+		"level.1":     "1st prize",
+		"level.2":     "2nd prize",
+		"level.3":     "3rd prize",
+		"level.4":     "4th prize",
+		"level.5":     "5th prize",
+		"level.6":     "6th prize",
+		"level.7":     "7th prize",
+		"level.8":     "8th prize",
+		"level.9":     "9th prize",
+		"label.price": "Prize",
+		"label.total": "Total prize",
+		"label.net":   "After tax",
+
+		"zone.duplicate_tilde": "invalid numbers: more than one '~'. input: %s",
+		"zone.transition":      "invalid numbers: unsupported transition: %s. input: %s",
+		"zone.number_empty":    "number must have at least 1 digit",
+		"zone.number_too_long": "number must have at most 2 digits",
+		"zone.number_invalid":  "invalid number: %s",
+		"zone.number_type":     "invalid number type: %s",
+		"zone.empty":           "invalid numbers: no numbers. input: %s",
+		"zone.duplicate_dan":   "invalid numbers: duplicate banker numbers: %v",
+		"zone.duplicate_tuo":   "invalid numbers: duplicate leg numbers: %v",
+		"zone.duplicate_both":  "invalid numbers: duplicate banker numbers: %v, duplicate leg numbers: %v",
+		"zone.conflict":        "invalid numbers: leg numbers repeat banker numbers: %v",
+		"zone.char":            "invalid numbers: unexpected character '%c'. input: %s",
+
+		"parse.token":           "parse failed: unexpected token %s after %s. input: %s",
+		"parse.duplicate_scale": "parse failed: multiplier given more than once. input: %s",
+		"parse.duplicate_index": "parse failed: issue given more than once. input: %s",
+		"parse.unknown_type":    "invalid lottery type: unsupported type %s. input: %s",
+		"parse.type":            "invalid lottery type. input: %s",
+		"parse.front":           "invalid front numbers: %s. input: %s",
+		"parse.back":            "invalid back numbers: %s. input: %s",
+		"parse.front_char":      "invalid front numbers: unexpected character '%c'. input: %s",
+		"parse.back_char":       "invalid back numbers. input: %s",
+		"parse.scale":           "invalid multiplier: %s. input: %s",
+		"parse.scale_char":      "invalid multiplier: unexpected character '%c'. input: %s",
+		"parse.index":           "invalid issue: %s. input: %s",
+		"parse.index_char":      "invalid issue: unexpected character '%c'. input: %s",
+		"parse.draw":            "invalid draw: %s",
+		"parse.line":            "%s line %d: %s",
+
+		"lottery.expand":     "failed to expand ticket. input: %s",
+		"lottery.not_single": "draw is not a single ticket: %s",
+
+		"rule.unknown_type":        "unsupported lottery type: %s",
+		"rule.front_dan_count":     "front banker count must be less than %d, got %d",
+		"rule.back_dan_count":      "back banker count must be less than %d, got %d",
+		"rule.front_dan_duplicate": "duplicate front banker numbers: %v",
+		"rule.front_tuo_duplicate": "duplicate front leg numbers: %v",
+		"rule.back_tuo_duplicate":  "duplicate back leg numbers: %v",
+		"rule.front_conflict":      "front leg numbers repeat banker numbers: %v",
+		"rule.back_conflict":       "back leg numbers repeat banker numbers: %v",
+		"rule.front_min":           "front zone needs at least %d numbers",
+		"rule.back_min":            "back zone needs at least %d numbers",
+		"rule.front_range":         "front numbers must be between %d and %d",
+		"rule.back_range":          "back numbers must be between %d and %d",
+		"rule.single_dan":          "a single ticket cannot contain banker numbers",
+		"rule.front_size":          "front zone must have %d numbers, got %d",
+		"rule.back_size":           "back zone must have %d numbers, got %d",
+		"rule.back_none":           "%s has no back zone",
+		"rule.special_size":        "draw must have %d special numbers, got %d",
+		"rule.special_conflict":    "special numbers repeat basic numbers: %v",
+
+		"prize.unknown_issue": "no prize rules for issue %d: %s",
+	},
+}
+
+// ParseLocale
+//
+// @Description 解析语言名称，支持 zh、zh-CN、zh_CN.UTF-8、en、en_US.UTF-8 等格式
+//
+// @Param name string 语言名称
+//
+// @Return Locale 语言
+//
+// @Return bool 是否是支持的语言
+func ParseLocale(name string) (Locale, bool) {
+	name = strings.ToLower(name)
+
+	if i := strings.IndexAny(name, "_-."); i >= 0 {
+		name = name[:i]
+	}
+
+	switch name {
+	case "zh":
+		return LocaleZhCN, true
+	case "en":
+		return LocaleEn, true
+	}
+
+	return LocaleZhCN, false
+}
+
+// GetEnvLocale
+//
+// @Description 按 LC_ALL、LC_MESSAGES、LANG 的顺序从环境变量中获取语言，以第一个非空的变量为准，不支持的语言和未设置时为简体中文
+//
+// @Return Locale 语言
+func GetEnvLocale() Locale {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			locale, _ := ParseLocale(value)
+			return locale
+		}
+	}
+
+	return LocaleZhCN
+}
+
+// SetLocale
+//
+// @Description 设置当前语言，影响中奖等级名称、结果标签和错误信息
+//
+// @Param locale Locale 语言
+func SetLocale(locale Locale) {
+	currentLocale = locale
+}
+
+// GetLocale
+//
+// @Description 获取当前语言
+//
+// @Return Locale 语言
+func GetLocale() Locale {
+	return currentLocale
+}
+
+// RegisterMessages
+//
+// @Description 向语言的消息目录中添加消息，用于其他包注册自己的消息，应该在 init 中调用
+//
+// @Param locale Locale 语言
+//
+// @Param messages map[string]string 消息键和格式字符串
+func RegisterMessages(locale Locale, messages map[string]string) {
+	catalogue, ok := catalogues[locale]
+	if !ok {
+		catalogue = make(map[string]string, len(messages))
+		catalogues[locale] = catalogue
+	}
+
+	for key, format := range messages {
+		catalogue[key] = format
+	}
+}
+
+// Translate
+//
+// @Description 使用指定语言格式化消息，找不到消息时使用简体中文，仍找不到时返回消息键
+//
+// @Param locale Locale 语言
+//
+// @Param key string 消息键
+//
+// @Param args ...any 格式化参数，*Error 类型的参数也会使用指定语言
+//
+// @Return string 格式化后的消息
+func Translate(locale Locale, key string, args ...any) string {
+	format, ok := catalogues[locale][key]
+	if !ok {
+		format, ok = catalogues[LocaleZhCN][key]
+	}
+
+	if !ok {
+		return key
+	}
+
+	localized := make([]any, len(args))
+
+	for i, arg := range args {
+		if err, ok := arg.(*Error); ok {
+			localized[i] = err.Localize(locale)
+		} else {
+			localized[i] = arg
+		}
+	}
+
+	return fmt.Sprintf(format, localized...)
+}
+
+// 错误类型，不随语言变化，用于程序判断错误原因
+type ErrorKind string
+
+const (
+	ErrSyntax          ErrorKind = "syntax"           // 彩票字符串格式错误
+	ErrUnknownType     ErrorKind = "unknown_type"     // 不支持的彩票类型
+	ErrInvalidNumber   ErrorKind = "invalid_number"   // 号码格式错误
+	ErrInvalidScale    ErrorKind = "invalid_scale"    // 倍投倍数错误
+	ErrInvalidIndex    ErrorKind = "invalid_index"    // 期号错误
+	ErrDuplicateNumber ErrorKind = "duplicate_number" // 号码重复
+	ErrDanTuoConflict  ErrorKind = "dan_tuo_conflict" // 拖码与胆码重复
+	ErrDanCount        ErrorKind = "dan_count"        // 胆码数量错误
+	ErrNumberCount     ErrorKind = "number_count"     // 号码数量错误
+	ErrNumberRange     ErrorKind = "number_range"     // 号码超出范围
+	ErrNotSingle       ErrorKind = "not_single"       // 不是单式票
+	ErrUnknownIssue    ErrorKind = "unknown_issue"    // 没有收录期号的奖级规则
+)
+
+// 可本地化的错误，错误信息在输出时按语言格式化
+type Error struct {
+	Kind ErrorKind // 错误类型
+	Key  string    // 消息键
+	Args []any     // 格式化参数
+}
+
+// NewError
+//
+// @Description 创建可本地化的错误
+//
+// @Param kind ErrorKind 错误类型，为空时使用第一个 *Error 参数的错误类型
+//
+// @Param key string 消息键
+//
+// @Param args ...any 格式化参数
+//
+// @Return *Error 错误
+func NewError(kind ErrorKind, key string, args ...any) *Error {
+	if kind == "" {
+		kind = GetErrorKind(findCause(args))
+	}
+
+	return &Error{kind, key, args}
+}
+
+func findCause(args []any) error {
+	for _, arg := range args {
+		if err, ok := arg.(error); ok {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Error) Error() string {
+	return e.Localize(currentLocale)
+}
+
+func (e *Error) Unwrap() error {
+	return findCause(e.Args)
+}
+
+// Localize
+//
+// @Description 使用指定语言格式化错误信息
+//
+// @Param locale Locale 语言
+//
+// @Return string 错误信息
+func (e *Error) Localize(locale Locale) string {
+	args := make([]any, len(e.Args))
+
+	// 作为参数的错误也使用指定语言格式化
+	for i, arg := range e.Args {
+		if err, ok := arg.(error); ok {
+			args[i] = LocalizeError(err, locale)
+		} else {
+			args[i] = arg
+		}
+	}
+
+	return Translate(locale, e.Key, args...)
+}
+
+// GetErrorKind
+//
+// @Description 获取错误类型，不是可本地化的错误时为空
+//
+// @Param err error 错误
+//
+// @Return ErrorKind 错误类型
+func GetErrorKind(err error) ErrorKind {
+	var e *Error
+
+	if errors.As(err, &e) {
+		return e.Kind
+	}
+
+	return ""
+}
+
+// LocalizeError
+//
+// @Description 使用指定语言格式化错误信息，不是可本地化的错误时返回原错误信息。
+// 被 fmt.Errorf 等包装的可本地化错误只翻译被包装的部分，包装时添加的文字保持不变，需要翻译时应该用 NewError 包装
+//
+// @Param err error 错误
+//
+// @Param locale Locale 语言
+//
+// @Return string 错误信息
+func LocalizeError(err error, locale Locale) string {
+	var e *Error
+
+	if !errors.As(err, &e) {
+		return err.Error()
+	}
+
+	if err == error(e) {
+		return e.Localize(locale)
+	}
+
+	return strings.Replace(err.Error(), e.Error(), e.Localize(locale), 1)
+}
@@ -0,0 +1,141 @@
+package lottery
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		name   string
+		locale Locale
+		ok     bool
+	}{
+		{"zh-CN", LocaleZhCN, true},
+		{"zh_CN.UTF-8", LocaleZhCN, true},
+		{"en", LocaleEn, true},
+		{"en_US.UTF-8", LocaleEn, true},
+		{"EN-GB", LocaleEn, true},
+		{"C", LocaleZhCN, false},
+		{"", LocaleZhCN, false},
+	}
+
+	for _, tt := range tests {
+		if locale, ok := ParseLocale(tt.name); locale != tt.locale || ok != tt.ok {
+			t.Errorf("%s 期望: %s %v, 实际: %s %v", tt.name, tt.locale, tt.ok, locale, ok)
+		}
+	}
+}
+
+func TestGetEnvLocale(t *testing.T) {
+	tests := []struct {
+		name   string
+		env    map[string]string
+		locale Locale
+	}{
+		{"未设置", map[string]string{}, LocaleZhCN},
+		{"LANG 英文", map[string]string{"LANG": "en_US.UTF-8"}, LocaleEn},
+		{"LC_ALL 优先", map[string]string{"LC_ALL": "zh_CN.UTF-8", "LANG": "en_US.UTF-8"}, LocaleZhCN},
+		{"LC_MESSAGES 优先于 LANG", map[string]string{"LC_MESSAGES": "en_US.UTF-8", "LANG": "zh_CN.UTF-8"}, LocaleEn},
+		{"不支持的语言", map[string]string{"LANG": "C.UTF-8"}, LocaleZhCN},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			if locale := GetEnvLocale(); locale != tt.locale {
+				t.Errorf("期望: %s, 实际: %s", tt.locale, locale)
+			}
+		})
+	}
+}
+
+func TestLocalizeError(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		kind  ErrorKind
+		zh    string
+		en    string
+	}{
+		{"不支持的彩票类型", "ABC:01,02,03,04,05-01,02", ErrUnknownType, "彩票类型解析失败。不支持的彩票类型: ABC。输入: ABC:01,02,03,04,05-01,02", "invalid lottery type: unsupported type ABC. input: ABC:01,02,03,04,05-01,02"},
+		{"号码过长", "DLT:01,02,03,04,005-01,02", ErrInvalidNumber, "前区号码解析失败。原因: 号码最多为2位数。输入: DLT:01,02,03,04,005-01,02", "invalid front numbers: number must have at most 2 digits. input: DLT:01,02,03,04,005-01,02"},
+		{"号码重复", "DLT:01,02,03,04,05-01,01", ErrDuplicateNumber, "后区号码解析失败。原因: 号码区解析失败。拖码区重复: [1]。。输入: DLT:01,02,03,04,05-01,01", "invalid back numbers: invalid numbers: duplicate leg numbers: [1]. input: DLT:01,02,03,04,05-01,01"},
+		{"倍投错误", "DLT:01,02,03,04,05-01,02xa", ErrInvalidScale, "倍投数解析失败。当前字符: 【a】。输入: DLT:01,02,03,04,05-01,02xa", "invalid multiplier: unexpected character 'a'. input: DLT:01,02,03,04,05-01,02xa"},
+		{"号码超出范围", "DLT:01,02,03,04,36-01,02", ErrNumberRange, "前区数字范围为1~35", "front numbers must be between 1 and 35"},
+		{"号码太少", "SSQ:01,02,03,04,05-01", ErrNumberCount, "前区最少需要6个数字", "front zone needs at least 6 numbers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := CountBets(tt.input)
+
+			if kind := GetErrorKind(err); kind != tt.kind {
+				t.Errorf("错误类型期望: %s, 实际: %s", tt.kind, kind)
+			}
+
+			if msg := LocalizeError(err, LocaleZhCN); msg != tt.zh {
+				t.Errorf("中文错误信息期望: %s, 实际: %s", tt.zh, msg)
+			}
+
+			if msg := LocalizeError(err, LocaleEn); msg != tt.en {
+				t.Errorf("英文错误信息期望: %s, 实际: %s", tt.en, msg)
+			}
+		})
+	}
+}
+
+func TestLocalizeWrappedError(t *testing.T) {
+	_, cause := CountBets("DLT:01,02,03,04,36-01,02")
+
+	tests := []struct {
+		name string
+		err  error
+		zh   string
+		en   string
+	}{
+		{"fmt.Errorf 包装", fmt.Errorf("第%d期: %w", 25053, cause), "第25053期: 前区数字范围为1~35", "第25053期: front numbers must be between 1 and 35"},
+		{"NewError 包装", NewError("", "parse.line", "tickets.txt", 3, cause), "tickets.txt 第3行: 前区数字范围为1~35", "tickets.txt line 3: front numbers must be between 1 and 35"},
+		{"不是可本地化的错误", errors.New("其他错误"), "其他错误", "其他错误"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if msg := LocalizeError(tt.err, LocaleZhCN); msg != tt.zh {
+				t.Errorf("中文错误信息期望: %s, 实际: %s", tt.zh, msg)
+			}
+
+			if msg := LocalizeError(tt.err, LocaleEn); msg != tt.en {
+				t.Errorf("英文错误信息期望: %s, 实际: %s", tt.en, msg)
+			}
+		})
+	}
+}
+
+func TestSetLocale(t *testing.T) {
+	t.Cleanup(func() { SetLocale(LocaleZhCN) })
+
+	source, _ := GetLottery("DLT:01,02,03,04,05,06-01,02")
+	target, _ := GetLottery("DLT:01,02,03,04,05-01,02")
+	result, _ := source.GetLotteryResult(target)
+
+	SetLocale(LocaleEn)
+
+	if str := result.FormatResult(false, false); str != "01,02,03,04,05,06-01,02\t1st prize×1, 4th prize×5\tTotal prize: 10015000\tAfter tax: 8015000" {
+		t.Errorf("英文结果错误: %s", str)
+	}
+
+	if _, err := GetGameRule("ABC"); err == nil || err.Error() != "unsupported lottery type: ABC" {
+		t.Errorf("英文错误信息错误: %v", err)
+	}
+
+	SetLocale(LocaleZhCN)
+
+	if str := result.FormatResult(false, false); str != "01,02,03,04,05,06-01,02\t一等奖×1, 四等奖×5\t合计奖金: 10015000\t税后: 8015000" {
+		t.Errorf("中文结果错误: %s", str)
+	}
+}
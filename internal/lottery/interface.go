@@ -1,5 +1,8 @@
 package lottery
 
+// 单注价格 (元)
+const BetPrice = 2
+
 // 购奖号码，通过 Bingo 判断当前号码是否是中奖号码
 type BingoNum struct {
 	Num   int  // 彩票号码
@@ -11,12 +14,13 @@ type BingoNum struct {
 // 号码类型包括：前区胆码、前区拖码、后区胆码、后区拖码
 type ResultNum struct {
 	BingoNum
-	Type string // 号码类型 (FrontDan: 前区胆码, FrontTuo: 前区拖码, BackDan: 后区胆码, BackTuo: 后区拖码)
+	Type    string // 号码类型 (FrontDan: 前区胆码, FrontTuo: 前区拖码, BackDan: 后区胆码, BackTuo: 后区拖码)
+	Special bool   // 是否命中开奖号码的特别号，只有七乐彩等有特别号的彩票会标记
 }
 
 // 购彩基本信息
 type LotteryBaseInfo struct {
-	Type  string // 彩票类型 (DLT: 大乐透, SSQ: 双色球)
+	Type  string // 彩票类型 (DLT: 大乐透, SSQ: 双色球, QLC: 七乐彩)
 	Index int    // 开奖期号
 	Scale int    // 倍投倍数
 }
@@ -46,7 +50,10 @@ type LotteryResult struct {
 	FrontMatched    int
 	BackMatched     int
 	Level           int
-	Price           int
+	UnitPrice       int // 单注奖金，未乘倍投倍数，复式票为0
+	Price           int // 税前奖金，包含倍投
+	Tax             int // 个人所得税，按单注奖金计算
+	Net             int // 税后奖金
 	Numbers         []ResultNum
 	List            []LotteryResult
 }
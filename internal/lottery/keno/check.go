@@ -0,0 +1,115 @@
+package keno
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 单区选号型彩票的开奖结果，金额单位为分，复式票按展开后的单式票分别计奖和计税
+type Result struct {
+	Ticket  Ticket      // 彩票
+	Draw    Ticket      // 开奖号码
+	Bets    int         // 注数，不包含倍投
+	Matched []int       // 命中的号码
+	Hits    map[int]int // 中奖的命中个数对应的中奖注数，包含倍投
+	Price   int         // 税前奖金 (分)
+	Tax     int         // 个人所得税 (分)
+	Net     int         // 税后奖金 (分)
+}
+
+// GetResult
+//
+// @Description 用开奖号码核对彩票，按命中个数统计复式票展开后的单式票，不需要逐注展开
+//
+// @Param draw Ticket 开奖号码
+//
+// @Return Result 开奖结果
+//
+// @Return error 错误信息
+func (ticket *Ticket) GetResult(draw Ticket) (Result, error) {
+	result := Result{Ticket: *ticket, Draw: draw, Bets: ticket.GetBetCount()}
+
+	if ticket.Type != draw.Type {
+		return result, lottery.NewError(lottery.ErrUnknownType, "keno.type_mismatch", ticket.Type, draw.Type)
+	}
+
+	rule, err := GetGameRule(ticket.Type)
+	if err != nil {
+		return result, err
+	}
+
+	play, ok := rule.Plays[ticket.Pick]
+	if !ok {
+		return result, lottery.NewError(ErrUnknownPlay, "keno.unknown_play", rule.Name, GetPlayLabel(ticket.Pick))
+	}
+
+	result.Matched = lottery.GetCrossNums(ticket.Nums, draw.Nums)
+
+	matched := len(result.Matched)
+	missed := len(ticket.Nums) - matched
+	scale := max(ticket.Scale, 1)
+
+	for hit, price := range play.Prizes {
+		// 命中 hit 个号码的单式票: 从命中的号码中选 hit 个，从未命中的号码中选其余的号码
+		count := combination(matched, hit) * combination(missed, ticket.Pick-hit) * scale
+		if count == 0 {
+			continue
+		}
+
+		if result.Hits == nil {
+			result.Hits = make(map[int]int)
+		}
+
+		// 个人所得税规则按元计算，超过起征额的奖金都是整元
+		result.Hits[hit] += count
+		result.Price += price * count
+		result.Tax += lottery.DefaultTaxRule.GetTax(price/100) * 100 * count
+	}
+
+	result.Net = result.Price - result.Tax
+
+	return result, nil
+}
+
+// FormatPrice
+//
+// @Description 将以分为单位的金额格式化为元，省略多余的0，例如: 460 格式化为 4.6
+//
+// @Param cents int 金额 (分)
+//
+// @Return string 金额 (元)
+func FormatPrice(cents int) string {
+	return strconv.FormatFloat(float64(cents)/100, 'f', -1, 64)
+}
+
+// FormatResult
+//
+// @Description 格式化开奖结果，例如: KL8/X5:01,02,03,04,05,06	选5中4×2 选5中3×4	奖金: 54
+//
+// @Return string 格式化后的字符串
+func (result *Result) FormatResult() string {
+	var labels []string
+
+	for _, hit := range slices.Backward(slices.Sorted(maps.Keys(result.Hits))) {
+		label := lottery.Translate(lottery.GetLocale(), "keno.hit", result.Ticket.Pick, hit)
+		labels = append(labels, fmt.Sprintf("%s×%d", label, result.Hits[hit]))
+	}
+
+	label := lottery.GetLevelLabel(0)
+	if len(labels) > 0 {
+		label = strings.Join(labels, " ")
+	}
+
+	str := fmt.Sprintf("%s\t%s\t%s: %s", result.Ticket.String(), label, lottery.Translate(lottery.GetLocale(), "label.price"), FormatPrice(result.Price))
+
+	if result.Tax > 0 {
+		str += fmt.Sprintf("\t%s: %s", lottery.Translate(lottery.GetLocale(), "label.net"), FormatPrice(result.Net))
+	}
+
+	return str
+}
@@ -0,0 +1,373 @@
+package keno
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 不支持的玩法
+const ErrUnknownPlay lottery.ErrorKind = "unknown_play"
+
+// 单区选号型彩票某一玩法的奖级，按命中个数确定奖金
+type PlayRule struct {
+	Pick   int         // 选号个数，同时也是单式票的号码个数
+	Prizes map[int]int // 命中个数对应的单注奖金 (分)，未列出的命中个数不中奖
+}
+
+// 单区选号型彩票的玩法规则，只有一个号码区，按玩法选择不同的选号个数和奖级
+type GameRule struct {
+	Type     string           // 彩票类型 (KL8: 快乐8)
+	Name     string           // 彩票名称
+	Min      int              // 最小号码
+	Max      int              // 最大号码
+	DrawSize int              // 开奖号码个数
+	Plays    map[int]PlayRule // 按选号个数区分的玩法
+}
+
+var gameRules = map[string]GameRule{
+	"KL8": {"KL8", "快乐8", 1, 80, 20, map[int]PlayRule{
+		// 选十中十为浮动奖，按封顶金额500万元计算
+		10: {10, map[int]int{10: 500000000, 9: 800000, 8: 80000, 7: 8000, 6: 500, 5: 300, 0: 200}},
+		9:  {9, map[int]int{9: 30000000, 8: 200000, 7: 20000, 6: 2000, 5: 500, 4: 300, 0: 200}},
+		8:  {8, map[int]int{8: 5000000, 7: 80000, 6: 8800, 5: 1000, 4: 300, 0: 200}},
+		7:  {7, map[int]int{7: 1000000, 6: 28800, 5: 2800, 4: 400, 0: 200}},
+		6:  {6, map[int]int{6: 300000, 5: 3000, 4: 1000, 3: 300}},
+		5:  {5, map[int]int{5: 100000, 4: 2100, 3: 300}},
+		4:  {4, map[int]int{4: 10000, 3: 500, 2: 300}},
+		3:  {3, map[int]int{3: 5300, 2: 300}},
+		2:  {2, map[int]int{2: 1900}},
+		1:  {1, map[int]int{1: 460}},
+	}},
+}
+
+func init() {
+	lottery.RegisterMessages(lottery.LocaleZhCN, map[string]string{
+		"keno.play":          "选%d",
+		"keno.hit":           "选%d中%d",
+		"keno.syntax":        "彩票解析失败，格式为: 类型[/X选号个数]:号码[x倍数][:期号]。输入: %s",
+		"keno.unknown_play":  "%s不支持的玩法: %s",
+		"keno.number":        "号码解析失败: %s。输入: %s",
+		"keno.range":         "号码范围为%d~%d，当前号码: %d",
+		"keno.duplicate":     "号码重复: %v",
+		"keno.count":         "%s至少需要%d个号码，当前数量: %d",
+		"keno.scale":         "倍投倍数解析失败: %s。输入: %s",
+		"keno.index":         "期号解析失败: %s。输入: %s",
+		"keno.draw_count":    "开奖号码应该有%d个，当前数量: %d",
+		"keno.type_mismatch": "彩票类型与开奖号码不一致: %s, %s",
+	})
+
+	lottery.RegisterMessages(lottery.LocaleEn, map[string]string{
+		"keno.play":          "pick %d",
+		"keno.hit":           "pick %d hit %d",
+		"keno.syntax":        "invalid ticket, the format is TYPE[/XPICK]:NUMBERS[xSCALE][:ISSUE]. input: %s",
+		"keno.unknown_play":  "%s does not support play %s",
+		"keno.number":        "invalid number: %s. input: %s",
+		"keno.range":         "numbers must be between %d and %d, got %d",
+		"keno.duplicate":     "duplicate numbers: %v",
+		"keno.count":         "%s needs at least %d numbers, got %d",
+		"keno.scale":         "invalid multiplier: %s. input: %s",
+		"keno.index":         "invalid issue: %s. input: %s",
+		"keno.draw_count":    "draw must have %d numbers, got %d",
+		"keno.type_mismatch": "ticket type does not match the draw: %s, %s",
+	})
+}
+
+// GetGameRule
+//
+// @Description 获取单区选号型彩票的玩法规则
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return GameRule 玩法规则
+//
+// @Return error 错误信息
+func GetGameRule(lotteryType string) (GameRule, error) {
+	rule, ok := gameRules[lotteryType]
+	if !ok {
+		return rule, lottery.NewError(lottery.ErrUnknownType, "rule.unknown_type", lotteryType)
+	}
+
+	return rule, nil
+}
+
+// IsKenoType
+//
+// @Description 判断彩票类型是否为单区选号型彩票
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return bool 是否为单区选号型彩票
+func IsKenoType(lotteryType string) bool {
+	_, ok := gameRules[lotteryType]
+	return ok
+}
+
+// GetPlayLabel
+//
+// @Description 获取玩法名称，例如: 选5
+//
+// @Param pick int 选号个数
+//
+// @Return string 玩法名称
+func GetPlayLabel(pick int) string {
+	return lottery.Translate(lottery.GetLocale(), "keno.play", pick)
+}
+
+// 单区选号型彩票，开奖号码也使用该结构，Pick 为开奖号码个数
+type Ticket struct {
+	Type  string // 彩票类型
+	Pick  int    // 玩法的选号个数，号码个数大于选号个数时为复式
+	Nums  []int  // 号码
+	Scale int    // 倍投倍数
+	Index int    // 期号
+}
+
+// parse
+//
+// @Description 解析彩票字符串，不检查号码，玩法省略时选号个数为号码个数
+//
+// @Param input string 彩票字符串
+//
+// @Return Ticket 彩票
+//
+// @Return GameRule 玩法规则
+//
+// @Return error 错误信息
+func parse(input string) (Ticket, GameRule, error) {
+	ticket := Ticket{Scale: 1}
+
+	head, rest, ok := strings.Cut(input, ":")
+	if !ok || rest == "" {
+		return Ticket{}, GameRule{}, lottery.NewError(lottery.ErrSyntax, "keno.syntax", input)
+	}
+
+	lotteryType, play, hasPlay := strings.Cut(head, "/")
+
+	rule, err := GetGameRule(lotteryType)
+	if err != nil {
+		return Ticket{}, rule, err
+	}
+
+	ticket.Type = lotteryType
+
+	body, extra, _ := strings.Cut(rest, ":")
+	if i := strings.IndexByte(body, 'x'); i >= 0 {
+		scale, err := strconv.Atoi(body[i+1:])
+		if err != nil || scale < 1 {
+			return Ticket{}, rule, lottery.NewError(lottery.ErrInvalidScale, "keno.scale", body[i+1:], input)
+		}
+
+		body, ticket.Scale = body[:i], scale
+	}
+
+	if extra != "" {
+		if ticket.Index, err = strconv.Atoi(extra); err != nil || ticket.Index < 1 {
+			return Ticket{}, rule, lottery.NewError(lottery.ErrInvalidIndex, "keno.index", extra, input)
+		}
+	}
+
+	for _, part := range strings.Split(body, ",") {
+		num, err := strconv.Atoi(part)
+		if err != nil {
+			return Ticket{}, rule, lottery.NewError(lottery.ErrInvalidNumber, "keno.number", part, input)
+		}
+
+		ticket.Nums = append(ticket.Nums, num)
+	}
+
+	ticket.Pick = len(ticket.Nums)
+
+	if hasPlay {
+		pick, err := strconv.Atoi(strings.TrimPrefix(play, "X"))
+		if _, ok := rule.Plays[pick]; err != nil || !ok || !strings.HasPrefix(play, "X") {
+			return Ticket{}, rule, lottery.NewError(ErrUnknownPlay, "keno.unknown_play", rule.Name, play)
+		}
+
+		ticket.Pick = pick
+	}
+
+	return ticket, rule, nil
+}
+
+// Parse
+//
+// @Description 解析单区选号型彩票字符串，格式为: 类型[/X选号个数]:号码[x倍数][:期号]，玩法省略时选号个数为号码个数，例如:
+//
+//	KL8:01,02,03,04,05               选5单式
+//	KL8/X5:01,02,03,04,05,06,07x2    选5复式，任选5个号码组成一注
+//	KL8/X1:08:2025280                选1单式，指定期号
+//
+// @Param input string 彩票字符串
+//
+// @Return Ticket 彩票
+//
+// @Return error 错误信息
+func Parse(input string) (Ticket, error) {
+	ticket, rule, err := parse(input)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	if _, ok := rule.Plays[ticket.Pick]; !ok {
+		return Ticket{}, lottery.NewError(ErrUnknownPlay, "keno.unknown_play", rule.Name, GetPlayLabel(ticket.Pick))
+	}
+
+	if err := rule.Check(&ticket); err != nil {
+		return Ticket{}, err
+	}
+
+	return ticket, nil
+}
+
+// ParseDraw
+//
+// @Description 解析开奖号码，号码个数必须与开奖号码个数相同，例如: KL8:01,02,...,20:2025280
+//
+// @Param input string 开奖号码字符串
+//
+// @Return Ticket 开奖号码
+//
+// @Return error 错误信息
+func ParseDraw(input string) (Ticket, error) {
+	draw, rule, err := parse(input)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	if len(draw.Nums) != rule.DrawSize {
+		return Ticket{}, lottery.NewError(lottery.ErrNumberCount, "keno.draw_count", rule.DrawSize, len(draw.Nums))
+	}
+
+	if err := rule.Check(&draw); err != nil {
+		return Ticket{}, err
+	}
+
+	return draw, nil
+}
+
+// Check
+//
+// @Description 检查号码范围、重复号码和号码个数，检查通过后将号码排序
+//
+// @Param ticket *Ticket 彩票
+//
+// @Return error 错误信息
+func (rule GameRule) Check(ticket *Ticket) error {
+	sort.Ints(ticket.Nums)
+
+	for _, num := range ticket.Nums {
+		if num < rule.Min || num > rule.Max {
+			return lottery.NewError(lottery.ErrNumberRange, "keno.range", rule.Min, rule.Max, num)
+		}
+	}
+
+	if dup := lottery.GetDupNums(ticket.Nums); len(dup) > 0 {
+		return lottery.NewError(lottery.ErrDuplicateNumber, "keno.duplicate", dup)
+	}
+
+	if len(ticket.Nums) < ticket.Pick {
+		return lottery.NewError(lottery.ErrNumberCount, "keno.count", GetPlayLabel(ticket.Pick), ticket.Pick, len(ticket.Nums))
+	}
+
+	return nil
+}
+
+// combination
+//
+// @Description 计算组合数 C(n, k)，k 超出范围时为0
+//
+// @Param n int 总数
+//
+// @Param k int 选取的个数
+//
+// @Return int 组合数
+func combination(n, k int) int {
+	if k < 0 || n < 0 || k > n {
+		return 0
+	}
+
+	result := 1
+
+	for i := 1; i <= k; i++ {
+		result = result * (n - k + i) / i
+	}
+
+	return result
+}
+
+// GetBetCount
+//
+// @Description 获取彩票包含的注数，不包含倍投
+//
+// @Return int 注数
+func (ticket *Ticket) GetBetCount() int {
+	return combination(len(ticket.Nums), ticket.Pick)
+}
+
+// GetCost
+//
+// @Description 获取彩票的投注金额，注数 × 倍投倍数 × 单注价格
+//
+// @Return int 投注金额
+func (ticket *Ticket) GetCost() int {
+	return ticket.GetBetCount() * max(ticket.Scale, 1) * lottery.BetPrice
+}
+
+// Expand
+//
+// @Description 将复式票展开为单式票
+//
+// @Return []Ticket 单式票列表
+func (ticket *Ticket) Expand() []Ticket {
+	var result []Ticket
+
+	for _, nums := range lottery.GetCombinations(ticket.Nums, ticket.Pick) {
+		result = append(result, Ticket{Type: ticket.Type, Pick: ticket.Pick, Nums: nums, Scale: ticket.Scale, Index: ticket.Index})
+	}
+
+	return result
+}
+
+// Format
+//
+// @Description 格式化彩票的号码，例如: 01,02,03,04,05
+//
+// @Return string 格式化后的字符串
+func (ticket *Ticket) Format() string {
+	parts := make([]string, 0, len(ticket.Nums))
+
+	for _, num := range ticket.Nums {
+		parts = append(parts, fmt.Sprintf("%02d", num))
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// String
+//
+// @Description 获取彩票的完整字符串，可以被 Parse 重新解析，例如: KL8/X5:01,02,03,04,05,06x2:2025280
+//
+// @Return string 彩票字符串
+func (ticket *Ticket) String() string {
+	str := ticket.Type
+
+	if ticket.Pick != len(ticket.Nums) {
+		str += fmt.Sprintf("/X%d", ticket.Pick)
+	}
+
+	str += ":" + ticket.Format()
+
+	if ticket.Scale > 1 {
+		str += fmt.Sprintf("x%d", ticket.Scale)
+	}
+
+	if ticket.Index > 0 {
+		str += fmt.Sprintf(":%d", ticket.Index)
+	}
+
+	return str
+}
@@ -0,0 +1,125 @@
+package keno
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+const testDraw = "KL8:01,02,03,04,05,06,07,08,09,10,11,12,13,14,15,16,17,18,19,20:2025280"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		kind  lottery.ErrorKind
+		str   string
+		bets  int
+	}{
+		{"选5单式", "KL8:5,04,03,02,01", "", "KL8:01,02,03,04,05", 1},
+		{"选5复式", "KL8/X5:01,02,03,04,05,06,07x2:2025280", "", "KL8/X5:01,02,03,04,05,06,07x2:2025280", 21},
+		{"选1复式", "KL8/X1:80,08", "", "KL8/X1:08,80", 2},
+		{"选10单式", "KL8/X10:01,02,03,04,05,06,07,08,09,10", "", "KL8:01,02,03,04,05,06,07,08,09,10", 1},
+		{"不支持的类型", "KL9:01", lottery.ErrUnknownType, "", 0},
+		{"缺少号码", "KL8", lottery.ErrSyntax, "", 0},
+		{"不支持的玩法", "KL8/X11:01,02,03,04,05,06,07,08,09,10,11", ErrUnknownPlay, "", 0},
+		{"玩法格式错误", "KL8/5:01,02,03,04,05", ErrUnknownPlay, "", 0},
+		{"单式号码太多", "KL8:01,02,03,04,05,06,07,08,09,10,11", ErrUnknownPlay, "", 0},
+		{"号码太少", "KL8/X5:01,02,03", lottery.ErrNumberCount, "", 0},
+		{"号码超出范围", "KL8:81", lottery.ErrNumberRange, "", 0},
+		{"号码重复", "KL8/X2:01,01,02", lottery.ErrDuplicateNumber, "", 0},
+		{"号码错误", "KL8:0a", lottery.ErrInvalidNumber, "", 0},
+		{"倍投错误", "KL8:01x0", lottery.ErrInvalidScale, "", 0},
+		{"期号错误", "KL8:01:abc", lottery.ErrInvalidIndex, "", 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket, err := Parse(tt.input)
+
+			if tt.kind != "" {
+				if kind := lottery.GetErrorKind(err); kind != tt.kind {
+					t.Errorf("错误类型期望: %s, 实际: %s (%v)", tt.kind, kind, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ticket.String() != tt.str || ticket.GetBetCount() != tt.bets || len(ticket.Expand()) != tt.bets {
+				t.Errorf("期望: %s %d注, 实际: %s %d注", tt.str, tt.bets, ticket.String(), ticket.GetBetCount())
+			}
+		})
+	}
+}
+
+func TestParseDraw(t *testing.T) {
+	if _, err := ParseDraw(testDraw); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ParseDraw("KL8:01,02,03"); lottery.GetErrorKind(err) != lottery.ErrNumberCount {
+		t.Errorf("开奖号码数量错误时应该返回错误: %v", err)
+	}
+}
+
+func TestGetResult(t *testing.T) {
+	draw, err := ParseDraw(testDraw)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		ticket string
+		hits   map[int]int
+		price  int
+		net    int
+		str    string
+	}{
+		{"选1中1", "KL8:01", map[int]int{1: 1}, 460, 460, "KL8:01\t选1中1×1\t奖金: 4.6"},
+		{"选1未中", "KL8:80", nil, 0, 0, "KL8:80\t无\t奖金: 0"},
+		{"选5中4复式", "KL8/X5:01,02,03,04,79,80x2", map[int]int{4: 4, 3: 8}, 10800, 10800, "KL8/X5:01,02,03,04,79,80x2\t选5中4×4 选5中3×8\t奖金: 108"},
+		{"选10中0", "KL8:71,72,73,74,75,76,77,78,79,80", map[int]int{0: 1}, 200, 200, "KL8:71,72,73,74,75,76,77,78,79,80\t选10中0×1\t奖金: 2"},
+		{"选10中10计税", "KL8:01,02,03,04,05,06,07,08,09,10", map[int]int{10: 1}, 500000000, 400000000, "KL8:01,02,03,04,05,06,07,08,09,10\t选10中10×1\t奖金: 5000000\t税后: 4000000"},
+		{"选6中2不中奖", "KL8:01,02,75,76,77,78", nil, 0, 0, "KL8:01,02,75,76,77,78\t无\t奖金: 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket, err := Parse(tt.ticket)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := ticket.GetResult(draw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if !maps.Equal(result.Hits, tt.hits) || result.Price != tt.price || result.Net != tt.net || result.FormatResult() != tt.str {
+				t.Errorf("期望: %v %d %d %q, 实际: %v %d %d %q", tt.hits, tt.price, tt.net, tt.str, result.Hits, result.Price, result.Net, result.FormatResult())
+			}
+
+			// 按命中个数统计的结果应该与逐注核对的结果相同
+			price := 0
+
+			for _, single := range ticket.Expand() {
+				singleResult, err := single.GetResult(draw)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				price += singleResult.Price
+			}
+
+			if price != result.Price {
+				t.Errorf("逐注核对的奖金: %d, 实际: %d", price, result.Price)
+			}
+		})
+	}
+}
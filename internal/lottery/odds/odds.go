@@ -0,0 +1,314 @@
+package odds
+
+import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 一等奖单注封顶金额，奖池余额达到 threshold 时为 high，否则为 low
+type jackpotCap struct {
+	threshold int64 // 奖池余额阈值 (元)
+	low       int   // 奖池余额低于阈值时的封顶金额
+	high      int   // 奖池余额达到阈值时的封顶金额
+}
+
+var jackpotCaps = map[string]jackpotCap{
+	"DLT": {800000000, 5000000, 10000000},
+	"SSQ": {100000000, 5000000, 10000000},
+}
+
+// 不支持计算有特别号的彩票
+const ErrSpecialUnsupported lottery.ErrorKind = "special_unsupported"
+
+func init() {
+	lottery.RegisterMessages(lottery.LocaleZhCN, map[string]string{
+		"odds.special_unsupported": "不支持计算有特别号的彩票的中奖概率: %s",
+	})
+
+	lottery.RegisterMessages(lottery.LocaleEn, map[string]string{
+		"odds.special_unsupported": "odds are not supported for games with a special number: %s",
+	})
+}
+
+// 计算参数
+type Options struct {
+	Index    int             // 期号，用于选择奖级规则，为0时使用彩票的期号，仍为0时使用最新的规则
+	Pool     int64           // 奖池余额 (元)，为0时不按奖池调整一等奖
+	Floating map[int]int     // 浮动奖的估计单注奖金，通常为历史平均值，为空时使用奖级规则的参考金额
+	Tax      lottery.TaxRule // 个人所得税规则，用于计算税后期望，为零值时不计税
+}
+
+// 单个中奖等级的概率和期望
+type LevelOdds struct {
+	Level       int     `json:"level"`       // 中奖等级
+	Probability float64 `json:"probability"` // 至少有一注中该等级的概率
+	Expected    float64 `json:"expected"`    // 中该等级的期望注数，包含倍投
+	Price       int     `json:"price"`       // 单注奖金，浮动奖为估计金额
+	Floating    bool    `json:"floating"`    // 是否为浮动奖
+	Value       float64 `json:"value"`       // 期望奖金，期望注数 × 单注奖金
+	NetValue    float64 `json:"netValue"`    // 税后期望奖金
+}
+
+// 彩票的中奖概率和期望收益
+type Report struct {
+	Ticket         string      `json:"ticket"`         // 彩票字符串
+	Rule           string      `json:"rule"`           // 奖级规则名称
+	Bets           int         `json:"bets"`           // 注数，不包含倍投
+	Cost           int         `json:"cost"`           // 投注金额
+	Pool           int64       `json:"pool"`           // 奖池余额 (元)
+	Levels         []LevelOdds `json:"levels"`         // 各等级的概率和期望，按等级从高到低排列
+	WinProbability float64     `json:"winProbability"` // 至少中一注的概率
+	Expected       float64     `json:"expected"`       // 期望奖金
+	ExpectedNet    float64     `json:"expectedNet"`    // 税后期望奖金
+	Return         float64     `json:"return"`         // 期望回报率，期望奖金 / 投注金额
+	NetReturn      float64     `json:"netReturn"`      // 税后期望回报率
+}
+
+// 一个号码区的开奖情况，Counts 为命中个数对应的单式注数
+type zoneOutcome struct {
+	probability float64
+	counts      map[int]int
+}
+
+// combination
+//
+// @Description 计算组合数 C(n, k)，k 超出范围时为0
+//
+// @Param n int 总数
+//
+// @Param k int 选取的个数
+//
+// @Return float64 组合数
+func combination(n, k int) float64 {
+	if k < 0 || n < 0 || k > n {
+		return 0
+	}
+
+	result := 1.0
+
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+
+	return result
+}
+
+// getZoneOutcomes
+//
+// @Description 按开奖号码命中胆码和拖码的个数对一个号码区的开奖情况分类，计算每类的概率和单式票的命中分布
+//
+// @Param total int 号码区的号码个数
+//
+// @Param size int 开奖号码个数，也是单式票的号码个数
+//
+// @Param dan int 胆码个数
+//
+// @Param tuo int 拖码个数
+//
+// @Return []zoneOutcome 开奖情况列表，概率之和为1
+func getZoneOutcomes(total, size, dan, tuo int) []zoneOutcome {
+	var result []zoneOutcome
+
+	all := combination(total, size)
+	pick := size - dan
+
+	for d := 0; d <= min(dan, size); d++ {
+		for t := 0; t <= min(tuo, size-d); t++ {
+			ways := combination(dan, d) * combination(tuo, t) * combination(total-dan-tuo, size-d-t)
+			if ways == 0 {
+				continue
+			}
+
+			counts := make(map[int]int)
+
+			for j := 0; j <= pick; j++ {
+				if count := combination(t, j) * combination(tuo-t, pick-j); count > 0 {
+					counts[d+j] += int(count)
+				}
+			}
+
+			result = append(result, zoneOutcome{ways / all, counts})
+		}
+	}
+
+	return result
+}
+
+// getFloatingPrice
+//
+// @Description 获取浮动奖的估计单注奖金，一等奖按奖池余额封顶
+//
+// @Param lotteryType string 彩票类型
+//
+// @Param level lottery.PrizeLevel 中奖等级
+//
+// @Param options Options 计算参数
+//
+// @Return int 估计单注奖金
+func getFloatingPrice(lotteryType string, level lottery.PrizeLevel, options Options) int {
+	price := level.Price
+
+	if estimate, ok := options.Floating[level.Level]; ok {
+		price = estimate
+	}
+
+	limits, ok := jackpotCaps[lotteryType]
+	if level.Level != 1 || options.Pool <= 0 || !ok {
+		return price
+	}
+
+	limit := limits.low
+	if options.Pool >= limits.threshold {
+		limit = limits.high
+	}
+
+	if _, ok := options.Floating[level.Level]; !ok {
+		return limit
+	}
+
+	return min(price, limit)
+}
+
+// Calculate
+//
+// @Description 按玩法规则的号码区大小精确计算彩票各中奖等级的概率和期望奖金，复式票和胆拖票不需要展开
+//
+// @Param ticket lottery.Lottery 彩票
+//
+// @Param options Options 计算参数
+//
+// @Return Report 概率和期望收益
+//
+// @Return error 错误信息
+func Calculate(ticket lottery.Lottery, options Options) (Report, error) {
+	var report Report
+
+	gameRule, err := lottery.GetGameRule(ticket.Type)
+	if err != nil {
+		return report, err
+	}
+
+	if err := gameRule.Check(ticket.LotteryParts); err != nil {
+		return report, err
+	}
+
+	// 特别号从前区剩余的号码中开出，前区和后区不再独立，暂不支持
+	if gameRule.Special > 0 {
+		return report, lottery.NewError(ErrSpecialUnsupported, "odds.special_unsupported", ticket.Type)
+	}
+
+	index := options.Index
+	if index == 0 {
+		index = ticket.Index
+	}
+
+	prizeRule, err := lottery.GetPrizeRule(ticket.Type, index)
+	if err != nil {
+		return report, err
+	}
+
+	scale := max(ticket.Scale, 1)
+	levelMap := make(map[int]int, len(prizeRule.Levels))
+
+	report.Ticket = ticket.String()
+	report.Rule = prizeRule.Name
+	report.Pool = options.Pool
+
+	for i, level := range prizeRule.Levels {
+		price := level.Price
+		if level.Floating {
+			price = getFloatingPrice(ticket.Type, level, options)
+		}
+
+		levelMap[level.Level] = i
+		report.Levels = append(report.Levels, LevelOdds{Level: level.Level, Price: price, Floating: level.Floating})
+	}
+
+	fronts := getZoneOutcomes(gameRule.FrontMax-gameRule.FrontMin+1, gameRule.FrontSize, len(ticket.FrontDan), len(ticket.FrontTuo))
+	backs := getZoneOutcomes(gameRule.BackMax-gameRule.BackMin+1, gameRule.BackSize, len(ticket.BackDan), len(ticket.BackTuo))
+
+	for _, front := range fronts {
+		for _, back := range backs {
+			probability := front.probability * back.probability
+			counts := make(map[int]int)
+
+			for frontMatched, frontCount := range front.counts {
+				for backMatched, backCount := range back.counts {
+					if level, _ := prizeRule.GetLevel(frontMatched, backMatched); level > 0 {
+						counts[level] += frontCount * backCount
+					}
+				}
+			}
+
+			if len(counts) > 0 {
+				report.WinProbability += probability
+			}
+
+			for level, count := range counts {
+				item := &report.Levels[levelMap[level]]
+				item.Probability += probability
+				item.Expected += probability * float64(count*scale)
+			}
+		}
+	}
+
+	report.Bets = int(combination(len(ticket.FrontTuo), gameRule.FrontSize-len(ticket.FrontDan)) * combination(len(ticket.BackTuo), gameRule.BackSize-len(ticket.BackDan)))
+	report.Cost = report.Bets * scale * lottery.BetPrice
+
+	for i := range report.Levels {
+		item := &report.Levels[i]
+		item.Value = item.Expected * float64(item.Price)
+		item.NetValue = item.Expected * float64(item.Price-options.Tax.GetTax(item.Price))
+
+		report.Expected += item.Value
+		report.ExpectedNet += item.NetValue
+	}
+
+	if report.Cost > 0 {
+		report.Return = report.Expected / float64(report.Cost)
+		report.NetReturn = report.ExpectedNet / float64(report.Cost)
+	}
+
+	return report, nil
+}
+
+// Print
+//
+// @Description 输出各中奖等级的概率和期望奖金
+//
+// @Param w io.Writer 输出
+func (report *Report) Print(w io.Writer) {
+	fmt.Fprintf(w, "彩票: %s，%d注，投注金额: %d\n", report.Ticket, report.Bets, report.Cost)
+	fmt.Fprintf(w, "奖级规则: %s", report.Rule)
+
+	if report.Pool > 0 {
+		fmt.Fprintf(w, "，奖池余额: %d", report.Pool)
+	}
+
+	fmt.Fprintln(w)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "等级\t概率\t约1/N\t期望注数\t单注奖金\t期望奖金\t")
+
+	for _, item := range report.Levels {
+		odds := "-"
+		if item.Probability > 0 {
+			odds = fmt.Sprintf("%.0f", 1/item.Probability)
+		}
+
+		price := fmt.Sprintf("%d", item.Price)
+		if item.Floating {
+			price = "≈" + price
+		}
+
+		fmt.Fprintf(tw, "%s\t%.8f\t%s\t%.6f\t%s\t%.4f\t\n", lottery.GetLevelLabel(item.Level), item.Probability, odds, item.Expected, price, item.Value)
+	}
+
+	tw.Flush()
+
+	fmt.Fprintf(w, "中奖概率: %.6f，期望奖金: %.4f，税后: %.4f\n", report.WinProbability, report.Expected, report.ExpectedNet)
+	fmt.Fprintf(w, "期望回报率: %.2f%%，税后: %.2f%%\n", report.Return*100, report.NetReturn*100)
+}
@@ -0,0 +1,157 @@
+package odds
+
+import (
+	"math"
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// almostEqual 判断两个浮点数的相对误差是否足够小
+func almostEqual(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-9*math.Max(math.Abs(a), math.Abs(b))
+}
+
+func TestGetZoneOutcomes(t *testing.T) {
+	tests := []struct {
+		name  string
+		total int
+		size  int
+		dan   int
+		tuo   int
+	}{
+		{"大乐透前区单式", 35, 5, 0, 5},
+		{"大乐透前区胆拖", 35, 5, 2, 8},
+		{"大乐透后区复式", 12, 2, 0, 4},
+		{"双色球后区复式", 16, 1, 0, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sum := 0.0
+
+			for _, outcome := range getZoneOutcomes(tt.total, tt.size, tt.dan, tt.tuo) {
+				sum += outcome.probability
+			}
+
+			if !almostEqual(sum, 1) {
+				t.Errorf("概率之和应该为1，实际: %v", sum)
+			}
+		})
+	}
+}
+
+func TestCalculate(t *testing.T) {
+	const all = 21425712 // C(35,5) × C(12,2)
+
+	tests := []struct {
+		name  string
+		input string
+		bets  int
+		first float64 // 一等奖概率
+	}{
+		{"单式", "DLT:01,02,03,04,05-01,02", 1, 1.0 / all},
+		{"复式", "DLT:01,02,03,04,05,06,07-01,02,03", 63, 63.0 / all},
+		{"胆拖", "DLT:01,02~03,04,05,06,07,08,09,10-01,02,03,04", 336, 336.0 / all},
+		{"倍投", "DLT:01,02,03,04,05-01,02x3:25053", 1, 1.0 / all},
+	}
+
+	single, err := Calculate(mustGetLottery(t, "DLT:01,02,03,04,05-01,02"), Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket := mustGetLottery(t, tt.input)
+
+			report, err := Calculate(ticket, Options{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if report.Bets != tt.bets || report.Cost != ticket.GetCost() {
+				t.Errorf("注数: %d, 投注金额: %d", report.Bets, report.Cost)
+			}
+
+			if !almostEqual(report.Levels[0].Probability, tt.first) {
+				t.Errorf("一等奖概率期望: %v, 实际: %v", tt.first, report.Levels[0].Probability)
+			}
+
+			// 每注单式票的中奖概率相同，期望注数应该等于注数 × 倍投 × 单式票的概率
+			for i, item := range report.Levels {
+				expected := float64(tt.bets*max(ticket.Scale, 1)) * single.Levels[i].Probability
+
+				if !almostEqual(item.Expected, expected) || item.Probability > item.Expected*(1+1e-9) {
+					t.Errorf("%s 期望注数: %v, 实际: %v, 概率: %v", lottery.GetLevelLabel(item.Level), expected, item.Expected, item.Probability)
+				}
+			}
+
+			if !almostEqual(report.Return, single.Return) {
+				t.Errorf("回报率应该与单式票相同。期望: %v, 实际: %v", single.Return, report.Return)
+			}
+		})
+	}
+}
+
+func TestCalculateJackpot(t *testing.T) {
+	ticket := mustGetLottery(t, "DLT:01,02,03,04,05-01,02")
+
+	tests := []struct {
+		name     string
+		options  Options
+		first    int
+		second   int
+		netFirst float64
+	}{
+		{"参考金额", Options{}, 10000000, 200000, 10000000},
+		{"历史平均", Options{Floating: map[int]int{1: 8000000, 2: 150000}}, 8000000, 150000, 8000000},
+		{"奖池不足封顶", Options{Pool: 500000000, Floating: map[int]int{1: 8000000}}, 5000000, 200000, 5000000},
+		{"奖池充足无历史", Options{Pool: 900000000}, 10000000, 200000, 10000000},
+		{"计税", Options{Tax: lottery.DefaultTaxRule}, 10000000, 200000, 8000000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := Calculate(ticket, tt.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			first, second := report.Levels[0], report.Levels[1]
+
+			if first.Price != tt.first || second.Price != tt.second || !first.Floating || report.Levels[2].Floating {
+				t.Errorf("单注奖金错误: %+v", report.Levels)
+			}
+
+			if !almostEqual(first.NetValue, first.Probability*tt.netFirst) {
+				t.Errorf("税后期望奖金错误: %v", first.NetValue)
+			}
+		})
+	}
+}
+
+func TestCalculateError(t *testing.T) {
+	ticket := mustGetLottery(t, "DLT:01,02,03,04-01,02")
+
+	if _, err := Calculate(ticket, Options{}); lottery.GetErrorKind(err) != lottery.ErrNumberCount {
+		t.Errorf("应该返回号码数量错误: %v", err)
+	}
+
+	special := mustGetLottery(t, "QLC:01,02,03,04,05,06,07")
+
+	if _, err := Calculate(special, Options{}); lottery.GetErrorKind(err) != ErrSpecialUnsupported {
+		t.Errorf("应该返回不支持特别号错误: %v", err)
+	}
+}
+
+func mustGetLottery(t *testing.T, input string) lottery.Lottery {
+	t.Helper()
+
+	lott, err := lottery.GetLottery(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return lott
+}
@@ -0,0 +1,198 @@
+package position
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/dlt"
+)
+
+// 定位型彩票的开奖结果，复式票按展开后的单式票分别计奖和计税
+type Result struct {
+	Ticket       Ticket      // 彩票
+	Draw         Ticket      // 开奖号码
+	Bets         int         // 注数，不包含倍投
+	Hits         []bool      // 每一位是否包含开奖号码
+	FrontMatched int         // 前区包含开奖号码的位数
+	BackMatched  int         // 后区包含开奖号码的位数
+	Level        int         // 最高中奖等级，未中奖时为0
+	Levels       map[int]int // 各等级的中奖注数，包含倍投
+	Price        int         // 税前奖金，浮动奖按奖级规则的参考金额计算
+	Tax          int         // 个人所得税
+	Net          int         // 税后奖金
+}
+
+// ParseDraw
+//
+// @Description 解析开奖号码，开奖号码必须是单式，例如: QXC:1-2-3-4-5-6-14:25100
+//
+// @Param input string 开奖号码字符串
+//
+// @Return Ticket 开奖号码
+//
+// @Return error 错误信息
+func ParseDraw(input string) (Ticket, error) {
+	draw, err := Parse(input)
+	if err != nil {
+		return draw, err
+	}
+
+	if !draw.IsSingle() {
+		return Ticket{}, lottery.NewError(lottery.ErrNotSingle, "position.not_single", input)
+	}
+
+	return draw, nil
+}
+
+// GetDraw
+//
+// @Description 将体彩接口的开奖数据转换为开奖号码，期号写入 Index
+//
+// @Param draw dlt.PoolDraw 开奖数据
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return Ticket 开奖号码
+//
+// @Return error 错误信息
+func GetDraw(draw dlt.PoolDraw, lotteryType string) (Ticket, error) {
+	rule, err := GetGameRule(lotteryType)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	issue, err := draw.GetIssue()
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	fields := strings.Fields(draw.LotteryDrawResult)
+	if len(fields) != rule.GetSize() {
+		return Ticket{}, lottery.NewError(lottery.ErrNumberCount, "position.draw_count", rule.GetSize(), len(fields), draw.LotteryDrawNum)
+	}
+
+	return ParseDraw(fmt.Sprintf("%s:%s:%d", lotteryType, strings.Join(fields, "-"), issue))
+}
+
+// GetHits
+//
+// @Description 按位置比较彩票和开奖号码，获取每一位是否命中以及前区和后区的命中位数
+//
+// @Param draw Ticket 开奖号码，必须是单式
+//
+// @Return []bool 每一位是否包含开奖号码
+//
+// @Return int 前区命中位数
+//
+// @Return int 后区命中位数
+func (ticket *Ticket) GetHits(draw Ticket) ([]bool, int, int) {
+	var frontMatched, backMatched int
+
+	frontSize := gameRules[ticket.Type].Front.Size
+	hits := make([]bool, len(ticket.Positions))
+
+	for i, nums := range ticket.Positions {
+		if i >= len(draw.Positions) || !slices.Contains(nums, draw.Positions[i][0]) {
+			continue
+		}
+
+		hits[i] = true
+
+		if i < frontSize {
+			frontMatched++
+		} else {
+			backMatched++
+		}
+	}
+
+	return hits, frontMatched, backMatched
+}
+
+// GetResult
+//
+// @Description 用开奖号码核对彩票，复式票展开为单式票后逐注核对，优先使用开奖号码的期号选择奖级规则
+//
+// @Param draw Ticket 开奖号码，必须是单式
+//
+// @Return Result 开奖结果
+//
+// @Return error 错误信息
+func (ticket *Ticket) GetResult(draw Ticket) (Result, error) {
+	result := Result{Ticket: *ticket, Draw: draw, Bets: ticket.GetBetCount()}
+
+	if !draw.IsSingle() {
+		return result, lottery.NewError(lottery.ErrNotSingle, "position.not_single", draw.String())
+	}
+
+	if ticket.Type != draw.Type {
+		return result, lottery.NewError(lottery.ErrUnknownType, "position.type_mismatch", ticket.Type, draw.Type)
+	}
+
+	index := draw.Index
+	if index == 0 {
+		index = ticket.Index
+	}
+
+	prizeRule, err := lottery.GetPrizeRule(ticket.Type, index)
+	if err != nil {
+		return result, err
+	}
+
+	result.Hits, result.FrontMatched, result.BackMatched = ticket.GetHits(draw)
+
+	scale := max(ticket.Scale, 1)
+
+	for _, single := range ticket.Expand() {
+		_, frontMatched, backMatched := single.GetHits(draw)
+
+		level, price := prizeRule.GetLevel(frontMatched, backMatched)
+		if level == 0 {
+			continue
+		}
+
+		if result.Levels == nil {
+			result.Levels = make(map[int]int)
+		}
+
+		if result.Level == 0 || level < result.Level {
+			result.Level = level
+		}
+
+		result.Levels[level] += scale
+		result.Price += price * scale
+		result.Tax += lottery.DefaultTaxRule.GetTax(price) * scale
+	}
+
+	result.Net = result.Price - result.Tax
+
+	return result, nil
+}
+
+// FormatResult
+//
+// @Description 格式化开奖结果，例如: QXC:12-3-4-5-6-7-14	五等奖×1 六等奖×1	奖金: 35
+//
+// @Return string 格式化后的字符串
+func (result *Result) FormatResult() string {
+	var labels []string
+
+	for _, level := range slices.Sorted(maps.Keys(result.Levels)) {
+		labels = append(labels, fmt.Sprintf("%s×%d", lottery.GetLevelLabel(level), result.Levels[level]))
+	}
+
+	label := lottery.GetLevelLabel(0)
+	if len(labels) > 0 {
+		label = strings.Join(labels, " ")
+	}
+
+	str := fmt.Sprintf("%s\t%s\t%s: %d", result.Ticket.String(), label, lottery.Translate(lottery.GetLocale(), "label.price"), result.Price)
+
+	if result.Tax > 0 {
+		str += fmt.Sprintf("\t%s: %d", lottery.Translate(lottery.GetLocale(), "label.net"), result.Net)
+	}
+
+	return str
+}
@@ -0,0 +1,399 @@
+package position
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 定位型彩票的号码区，区内每一位单独选号
+type Zone struct {
+	Size int // 位数
+	Min  int // 每一位的最小号码
+	Max  int // 每一位的最大号码
+}
+
+// 定位型彩票的玩法规则，开奖时按位置比较，前区和后区的命中位数对应奖级规则中的 [前区命中个数, 后区命中个数]
+type GameRule struct {
+	Type  string // 彩票类型 (QXC: 七星彩)
+	Name  string // 彩票名称
+	Front Zone   // 前区
+	Back  Zone   // 后区
+}
+
+var gameRules = map[string]GameRule{
+	"QXC": {"QXC", "七星彩", Zone{6, 0, 9}, Zone{1, 0, 14}},
+}
+
+func init() {
+	lottery.RegisterMessages(lottery.LocaleZhCN, map[string]string{
+		"position.syntax":        "彩票解析失败，格式为: 类型:号码[x倍数][:期号]，每一位之间用 - 分隔。输入: %s",
+		"position.number":        "第%d位号码解析失败: %s。输入: %s",
+		"position.positions":     "%s应该有%d位，当前位数: %d",
+		"position.empty":         "第%d位没有号码",
+		"position.range":         "第%d位号码范围为%d~%d，当前号码: %d",
+		"position.duplicate":     "第%d位号码重复: %v",
+		"position.scale":         "倍投倍数解析失败: %s。输入: %s",
+		"position.index":         "期号解析失败: %s。输入: %s",
+		"position.not_single":    "开奖号码必须是单式: %s",
+		"position.type_mismatch": "彩票类型与开奖号码不一致: %s, %s",
+		"position.draw_count":    "开奖号码数量错误，期望: %d, 实际: %d。期号: %s",
+	})
+
+	lottery.RegisterMessages(lottery.LocaleEn, map[string]string{
+		"position.syntax":        "invalid ticket, the format is TYPE:NUMBERS[xSCALE][:ISSUE] with positions separated by '-'. input: %s",
+		"position.number":        "invalid number at position %d: %s. input: %s",
+		"position.positions":     "%s needs %d positions, got %d",
+		"position.empty":         "position %d has no numbers",
+		"position.range":         "position %d must be between %d and %d, got %d",
+		"position.duplicate":     "duplicate numbers at position %d: %v",
+		"position.scale":         "invalid multiplier: %s. input: %s",
+		"position.index":         "invalid issue: %s. input: %s",
+		"position.not_single":    "draw must be a single ticket: %s",
+		"position.type_mismatch": "ticket type does not match the draw: %s, %s",
+		"position.draw_count":    "wrong number of draw numbers, expected %d, got %d. issue: %s",
+	})
+}
+
+// GetGameRule
+//
+// @Description 获取定位型彩票的玩法规则
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return GameRule 玩法规则
+//
+// @Return error 错误信息
+func GetGameRule(lotteryType string) (GameRule, error) {
+	rule, ok := gameRules[lotteryType]
+	if !ok {
+		return rule, lottery.NewError(lottery.ErrUnknownType, "rule.unknown_type", lotteryType)
+	}
+
+	return rule, nil
+}
+
+// IsPositionType
+//
+// @Description 判断彩票类型是否为定位型彩票
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return bool 是否为定位型彩票
+func IsPositionType(lotteryType string) bool {
+	_, ok := gameRules[lotteryType]
+	return ok
+}
+
+// GetSize
+//
+// @Description 获取号码的总位数
+//
+// @Return int 前区和后区的位数之和
+func (rule GameRule) GetSize() int {
+	return rule.Front.Size + rule.Back.Size
+}
+
+// getZone
+//
+// @Description 获取某一位所在的号码区
+//
+// @Param i int 位置，从0开始
+//
+// @Return Zone 号码区
+func (rule GameRule) getZone(i int) Zone {
+	if i < rule.Front.Size {
+		return rule.Front
+	}
+
+	return rule.Back
+}
+
+// 定位型彩票
+type Ticket struct {
+	Type      string  // 彩票类型
+	Positions [][]int // 每一位的号码，前区在前、后区在后，单式票每一位只有一个号码
+	Scale     int     // 倍投倍数
+	Index     int     // 期号
+}
+
+// parsePosition
+//
+// @Description 解析一位的号码，号码之间用逗号分隔，号码不超过9的位置也可以直接连写，例如: 12 或 0,14
+//
+// @Param str string 号码字符串
+//
+// @Param zone Zone 所在的号码区
+//
+// @Param i int 位置，从0开始，用于错误信息
+//
+// @Param input string 完整的彩票字符串，用于错误信息
+//
+// @Return []int 号码列表
+//
+// @Return error 错误信息
+func parsePosition(str string, zone Zone, i int, input string) ([]int, error) {
+	parts := strings.Split(str, ",")
+	if len(parts) == 1 && zone.Max <= 9 && str != "" {
+		parts = strings.Split(str, "")
+	}
+
+	nums := make([]int, 0, len(parts))
+
+	for _, part := range parts {
+		if part == "" {
+			continue
+		}
+
+		num, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, lottery.NewError(lottery.ErrInvalidNumber, "position.number", i+1, part, input)
+		}
+
+		nums = append(nums, num)
+	}
+
+	return nums, nil
+}
+
+// parseExtra
+//
+// @Description 解析号码之后的倍投和期号，例如: x3:25100 或 :25100x3
+//
+// @Param ticket *Ticket 彩票
+//
+// @Param extra string 号码之后的字符串
+//
+// @Param input string 完整的彩票字符串，用于错误信息
+//
+// @Return error 错误信息
+func parseExtra(ticket *Ticket, extra, input string) error {
+	var scaleParsed, indexParsed bool
+
+	for len(extra) > 0 {
+		mark := extra[0]
+		end := strings.IndexAny(extra[1:], "x:") + 1
+		if end == 0 {
+			end = len(extra)
+		}
+
+		value := extra[1:end]
+		extra = extra[end:]
+
+		num, err := strconv.Atoi(value)
+
+		switch {
+		case mark == 'x' && (scaleParsed || err != nil || num < 1):
+			return lottery.NewError(lottery.ErrInvalidScale, "position.scale", value, input)
+		case mark == 'x':
+			ticket.Scale, scaleParsed = num, true
+		case indexParsed || err != nil || num < 1:
+			return lottery.NewError(lottery.ErrInvalidIndex, "position.index", value, input)
+		default:
+			ticket.Index, indexParsed = num, true
+		}
+	}
+
+	return nil
+}
+
+// Parse
+//
+// @Description 解析定位型彩票字符串，格式为: 类型:号码[x倍数][:期号]，每一位之间用 - 分隔，例如:
+//
+//	QXC:1-2-3-4-5-6-14          单式
+//	QXC:12-3-4-5-6-7-0,14x2     复式，每一位可以选多个号码，前区可以连写，后区用逗号分隔
+//	QXC:1-2-3-4-5-6-14:25100    指定期号
+//
+// @Param input string 彩票字符串
+//
+// @Return Ticket 彩票
+//
+// @Return error 错误信息
+func Parse(input string) (Ticket, error) {
+	ticket := Ticket{Scale: 1}
+
+	lotteryType, rest, ok := strings.Cut(input, ":")
+	if !ok || rest == "" {
+		return Ticket{}, lottery.NewError(lottery.ErrSyntax, "position.syntax", input)
+	}
+
+	rule, err := GetGameRule(lotteryType)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	ticket.Type = lotteryType
+
+	body := rest
+	if i := strings.IndexAny(rest, "x:"); i >= 0 {
+		body = rest[:i]
+
+		if err := parseExtra(&ticket, rest[i:], input); err != nil {
+			return Ticket{}, err
+		}
+	}
+
+	for i, part := range strings.Split(body, "-") {
+		nums, err := parsePosition(part, rule.getZone(i), i, input)
+		if err != nil {
+			return Ticket{}, err
+		}
+
+		ticket.Positions = append(ticket.Positions, nums)
+	}
+
+	if err := rule.Check(&ticket); err != nil {
+		return Ticket{}, err
+	}
+
+	return ticket, nil
+}
+
+// Check
+//
+// @Description 按玩法规则检查彩票的位数、号码范围和重复号码，检查通过后将每一位的号码排序
+//
+// @Param ticket *Ticket 彩票
+//
+// @Return error 错误信息
+func (rule GameRule) Check(ticket *Ticket) error {
+	if len(ticket.Positions) != rule.GetSize() {
+		return lottery.NewError(lottery.ErrNumberCount, "position.positions", rule.Name, rule.GetSize(), len(ticket.Positions))
+	}
+
+	for i, nums := range ticket.Positions {
+		zone := rule.getZone(i)
+
+		if len(nums) == 0 {
+			return lottery.NewError(lottery.ErrNumberCount, "position.empty", i+1)
+		}
+
+		for _, num := range nums {
+			if num < zone.Min || num > zone.Max {
+				return lottery.NewError(lottery.ErrNumberRange, "position.range", i+1, zone.Min, zone.Max, num)
+			}
+		}
+
+		if dup := lottery.GetDupNums(nums); len(dup) > 0 {
+			return lottery.NewError(lottery.ErrDuplicateNumber, "position.duplicate", i+1, dup)
+		}
+
+		sort.Ints(nums)
+	}
+
+	return nil
+}
+
+// IsSingle
+//
+// @Description 判断彩票是否为单式
+//
+// @Return bool 是否为单式
+func (ticket *Ticket) IsSingle() bool {
+	for _, nums := range ticket.Positions {
+		if len(nums) != 1 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetBetCount
+//
+// @Description 获取彩票包含的注数，不包含倍投
+//
+// @Return int 注数
+func (ticket *Ticket) GetBetCount() int {
+	count := 1
+
+	for _, nums := range ticket.Positions {
+		count *= len(nums)
+	}
+
+	return count
+}
+
+// GetCost
+//
+// @Description 获取彩票的投注金额，注数 × 倍投倍数 × 单注价格
+//
+// @Return int 投注金额
+func (ticket *Ticket) GetCost() int {
+	return ticket.GetBetCount() * max(ticket.Scale, 1) * lottery.BetPrice
+}
+
+// Expand
+//
+// @Description 将复式票展开为单式票，按每一位号码从小到大的顺序排列
+//
+// @Return []Ticket 单式票列表
+func (ticket *Ticket) Expand() []Ticket {
+	result := []Ticket{{Type: ticket.Type, Scale: ticket.Scale, Index: ticket.Index}}
+
+	for _, nums := range ticket.Positions {
+		next := make([]Ticket, 0, len(result)*len(nums))
+
+		for _, prefix := range result {
+			for _, num := range nums {
+				single := prefix
+				single.Positions = append(append([][]int{}, prefix.Positions...), []int{num})
+				next = append(next, single)
+			}
+		}
+
+		result = next
+	}
+
+	return result
+}
+
+// Format
+//
+// @Description 格式化彩票的号码，例如: 12-3-4-5-6-7-0,14
+//
+// @Return string 格式化后的字符串
+func (ticket *Ticket) Format() string {
+	rule := gameRules[ticket.Type]
+	parts := make([]string, 0, len(ticket.Positions))
+
+	for i, nums := range ticket.Positions {
+		strs := make([]string, 0, len(nums))
+
+		for _, num := range nums {
+			strs = append(strs, strconv.Itoa(num))
+		}
+
+		sep := ","
+		if rule.getZone(i).Max <= 9 {
+			sep = ""
+		}
+
+		parts = append(parts, strings.Join(strs, sep))
+	}
+
+	return strings.Join(parts, "-")
+}
+
+// String
+//
+// @Description 获取彩票的完整字符串，可以被 Parse 重新解析，例如: QXC:12-3-4-5-6-7-0,14x2:25100
+//
+// @Return string 彩票字符串
+func (ticket *Ticket) String() string {
+	str := ticket.Type + ":" + ticket.Format()
+
+	if ticket.Scale > 1 {
+		str += fmt.Sprintf("x%d", ticket.Scale)
+	}
+
+	if ticket.Index > 0 {
+		str += fmt.Sprintf(":%d", ticket.Index)
+	}
+
+	return str
+}
@@ -0,0 +1,133 @@
+package position
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/dlt"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		kind   lottery.ErrorKind
+		str    string
+		bets   int
+		single bool
+	}{
+		{"单式", "QXC:1-2-3-4-5-6-14", "", "QXC:1-2-3-4-5-6-14", 1, true},
+		{"复式", "QXC:21-3-4-5-6-7-14,0x2:25100", "", "QXC:12-3-4-5-6-7-0,14x2:25100", 4, false},
+		{"前区逗号分隔", "QXC:1,2,3-3-4-5-6-7-9", "", "QXC:123-3-4-5-6-7-9", 3, false},
+		{"不支持的类型", "QXB:1-2-3-4-5-6-7", lottery.ErrUnknownType, "", 0, false},
+		{"缺少号码", "QXC", lottery.ErrSyntax, "", 0, false},
+		{"位数错误", "QXC:1-2-3-4-5-6", lottery.ErrNumberCount, "", 0, false},
+		{"空位", "QXC:1-2--4-5-6-7", lottery.ErrNumberCount, "", 0, false},
+		{"错误的字符", "QXC:1-a-3-4-5-6-7", lottery.ErrInvalidNumber, "", 0, false},
+		{"后区超出范围", "QXC:1-2-3-4-5-6-15", lottery.ErrNumberRange, "", 0, false},
+		{"前区不能是两位数", "QXC:10,1-2-3-4-5-6-7", lottery.ErrNumberRange, "", 0, false},
+		{"号码重复", "QXC:11-2-3-4-5-6-7", lottery.ErrDuplicateNumber, "", 0, false},
+		{"倍投错误", "QXC:1-2-3-4-5-6-7x0", lottery.ErrInvalidScale, "", 0, false},
+		{"期号错误", "QXC:1-2-3-4-5-6-7:abc", lottery.ErrInvalidIndex, "", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket, err := Parse(tt.input)
+
+			if tt.kind != "" {
+				if kind := lottery.GetErrorKind(err); kind != tt.kind {
+					t.Errorf("错误类型期望: %s, 实际: %s (%v)", tt.kind, kind, err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if ticket.String() != tt.str || ticket.GetBetCount() != tt.bets || ticket.IsSingle() != tt.single || len(ticket.Expand()) != tt.bets {
+				t.Errorf("期望: %s %d注, 实际: %s %d注", tt.str, tt.bets, ticket.String(), ticket.GetBetCount())
+			}
+		})
+	}
+}
+
+func TestGetResult(t *testing.T) {
+	draw, err := ParseDraw("QXC:1-2-3-4-5-6-14:25100")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		ticket string
+		level  int
+		levels map[int]int
+		price  int
+		net    int
+	}{
+		{"一等奖", "QXC:1-2-3-4-5-6-14", 1, map[int]int{1: 1}, 5000000, 4000000},
+		{"二等奖", "QXC:1-2-3-4-5-6-0", 2, map[int]int{2: 1}, 50000, 40000},
+		{"三等奖", "QXC:1-2-3-4-5-0-14", 3, map[int]int{3: 1}, 3000, 3000},
+		{"四等奖前区五位", "QXC:1-2-3-4-5-0-0", 4, map[int]int{4: 1}, 500, 500},
+		{"四等奖前区四位和后区", "QXC:1-2-3-4-0-0-14", 4, map[int]int{4: 1}, 500, 500},
+		{"五等奖", "QXC:1-2-3-4-0-0-0", 5, map[int]int{5: 1}, 30, 30},
+		{"六等奖只中后区", "QXC:0-0-0-0-0-0-14", 6, map[int]int{6: 1}, 5, 5},
+		{"位置不对不中奖", "QXC:2-3-4-5-6-1-0", 0, nil, 0, 0},
+		{"前区两位不中奖", "QXC:1-2-0-0-0-0-0", 0, nil, 0, 0},
+		{"复式倍投", "QXC:1-2-3-4-0-0-0,14x2", 4, map[int]int{4: 2, 5: 2}, 1060, 1060},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket, err := Parse(tt.ticket)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := ticket.GetResult(draw)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if result.Level != tt.level || !maps.Equal(result.Levels, tt.levels) || result.Price != tt.price || result.Net != tt.net {
+				t.Errorf("期望: %d %v %d %d, 实际: %d %v %d %d", tt.level, tt.levels, tt.price, tt.net, result.Level, result.Levels, result.Price, result.Net)
+			}
+		})
+	}
+
+	complex, _ := Parse("QXC:12-2-3-4-5-6-14")
+
+	if _, err := complex.GetResult(complex); lottery.GetErrorKind(err) != lottery.ErrNotSingle {
+		t.Errorf("开奖号码不是单式时应该返回错误: %v", err)
+	}
+}
+
+func TestGetDraw(t *testing.T) {
+	tests := []struct {
+		name string
+		draw dlt.PoolDraw
+		str  string
+	}{
+		{"七星彩", dlt.PoolDraw{LotteryDrawNum: "25100", LotteryDrawResult: "1 0 9 8 8 7 14"}, "QXC:1-0-9-8-8-7-14:25100"},
+		{"数量错误", dlt.PoolDraw{LotteryDrawNum: "25100", LotteryDrawResult: "1 0 9"}, ""},
+		{"后区超出范围", dlt.PoolDraw{LotteryDrawNum: "25100", LotteryDrawResult: "1 0 9 8 8 7 15"}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			draw, err := GetDraw(tt.draw, "QXC")
+
+			if tt.str == "" {
+				if err == nil {
+					t.Error("应该返回错误")
+				}
+			} else if err != nil || draw.String() != tt.str {
+				t.Errorf("期望: %s, 实际: %s %v", tt.str, draw.String(), err)
+			}
+		})
+	}
+}
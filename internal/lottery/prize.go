@@ -0,0 +1,145 @@
+package lottery
+
+// 中奖等级，Matches 为该等级对应的 [前区命中个数, 后区命中个数] 组合
+type PrizeLevel struct {
+	Level    int      // 中奖等级
+	Matches  [][2]int // 命中组合
+	Price    int      // 单注奖金，浮动奖为参考金额
+	Floating bool     // 是否为浮动奖
+	AddPrice int      // 追加投注的单注奖金，浮动奖为参考金额，为0时该等级没有追加奖金
+}
+
+// 奖级规则，在期号范围 [From, To] 内生效，期号不在任何规则范围内时使用起始期号最接近的旧规则
+type PrizeRule struct {
+	Type   string       // 彩票类型
+	Name   string       // 规则名称
+	From   int          // 生效的起始期号
+	To     int          // 生效的结束期号，为0时一直生效
+	Levels []PrizeLevel // 中奖等级，按等级从高到低排列
+}
+
+// 各彩票的奖级规则，同一类型按生效期号从旧到新排列
+var prizeRules = []PrizeRule{
+	{"DLT", "大乐透八级奖级", 7001, 14051, []PrizeLevel{
+		{1, [][2]int{{5, 2}}, 10000000, true, 5000000},
+		{2, [][2]int{{5, 1}}, 200000, true, 100000},
+		{3, [][2]int{{5, 0}}, 10000, true, 5000},
+		{4, [][2]int{{4, 2}}, 3000, false, 1500},
+		{5, [][2]int{{4, 1}}, 600, false, 300},
+		{6, [][2]int{{4, 0}, {3, 2}}, 100, false, 0},
+		{7, [][2]int{{3, 1}, {2, 2}}, 10, false, 0},
+		{8, [][2]int{{3, 0}, {1, 2}, {2, 1}, {0, 2}}, 5, false, 0},
+	}},
+	{"DLT", "大乐透六级奖级 (2014年调整)", 14052, 19107, []PrizeLevel{
+		{1, [][2]int{{5, 2}}, 10000000, true, 6000000},
+		{2, [][2]int{{5, 1}}, 200000, true, 120000},
+		{3, [][2]int{{5, 0}, {4, 2}}, 10000, true, 6000},
+		{4, [][2]int{{4, 1}, {3, 2}}, 200, false, 100},
+		{5, [][2]int{{4, 0}, {3, 1}, {2, 2}}, 10, false, 5},
+		{6, [][2]int{{3, 0}, {1, 2}, {2, 1}, {0, 2}}, 5, false, 0},
+	}},
+	{"DLT", "大乐透九级奖级", 19108, 0, []PrizeLevel{
+		{1, [][2]int{{5, 2}}, 10000000, true, 8000000},
+		{2, [][2]int{{5, 1}}, 200000, true, 160000},
+		{3, [][2]int{{5, 0}}, 10000, false, 5000},
+		{4, [][2]int{{4, 2}}, 3000, false, 1500},
+		{5, [][2]int{{4, 1}}, 300, false, 150},
+		{6, [][2]int{{3, 2}}, 200, false, 100},
+		{7, [][2]int{{4, 0}}, 100, false, 50},
+		{8, [][2]int{{3, 1}, {2, 2}}, 15, false, 0},
+		{9, [][2]int{{3, 0}, {2, 1}, {1, 2}, {0, 2}}, 5, false, 0},
+	}},
+	{"SSQ", "双色球六级奖级", 0, 0, []PrizeLevel{
+		{1, [][2]int{{6, 1}}, 5000000, true, 0},
+		{2, [][2]int{{6, 0}}, 200000, true, 0},
+		{3, [][2]int{{5, 1}}, 3000, false, 0},
+		{4, [][2]int{{5, 0}, {4, 1}}, 200, false, 0},
+		{5, [][2]int{{4, 0}, {3, 1}}, 10, false, 0},
+		{6, [][2]int{{2, 1}, {1, 1}, {0, 1}}, 5, false, 0},
+	}},
+	// 七乐彩的后区命中个数为前区号码命中特别号的个数
+	{"QLC", "七乐彩七级奖级", 0, 0, []PrizeLevel{
+		{1, [][2]int{{7, 0}}, 5000000, true, 0},
+		{2, [][2]int{{6, 1}}, 20000, true, 0},
+		{3, [][2]int{{6, 0}}, 2000, true, 0},
+		{4, [][2]int{{5, 1}}, 200, false, 0},
+		{5, [][2]int{{5, 0}}, 50, false, 0},
+		{6, [][2]int{{4, 1}}, 10, false, 0},
+		{7, [][2]int{{4, 0}}, 5, false, 0},
+	}},
+	// 七星彩按位置比较，前区为前六位的命中位数，后区为第七位是否命中
+	{"QXC", "七星彩六级奖级 (2020年调整)", 0, 0, []PrizeLevel{
+		{1, [][2]int{{6, 1}}, 5000000, true, 0},
+		{2, [][2]int{{6, 0}}, 50000, true, 0},
+		{3, [][2]int{{5, 1}}, 3000, false, 0},
+		{4, [][2]int{{5, 0}, {4, 1}}, 500, false, 0},
+		{5, [][2]int{{4, 0}, {3, 1}}, 30, false, 0},
+		{6, [][2]int{{3, 0}, {2, 1}, {1, 1}, {0, 1}}, 5, false, 0},
+	}},
+}
+
+// GetPrizeRule
+//
+// @Description 获取期号对应的奖级规则，期号在两个规则之间时使用较旧的规则，早于最早的规则时返回错误
+//
+// @Param lotteryType string 彩票类型
+//
+// @Param index int 期号，为0时使用最新的规则
+//
+// @Return PrizeRule 奖级规则
+//
+// @Return error 错误信息
+func GetPrizeRule(lotteryType string, index int) (PrizeRule, error) {
+	var (
+		result PrizeRule
+		found  bool
+	)
+
+	known := false
+
+	for _, rule := range prizeRules {
+		if rule.Type != lotteryType {
+			continue
+		}
+
+		known = true
+
+		// 规则按生效期号从旧到新排列，最后一个已经生效的规则即为当期规则
+		if index == 0 || rule.From <= index {
+			result, found = rule, true
+		}
+	}
+
+	if !known {
+		return result, NewError(ErrUnknownType, "rule.unknown_type", lotteryType)
+	}
+
+	if !found {
+		return result, NewError(ErrUnknownIssue, "prize.unknown_issue", index, lotteryType)
+	}
+
+	return result, nil
+}
+
+// GetLevel
+//
+// @Description 根据前区和后区的命中个数获取中奖等级和单注奖金
+//
+// @Param frontMatched int 前区命中个数
+//
+// @Param backMatched int 后区命中个数
+//
+// @Return int 中奖等级，未中奖时为0
+//
+// @Return int 单注奖金，未中奖时为0
+func (rule *PrizeRule) GetLevel(frontMatched, backMatched int) (int, int) {
+	for _, level := range rule.Levels {
+		for _, match := range level.Matches {
+			if match[0] == frontMatched && match[1] == backMatched {
+				return level.Level, level.Price
+			}
+		}
+	}
+
+	return 0, 0
+}
@@ -0,0 +1,144 @@
+package lottery
+
+import "testing"
+
+func TestGetPrizeRule(t *testing.T) {
+	tests := []struct {
+		name   string
+		typ    string
+		index  int
+		levels int
+		err    bool
+	}{
+		{"最新规则", "DLT", 0, 9, false},
+		{"八级规则起始期号", "DLT", 7001, 8, false},
+		{"八级规则结束期号", "DLT", 14051, 8, false},
+		{"六级规则起始期号", "DLT", 14052, 6, false},
+		{"六级规则结束期号", "DLT", 19107, 6, false},
+		{"九级规则起始期号", "DLT", 19108, 9, false},
+		{"九级规则", "DLT", 25053, 9, false},
+		{"早于最早的规则", "DLT", 6153, 0, true},
+		{"双色球", "SSQ", 25001, 6, false},
+		{"七乐彩", "QLC", 2025100, 7, false},
+		{"不支持的类型", "ABC", 0, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := GetPrizeRule(tt.typ, tt.index)
+
+			if tt.err {
+				if err == nil {
+					t.Errorf("应该失败")
+				}
+			} else if err != nil {
+				t.Errorf("错误信息: %s", err)
+			} else if len(rule.Levels) != tt.levels {
+				t.Errorf("期望奖级数量: %d, 实际: %d", tt.levels, len(rule.Levels))
+			}
+		})
+	}
+}
+
+func TestGetPrizeRuleGap(t *testing.T) {
+	rules := prizeRules
+	t.Cleanup(func() { prizeRules = rules })
+
+	// 两个规则之间有未收录的期号时使用较旧的规则
+	prizeRules = []PrizeRule{
+		{"DLT", "旧规则", 7001, 10000, rules[0].Levels},
+		{"DLT", "新规则", 12001, 0, rules[1].Levels},
+	}
+
+	for index, name := range map[int]string{10000: "旧规则", 11001: "旧规则", 12001: "新规则", 0: "新规则"} {
+		if rule, err := GetPrizeRule("DLT", index); err != nil || rule.Name != name {
+			t.Errorf("期号 %d 期望: %s, 实际: %s %v", index, name, rule.Name, err)
+		}
+	}
+}
+
+func TestGetLotteryResultByIndex(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		target string
+		level  int
+		price  int
+	}{
+		{"六级规则三等奖", "DLT:01,02,03,04,06-01,02", "DLT:01,02,03,04,05-01,02:19107", 3, 10000},
+		{"九级规则四等奖", "DLT:01,02,03,04,06-01,02", "DLT:01,02,03,04,05-01,02:19108", 4, 3000},
+		{"八级规则四等奖", "DLT:01,02,03,04,06-01,02", "DLT:01,02,03,04,05-01,02:14051", 4, 3000},
+		{"八级规则六等奖", "DLT:01,02,03,06,07-01,02", "DLT:01,02,03,04,05-01,02:07001", 6, 100},
+		{"六级规则四等奖", "DLT:01,02,03,06,07-01,02", "DLT:01,02,03,04,05-01,02:14052", 4, 200},
+		{"九级规则六等奖", "DLT:01,02,03,06,07-01,02", "DLT:01,02,03,04,05-01,02:25053", 6, 200},
+		{"六级规则五等奖", "DLT:01,02,03,04,06-03,04", "DLT:01,02,03,04,05-01,02:18001", 5, 10},
+		{"使用彩票期号", "DLT:01,02,03,04,06-03,04:18001", "DLT:01,02,03,04,05-01,02", 5, 10},
+		{"双色球三等奖", "SSQ:01,02,03,04,05,07-16", "SSQ:01,02,03,04,05,06-16", 3, 3000},
+		{"双色球六等奖", "SSQ:07,08,09,10,11,12-16", "SSQ:01,02,03,04,05,06-16", 6, 5},
+		{"七乐彩一等奖", "QLC:01,02,03,04,05,06,07", "QLC:01,02,03,04,05,06,07-08", 1, 5000000},
+		{"七乐彩二等奖", "QLC:01,02,03,04,05,06,08", "QLC:01,02,03,04,05,06,07-08", 2, 20000},
+		{"七乐彩三等奖", "QLC:01,02,03,04,05,06,09", "QLC:01,02,03,04,05,06,07-08", 3, 2000},
+		{"七乐彩六等奖", "QLC:01,02,03,04,08,10,11:2025100", "QLC:01,02,03,04,05,06,07-08", 6, 10},
+		{"七乐彩只中特别号", "QLC:01,02,03,08,10,11,12", "QLC:01,02,03,04,05,06,07-08", 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, _ := GetLottery(tt.source)
+			target, _ := GetLottery(tt.target)
+
+			result, err := source.GetLotteryResult(target)
+
+			if err != nil {
+				t.Errorf("错误信息: %s", err)
+			} else if result.Level != tt.level || result.Price != tt.price {
+				t.Errorf("期望: %d %d, 实际: %d %d", tt.level, tt.price, result.Level, result.Price)
+			}
+		})
+	}
+
+	source, _ := GetLottery("DLT:01,02,03,04,05-01,02")
+	target, _ := GetLottery("DLT:01,02,03,04,05-01,02:6153")
+
+	if _, err := source.GetLotteryResult(target); err == nil {
+		t.Errorf("未收录的期号应该失败")
+	}
+}
+
+func TestSpecialNumber(t *testing.T) {
+	source, err := GetLottery("QLC:01,02~03,04,05,06,08,09,10x2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	target, err := GetLottery("QLC:01,02,03,04,05,06,07-08:2025100")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if source.String() != "QLC:01,02~03,04,05,06,08,09,10x2" || target.String() != "QLC:01,02,03,04,05,06,07-08:2025100" {
+		t.Errorf("格式化错误: %s, %s", source.String(), target.String())
+	}
+
+	result, err := source.GetLotteryResult(target)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var special []int
+
+	for _, num := range result.Numbers {
+		if num.Special {
+			special = append(special, num.Num)
+
+			if num.Bingo {
+				t.Errorf("特别号不应该标记为命中基本号码: %d", num.Num)
+			}
+		}
+	}
+
+	// 二等奖1注，三等奖2注，四等奖8注，五等奖4注，六等奖6注
+	if len(special) != 1 || special[0] != 8 || result.FrontMatched != 6 || result.BackMatched != 1 || result.Level != 2 || result.Price != 25860*2 {
+		t.Errorf("特别号: %v, 命中: %d+%d, 等级: %d, 奖金: %d", special, result.FrontMatched, result.BackMatched, result.Level, result.Price)
+	}
+}
@@ -0,0 +1,154 @@
+package lottery
+
+// 彩票玩法规则，描述前区和后区的号码范围以及单式票的号码数量
+//
+// 七乐彩等没有后区的彩票 BackMin、BackMax 和 BackSize 都为0，开奖时从前区剩余的号码中开出 Special 个特别号，特别号写在开奖号码的后区
+type GameRule struct {
+	Type      string // 彩票类型 (DLT: 大乐透, SSQ: 双色球, QLC: 七乐彩)
+	Name      string // 彩票名称
+	FrontMin  int    // 前区最小号码
+	FrontMax  int    // 前区最大号码
+	FrontSize int    // 单式票前区号码数量
+	BackMin   int    // 后区最小号码
+	BackMax   int    // 后区最大号码
+	BackSize  int    // 单式票后区号码数量
+	Special   int    // 开奖号码的特别号数量，特别号与彩票的前区号码比较
+}
+
+var gameRules = map[string]GameRule{
+	"DLT": {"DLT", "大乐透", 1, 35, 5, 1, 12, 2, 0},
+	"SSQ": {"SSQ", "双色球", 1, 33, 6, 1, 16, 1, 0},
+	"QLC": {"QLC", "七乐彩", 1, 30, 7, 0, 0, 0, 1},
+}
+
+// GetGameRule
+//
+// @Description 获取彩票类型对应的玩法规则
+//
+// @Param lotteryType string 彩票类型
+//
+// @Return GameRule 玩法规则
+//
+// @Return error 错误信息
+func GetGameRule(lotteryType string) (GameRule, error) {
+	rule, ok := gameRules[lotteryType]
+
+	if !ok {
+		return rule, NewError(ErrUnknownType, "rule.unknown_type", lotteryType)
+	}
+
+	return rule, nil
+}
+
+// Check
+//
+// @Description 检查彩票的前区和后区是否符合玩法规则
+//
+// @Param parts LotteryParts 彩票构成部分
+//
+// @Return error 错误信息
+func (rule GameRule) Check(parts LotteryParts) error {
+	if len(parts.FrontDan) >= rule.FrontSize {
+		return NewError(ErrDanCount, "rule.front_dan_count", rule.FrontSize, len(parts.FrontDan))
+	} else if len(parts.BackDan) > 0 && len(parts.BackDan) >= rule.BackSize {
+		return NewError(ErrDanCount, "rule.back_dan_count", rule.BackSize, len(parts.BackDan))
+	} else if arr := GetDupNums(parts.FrontDan); len(arr) > 0 {
+		return NewError(ErrDuplicateNumber, "rule.front_dan_duplicate", arr)
+	} else if arr := GetDupNums(parts.FrontTuo); len(arr) > 0 {
+		return NewError(ErrDuplicateNumber, "rule.front_tuo_duplicate", arr)
+	} else if arr := GetDupNums(parts.BackTuo); len(arr) > 0 {
+		return NewError(ErrDuplicateNumber, "rule.back_tuo_duplicate", arr)
+	} else if arr := GetCrossNums(parts.FrontDan, parts.FrontTuo); len(arr) > 0 {
+		return NewError(ErrDanTuoConflict, "rule.front_conflict", arr)
+	} else if arr := GetCrossNums(parts.BackDan, parts.BackTuo); len(arr) > 0 {
+		return NewError(ErrDanTuoConflict, "rule.back_conflict", arr)
+	}
+
+	front := append(append([]int{}, parts.FrontDan...), parts.FrontTuo...)
+	back := append(append([]int{}, parts.BackDan...), parts.BackTuo...)
+
+	if len(front) < rule.FrontSize {
+		return NewError(ErrNumberCount, "rule.front_min", rule.FrontSize)
+	}
+
+	if len(back) < rule.BackSize {
+		return NewError(ErrNumberCount, "rule.back_min", rule.BackSize)
+	}
+
+	if rule.BackSize == 0 && len(back) > 0 {
+		return NewError(ErrNumberCount, "rule.back_none", rule.Name)
+	}
+
+	for _, n := range front {
+		if !(rule.FrontMin <= n && n <= rule.FrontMax) {
+			return NewError(ErrNumberRange, "rule.front_range", rule.FrontMin, rule.FrontMax)
+		}
+	}
+
+	for _, n := range back {
+		if !(rule.BackMin <= n && n <= rule.BackMax) {
+			return NewError(ErrNumberRange, "rule.back_range", rule.BackMin, rule.BackMax)
+		}
+	}
+
+	return nil
+}
+
+// CheckSingle
+//
+// @Description 检查单式票（例如开奖号码）是否符合玩法规则，号码数量必须与单式票一致
+//
+// @Param parts LotteryParts 彩票构成部分
+//
+// @Return error 错误信息
+func (rule GameRule) CheckSingle(parts LotteryParts) error {
+	if len(parts.FrontDan) > 0 || len(parts.BackDan) > 0 {
+		return NewError(ErrNotSingle, "rule.single_dan")
+	}
+
+	if len(parts.FrontTuo) != rule.FrontSize {
+		return NewError(ErrNumberCount, "rule.front_size", rule.FrontSize, len(parts.FrontTuo))
+	}
+
+	if len(parts.BackTuo) != rule.BackSize {
+		return NewError(ErrNumberCount, "rule.back_size", rule.BackSize, len(parts.BackTuo))
+	}
+
+	return rule.Check(parts)
+}
+
+// CheckDraw
+//
+// @Description 检查开奖号码是否符合玩法规则，有特别号的彩票后区为特别号，特别号不能与前区号码重复
+//
+// @Param parts LotteryParts 开奖号码的构成部分
+//
+// @Return error 错误信息
+func (rule GameRule) CheckDraw(parts LotteryParts) error {
+	if rule.Special == 0 {
+		return rule.CheckSingle(parts)
+	}
+
+	if len(parts.BackDan) > 0 {
+		return NewError(ErrNotSingle, "rule.single_dan")
+	}
+
+	if len(parts.BackTuo) != rule.Special {
+		return NewError(ErrNumberCount, "rule.special_size", rule.Special, len(parts.BackTuo))
+	}
+
+	for _, n := range parts.BackTuo {
+		if !(rule.FrontMin <= n && n <= rule.FrontMax) {
+			return NewError(ErrNumberRange, "rule.front_range", rule.FrontMin, rule.FrontMax)
+		}
+	}
+
+	if arr := append(GetDupNums(parts.BackTuo), GetCrossNums(parts.FrontTuo, parts.BackTuo)...); len(arr) > 0 {
+		return NewError(ErrDuplicateNumber, "rule.special_conflict", arr)
+	}
+
+	front := parts
+	front.BackTuo = nil
+
+	return rule.CheckSingle(front)
+}
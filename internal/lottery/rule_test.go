@@ -0,0 +1,90 @@
+package lottery
+
+import (
+	"testing"
+)
+
+func TestGameRuleCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		single bool
+		msg    string
+		input  LotteryParts
+	}{
+		{"双色球单式", true, "", LotteryParts{LotteryBaseInfo{"SSQ", 0, 1}, nil, []int{1, 2, 3, 4, 5, 33}, nil, []int{16}}},
+		{"双色球复式", false, "", LotteryParts{LotteryBaseInfo{"SSQ", 0, 1}, nil, []int{1, 2, 3, 4, 5, 6, 7}, nil, []int{1, 16}}},
+		{"双色球胆拖", false, "", LotteryParts{LotteryBaseInfo{"SSQ", 0, 1}, []int{1, 2}, []int{3, 4, 5, 6, 7}, nil, []int{1}}},
+		{"双色球后区胆码", false, "后区胆码数量应该小于1，当前数量: 1", LotteryParts{LotteryBaseInfo{"SSQ", 0, 1}, nil, []int{1, 2, 3, 4, 5, 6}, []int{1}, []int{2}}},
+		{"双色球前区过大", false, "前区数字范围为1~33", LotteryParts{LotteryBaseInfo{"SSQ", 0, 1}, nil, []int{1, 2, 3, 4, 5, 34}, nil, []int{1}}},
+		{"双色球后区过大", false, "后区数字范围为1~16", LotteryParts{LotteryBaseInfo{"SSQ", 0, 1}, nil, []int{1, 2, 3, 4, 5, 6}, nil, []int{17}}},
+		{"双色球前区太少", false, "前区最少需要6个数字", LotteryParts{LotteryBaseInfo{"SSQ", 0, 1}, nil, []int{1, 2, 3, 4, 5}, nil, []int{1}}},
+		{"单式号码过多", true, "前区号码数量应该为6，当前数量: 7", LotteryParts{LotteryBaseInfo{"SSQ", 0, 1}, nil, []int{1, 2, 3, 4, 5, 6, 7}, nil, []int{1}}},
+		{"单式包含胆码", true, "单式票不能包含胆码", LotteryParts{LotteryBaseInfo{"DLT", 0, 1}, []int{1}, []int{2, 3, 4, 5}, nil, []int{1, 2}}},
+		{"大乐透单式", true, "", LotteryParts{LotteryBaseInfo{"DLT", 0, 1}, nil, []int{1, 2, 3, 4, 35}, nil, []int{1, 12}}},
+		{"七乐彩单式", true, "", LotteryParts{LotteryBaseInfo{"QLC", 0, 1}, nil, []int{1, 2, 3, 4, 5, 6, 30}, nil, nil}},
+		{"七乐彩胆拖", false, "", LotteryParts{LotteryBaseInfo{"QLC", 0, 1}, []int{1, 2}, []int{3, 4, 5, 6, 7, 8}, nil, nil}},
+		{"七乐彩没有后区", false, "七乐彩没有后区号码", LotteryParts{LotteryBaseInfo{"QLC", 0, 1}, nil, []int{1, 2, 3, 4, 5, 6, 7}, nil, []int{8}}},
+		{"大乐透后区过多", true, "后区号码数量应该为2，当前数量: 3", LotteryParts{LotteryBaseInfo{"DLT", 0, 1}, nil, []int{1, 2, 3, 4, 35}, nil, []int{1, 2, 12}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := GetGameRule(tt.input.Type)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.single {
+				err = rule.CheckSingle(tt.input)
+			} else {
+				err = rule.Check(tt.input)
+			}
+
+			if err != nil {
+				if len(tt.msg) == 0 {
+					t.Errorf("应该成功，错误信息: %s", err)
+				} else if err.Error() != tt.msg {
+					t.Errorf("错误信息错误，期望: %s, 实际: %s", tt.msg, err)
+				}
+			} else if len(tt.msg) > 0 {
+				t.Errorf("应该失败，输入: %+v", tt.input)
+			}
+		})
+	}
+}
+
+func TestGameRuleCheckDraw(t *testing.T) {
+	tests := []struct {
+		name  string
+		msg   string
+		input LotteryParts
+	}{
+		{"七乐彩", "", LotteryParts{LotteryBaseInfo{"QLC", 0, 1}, nil, []int{1, 2, 3, 4, 5, 6, 7}, nil, []int{8}}},
+		{"七乐彩缺少特别号", "特别号数量应该为1，当前数量: 0", LotteryParts{LotteryBaseInfo{"QLC", 0, 1}, nil, []int{1, 2, 3, 4, 5, 6, 7}, nil, nil}},
+		{"特别号与基本号码重复", "特别号与基本号码重复: [7]", LotteryParts{LotteryBaseInfo{"QLC", 0, 1}, nil, []int{1, 2, 3, 4, 5, 6, 7}, nil, []int{7}}},
+		{"特别号超出范围", "前区数字范围为1~30", LotteryParts{LotteryBaseInfo{"QLC", 0, 1}, nil, []int{1, 2, 3, 4, 5, 6, 7}, nil, []int{31}}},
+		{"基本号码数量错误", "前区号码数量应该为7，当前数量: 6", LotteryParts{LotteryBaseInfo{"QLC", 0, 1}, nil, []int{1, 2, 3, 4, 5, 6}, nil, []int{8}}},
+		{"没有特别号的彩票", "", LotteryParts{LotteryBaseInfo{"SSQ", 0, 1}, nil, []int{1, 2, 3, 4, 5, 6}, nil, []int{16}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := GetGameRule(tt.input.Type)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = rule.CheckDraw(tt.input)
+
+			if err != nil {
+				if len(tt.msg) == 0 {
+					t.Errorf("应该成功，错误信息: %s", err)
+				} else if err.Error() != tt.msg {
+					t.Errorf("错误信息错误，期望: %s, 实际: %s", tt.msg, err)
+				}
+			} else if len(tt.msg) > 0 {
+				t.Errorf("应该失败，输入: %+v", tt.input)
+			}
+		})
+	}
+}
@@ -0,0 +1,214 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 冷热分类
+const (
+	HeatHot  = "hot"  // 热号
+	HeatWarm = "warm" // 温号
+	HeatCold = "cold" // 冷号
+)
+
+// 频率统计参数
+type FrequencyOptions struct {
+	Window    int     // 统计的期数，为0时统计全部期数
+	HotRatio  float64 // 命中次数达到理论次数的倍数时为热号，为0时使用1.2
+	ColdRatio float64 // 命中次数低于理论次数的倍数时为冷号，为0时使用0.8
+}
+
+// 单个号码的统计结果
+type NumStat struct {
+	Num         int     `json:"num"`         // 号码
+	Hits        int     `json:"hits"`        // 命中次数
+	Frequency   float64 `json:"frequency"`   // 命中频率
+	Omission    int     `json:"omission"`    // 当前遗漏，最近一次命中之后的期数
+	MaxOmission int     `json:"maxOmission"` // 最大遗漏，窗口内连续未命中的最大期数
+	AvgGap      float64 `json:"avgGap"`      // 平均遗漏，窗口内未命中期数 / (命中次数 + 1)
+	Heat        string  `json:"heat"`        // 冷热分类
+}
+
+// 号码频率统计报告
+type FrequencyReport struct {
+	Type   string    `json:"type"`   // 彩票类型
+	Window int       `json:"window"` // 统计的期数
+	First  int       `json:"first"`  // 窗口内最早的期号
+	Last   int       `json:"last"`   // 窗口内最新的期号
+	Front  []NumStat `json:"front"`  // 前区统计
+	Back   []NumStat `json:"back"`   // 后区统计，没有后区的彩票为空
+}
+
+// getZoneStats
+//
+// @Description 统计一个号码区内每个号码的命中情况
+//
+// @Param zones [][]int 每期开奖的号码，从旧到新排列
+//
+// @Param minNum int 最小号码
+//
+// @Param maxNum int 最大号码
+//
+// @Param size int 每期开出的号码数量
+//
+// @Param options FrequencyOptions 统计参数
+//
+// @Return []NumStat 统计结果
+func getZoneStats(zones [][]int, minNum, maxNum, size int, options FrequencyOptions) []NumStat {
+	total := len(zones)
+	result := make([]NumStat, 0, maxNum-minNum+1)
+	expected := float64(total*size) / float64(maxNum-minNum+1)
+
+	for num := minNum; num <= maxNum; num++ {
+		stat := NumStat{Num: num}
+		omission := 0
+
+		for _, zone := range zones {
+			hit := false
+
+			for _, n := range zone {
+				if n == num {
+					hit = true
+					break
+				}
+			}
+
+			if hit {
+				stat.Hits++
+				omission = 0
+			} else {
+				omission++
+				stat.MaxOmission = max(stat.MaxOmission, omission)
+			}
+		}
+
+		stat.Omission = omission
+
+		if total > 0 {
+			stat.Frequency = float64(stat.Hits) / float64(total)
+		}
+
+		stat.AvgGap = float64(total-stat.Hits) / float64(stat.Hits+1)
+
+		switch {
+		case float64(stat.Hits) >= expected*options.HotRatio:
+			stat.Heat = HeatHot
+		case float64(stat.Hits) < expected*options.ColdRatio:
+			stat.Heat = HeatCold
+		default:
+			stat.Heat = HeatWarm
+		}
+
+		result = append(result, stat)
+	}
+
+	return result
+}
+
+// GetFrequency
+//
+// @Description 统计历史开奖中每个号码的命中频率、遗漏和冷热
+//
+// @Param draws []lottery.Lottery 开奖号码列表，从新到旧排列，与历史数据的顺序一致
+//
+// @Param options FrequencyOptions 统计参数
+//
+// @Return FrequencyReport 统计报告
+//
+// @Return error 错误信息
+func GetFrequency(draws []lottery.Lottery, options FrequencyOptions) (FrequencyReport, error) {
+	var report FrequencyReport
+
+	if len(draws) == 0 {
+		return report, errors.New("没有开奖数据")
+	}
+
+	rule, err := lottery.GetGameRule(draws[0].Type)
+	if err != nil {
+		return report, err
+	}
+
+	if options.HotRatio == 0 {
+		options.HotRatio = 1.2
+	}
+
+	if options.ColdRatio == 0 {
+		options.ColdRatio = 0.8
+	}
+
+	window := len(draws)
+	if options.Window > 0 {
+		window = min(options.Window, window)
+	}
+
+	fronts := make([][]int, window)
+	backs := make([][]int, window)
+
+	// 窗口内的开奖按从旧到新排列，最后一期为最新一期
+	for i, draw := range draws[:window] {
+		if draw.Type != rule.Type {
+			return report, fmt.Errorf("彩票类型不一致: %s, %s", rule.Type, draw.Type)
+		}
+
+		fronts[window-1-i] = draw.FrontTuo
+		backs[window-1-i] = draw.BackTuo
+	}
+
+	report.Type = rule.Type
+	report.Window = window
+	report.First = draws[window-1].Index
+	report.Last = draws[0].Index
+	report.Front = getZoneStats(fronts, rule.FrontMin, rule.FrontMax, rule.FrontSize, options)
+
+	// 七乐彩等没有后区的彩票，后区为特别号，不统计
+	if rule.BackSize > 0 {
+		report.Back = getZoneStats(backs, rule.BackMin, rule.BackMax, rule.BackSize, options)
+	}
+
+	return report, nil
+}
+
+func getHeatLabel(heat string) string {
+	switch heat {
+	case HeatHot:
+		return "热"
+	case HeatCold:
+		return "冷"
+	default:
+		return "温"
+	}
+}
+
+// Print
+//
+// @Description 以表格形式输出统计报告
+//
+// @Param w io.Writer 输出
+func (report *FrequencyReport) Print(w io.Writer) {
+	fmt.Fprintf(w, "统计期数: %d，期号范围: %d ~ %d\n", report.Window, report.First, report.Last)
+
+	for _, zone := range []struct {
+		name  string
+		stats []NumStat
+	}{{"前区", report.Front}, {"后区", report.Back}} {
+		if len(zone.stats) == 0 {
+			continue
+		}
+
+		fmt.Fprintf(w, "\n%s\n", zone.name)
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+		fmt.Fprintln(tw, "号码\t命中\t频率\t当前遗漏\t最大遗漏\t平均遗漏\t冷热\t")
+
+		for _, stat := range zone.stats {
+			fmt.Fprintf(tw, "%02d\t%d\t%.2f%%\t%d\t%d\t%.2f\t%s\t\n", stat.Num, stat.Hits, stat.Frequency*100, stat.Omission, stat.MaxOmission, stat.AvgGap, getHeatLabel(stat.Heat))
+		}
+
+		tw.Flush()
+	}
+}
@@ -0,0 +1,130 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// getDraws 将开奖号码字符串转换为开奖列表，字符串按从新到旧排列
+func getDraws(t *testing.T, inputs ...string) []lottery.Lottery {
+	var draws []lottery.Lottery
+
+	for _, input := range inputs {
+		draw, err := lottery.GetLottery(input)
+		if err != nil {
+			t.Fatalf("解析失败: %s", err)
+		}
+
+		draws = append(draws, draw)
+	}
+
+	return draws
+}
+
+func TestGetFrequency(t *testing.T) {
+	draws := getDraws(t,
+		"DLT:01,02,03,04,05-01,02:25004",
+		"DLT:01,06,07,08,09-01,03:25003",
+		"DLT:02,06,10,11,12-02,03:25002",
+		"DLT:01,02,13,14,15-04,05:25001",
+	)
+
+	tests := []struct {
+		name    string
+		options FrequencyOptions
+		front   bool
+		stat    NumStat
+	}{
+		{"前区01", FrequencyOptions{}, true, NumStat{1, 3, 0.75, 0, 1, 0.25, HeatHot}},
+		{"前区02", FrequencyOptions{}, true, NumStat{2, 3, 0.75, 0, 1, 0.25, HeatHot}},
+		{"前区06", FrequencyOptions{}, true, NumStat{6, 2, 0.5, 1, 1, 2.0 / 3, HeatHot}},
+		{"前区35", FrequencyOptions{}, true, NumStat{35, 0, 0, 4, 4, 4, HeatCold}},
+		{"后区01", FrequencyOptions{}, false, NumStat{1, 2, 0.5, 0, 2, 2.0 / 3, HeatHot}},
+		{"后区04", FrequencyOptions{}, false, NumStat{4, 1, 0.25, 3, 3, 1.5, HeatHot}},
+		{"后区12", FrequencyOptions{}, false, NumStat{12, 0, 0, 4, 4, 4, HeatCold}},
+		{"前区06，最近2期", FrequencyOptions{Window: 2}, true, NumStat{6, 1, 0.5, 1, 1, 0.5, HeatHot}},
+		{"前区15，最近2期", FrequencyOptions{Window: 2}, true, NumStat{15, 0, 0, 2, 2, 2, HeatCold}},
+		{"前区03，热号倍数3", FrequencyOptions{HotRatio: 3}, true, NumStat{3, 1, 0.25, 0, 3, 1.5, HeatWarm}},
+		{"后区03，最近3期", FrequencyOptions{Window: 3}, false, NumStat{3, 2, 2.0 / 3, 1, 1, 1.0 / 3, HeatHot}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report, err := GetFrequency(draws, tt.options)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			stats := report.Back
+			if tt.front {
+				stats = report.Front
+			}
+
+			if stat := stats[tt.stat.Num-1]; stat != tt.stat {
+				t.Errorf("期望: %+v, 实际: %+v", tt.stat, stat)
+			}
+		})
+	}
+}
+
+func TestGetFrequencyReport(t *testing.T) {
+	draws := getDraws(t,
+		"SSQ:01,02,03,04,05,06-16:2025003",
+		"SSQ:07,08,09,10,11,12-01:2025002",
+		"SSQ:13,14,15,16,17,18-02:2025001",
+	)
+
+	report, err := GetFrequency(draws, FrequencyOptions{Window: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if report.Window != 3 || report.First != 2025001 || report.Last != 2025003 || len(report.Front) != 33 || len(report.Back) != 16 {
+		t.Errorf("统计报告错误: %+v", report)
+	}
+
+	if _, err := GetFrequency(nil, FrequencyOptions{}); err == nil {
+		t.Errorf("没有开奖数据时应该失败")
+	}
+
+	if _, err := GetFrequency(append(draws, getDraws(t, "DLT:01,02,03,04,05-01,02")...), FrequencyOptions{}); err == nil {
+		t.Errorf("彩票类型不一致时应该失败")
+	}
+}
+
+func TestGetFrequencySpecial(t *testing.T) {
+	draws := getDraws(t,
+		"QLC:01,02,03,04,05,06,07-08:2025003",
+		"QLC:01,09,10,11,12,13,14-15:2025002",
+		"QLC:16,17,18,19,20,21,22-01:2025001",
+	)
+
+	report, err := GetFrequency(draws, FrequencyOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 特别号不计入前区命中，也没有后区统计
+	if len(report.Front) != 30 || len(report.Back) != 0 {
+		t.Fatalf("统计报告错误: %+v", report)
+	}
+
+	if stat := report.Front[0]; stat.Hits != 2 || stat.Heat != HeatHot {
+		t.Errorf("前区01错误: %+v", stat)
+	}
+
+	if stat := report.Front[7]; stat.Hits != 0 || stat.Heat != HeatCold {
+		t.Errorf("前区08错误: %+v", stat)
+	}
+
+	var buf bytes.Buffer
+
+	report.Print(&buf)
+
+	if strings.Contains(buf.String(), "后区") {
+		t.Errorf("没有后区的彩票不应该输出后区统计: %s", buf.String())
+	}
+}
@@ -0,0 +1,373 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 单式票前区的形态指标
+type Pattern struct {
+	Index       int    `json:"index"`       // 期号
+	Sum         int    `json:"sum"`         // 和值
+	Span        int    `json:"span"`        // 跨度，最大号码 - 最小号码
+	Odd         int    `json:"odd"`         // 奇数个数
+	Even        int    `json:"even"`        // 偶数个数
+	Big         int    `json:"big"`         // 大号个数
+	Small       int    `json:"small"`       // 小号个数
+	Zones       [3]int `json:"zones"`       // 三区分布，大乐透为 1~12/13~24/25~35
+	Consecutive int    `json:"consecutive"` // 连号对数，例如 01,02,03 为2对
+	MaxRun      int    `json:"maxRun"`      // 最长连号个数，无连号时为1
+	Repeat      int    `json:"repeat"`      // 与上一期重复的号码个数 (重号)
+	AC          int    `json:"ac"`          // AC值，号码两两差值的种类数 - (号码个数 - 1)
+}
+
+// 分布中的一项
+type Bucket struct {
+	Label string  `json:"label"` // 取值
+	Count int     `json:"count"` // 出现次数
+	Ratio float64 `json:"ratio"` // 出现比例
+}
+
+// 形态分布报告
+type PatternReport struct {
+	Type        string    `json:"type"`        // 彩票类型
+	Window      int       `json:"window"`      // 统计的期数
+	Patterns    []Pattern `json:"patterns"`    // 每期的形态，从新到旧排列
+	Sum         []Bucket  `json:"sum"`         // 和值分布
+	Span        []Bucket  `json:"span"`        // 跨度分布
+	OddEven     []Bucket  `json:"oddEven"`     // 奇偶比分布
+	BigSmall    []Bucket  `json:"bigSmall"`    // 大小比分布
+	Zones       []Bucket  `json:"zones"`       // 三区比分布
+	Consecutive []Bucket  `json:"consecutive"` // 连号对数分布
+	Repeat      []Bucket  `json:"repeat"`      // 重号个数分布
+	AC          []Bucket  `json:"ac"`          // AC值分布
+}
+
+// 取值范围，包含 Min 和 Max
+type Range struct {
+	Min int `json:"min"`
+	Max int `json:"max"`
+}
+
+// 形态过滤条件，为空的条件不做限制
+type PatternFilter struct {
+	Sum         *Range `json:"sum,omitempty"`         // 和值
+	Span        *Range `json:"span,omitempty"`        // 跨度
+	Odd         *Range `json:"odd,omitempty"`         // 奇数个数
+	Big         *Range `json:"big,omitempty"`         // 大号个数
+	Consecutive *Range `json:"consecutive,omitempty"` // 连号对数
+	MaxRun      *Range `json:"maxRun,omitempty"`      // 最长连号个数
+	Repeat      *Range `json:"repeat,omitempty"`      // 与上一期重复的号码个数
+	AC          *Range `json:"ac,omitempty"`          // AC值
+}
+
+// GetPattern
+//
+// @Description 计算单式票前区的形态指标
+//
+// @Param lott lottery.Lottery 单式票
+//
+// @Param prev *lottery.Lottery 上一期开奖号码，用于计算重号，为空时重号为0
+//
+// @Return Pattern 形态指标
+//
+// @Return error 错误信息
+func GetPattern(lott lottery.Lottery, prev *lottery.Lottery) (Pattern, error) {
+	var pattern Pattern
+
+	if !lott.IsSingleLottery() {
+		return pattern, errors.New("只能计算单式票的形态")
+	}
+
+	rule, err := lottery.GetGameRule(lott.Type)
+	if err != nil {
+		return pattern, err
+	}
+
+	nums := append([]int{}, lott.FrontDan...)
+	nums = append(nums, lott.FrontTuo...)
+	sort.Ints(nums)
+
+	if len(nums) == 0 {
+		return pattern, errors.New("前区号码为空")
+	}
+
+	zoneSize := (rule.FrontMax - rule.FrontMin + 3) / 3
+	bigMin := (rule.FrontMin + rule.FrontMax + 1) / 2
+
+	pattern.Index = lott.Index
+	pattern.Span = nums[len(nums)-1] - nums[0]
+	pattern.MaxRun = 1
+	run := 1
+
+	for i, num := range nums {
+		pattern.Sum += num
+
+		if num%2 == 1 {
+			pattern.Odd++
+		} else {
+			pattern.Even++
+		}
+
+		if num >= bigMin {
+			pattern.Big++
+		} else {
+			pattern.Small++
+		}
+
+		pattern.Zones[min((num-rule.FrontMin)/zoneSize, 2)]++
+
+		if i > 0 && num-nums[i-1] == 1 {
+			pattern.Consecutive++
+			run++
+			pattern.MaxRun = max(pattern.MaxRun, run)
+		} else {
+			run = 1
+		}
+	}
+
+	diffMap := make(map[int]bool)
+
+	for i := 0; i < len(nums); i++ {
+		for j := i + 1; j < len(nums); j++ {
+			diffMap[nums[j]-nums[i]] = true
+		}
+	}
+
+	pattern.AC = len(diffMap) - (len(nums) - 1)
+
+	if prev != nil {
+		prevNums := append(append([]int{}, prev.FrontDan...), prev.FrontTuo...)
+		pattern.Repeat = len(lottery.GetCrossNums(nums, prevNums))
+	}
+
+	return pattern, nil
+}
+
+// OddEven
+//
+// @Description 获取奇偶比，例如 3:2
+//
+// @Return string 奇偶比
+func (pattern *Pattern) OddEven() string {
+	return fmt.Sprintf("%d:%d", pattern.Odd, pattern.Even)
+}
+
+// BigSmall
+//
+// @Description 获取大小比，例如 3:2
+//
+// @Return string 大小比
+func (pattern *Pattern) BigSmall() string {
+	return fmt.Sprintf("%d:%d", pattern.Big, pattern.Small)
+}
+
+// ZoneRatio
+//
+// @Description 获取三区比，例如 2:2:1
+//
+// @Return string 三区比
+func (pattern *Pattern) ZoneRatio() string {
+	return fmt.Sprintf("%d:%d:%d", pattern.Zones[0], pattern.Zones[1], pattern.Zones[2])
+}
+
+// Contains
+//
+// @Description 判断取值是否在范围内
+//
+// @Param value int 取值
+//
+// @Return bool 是否在范围内
+func (r *Range) Contains(value int) bool {
+	return r == nil || (r.Min <= value && value <= r.Max)
+}
+
+// Check
+//
+// @Description 检查形态是否满足全部过滤条件
+//
+// @Param pattern Pattern 形态指标
+//
+// @Return string 第一个不满足的条件名称，全部满足时为空
+func (filter *PatternFilter) Check(pattern Pattern) string {
+	conditions := []struct {
+		name  string
+		r     *Range
+		value int
+	}{
+		{"sum", filter.Sum, pattern.Sum},
+		{"span", filter.Span, pattern.Span},
+		{"odd", filter.Odd, pattern.Odd},
+		{"big", filter.Big, pattern.Big},
+		{"consecutive", filter.Consecutive, pattern.Consecutive},
+		{"maxRun", filter.MaxRun, pattern.MaxRun},
+		{"repeat", filter.Repeat, pattern.Repeat},
+		{"ac", filter.AC, pattern.AC},
+	}
+
+	for _, condition := range conditions {
+		if !condition.r.Contains(condition.value) {
+			return condition.name
+		}
+	}
+
+	return ""
+}
+
+// Match
+//
+// @Description 判断形态是否满足全部过滤条件
+//
+// @Param pattern Pattern 形态指标
+//
+// @Return bool 是否满足
+func (filter *PatternFilter) Match(pattern Pattern) bool {
+	return filter.Check(pattern) == ""
+}
+
+// getBuckets
+//
+// @Description 统计取值的分布，数字取值按数值排序，其余按字符串排序
+//
+// @Param labels []string 取值列表
+//
+// @Return []Bucket 分布
+func getBuckets(labels []string) []Bucket {
+	var result []Bucket
+
+	countMap := make(map[string]int)
+
+	for _, label := range labels {
+		if countMap[label] == 0 {
+			result = append(result, Bucket{Label: label})
+		}
+
+		countMap[label]++
+	}
+
+	for i := range result {
+		result[i].Count = countMap[result[i].Label]
+		result[i].Ratio = float64(result[i].Count) / float64(len(labels))
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		a, aErr := strconv.Atoi(result[i].Label)
+		b, bErr := strconv.Atoi(result[j].Label)
+
+		if aErr == nil && bErr == nil {
+			return a < b
+		}
+
+		return result[i].Label < result[j].Label
+	})
+
+	return result
+}
+
+// GetPatternReport
+//
+// @Description 计算每期开奖的形态以及各指标的分布
+//
+// @Param draws []lottery.Lottery 开奖号码列表，从新到旧排列
+//
+// @Param window int 统计的期数，为0时统计全部期数
+//
+// @Return PatternReport 形态分布报告
+//
+// @Return error 错误信息
+func GetPatternReport(draws []lottery.Lottery, window int) (PatternReport, error) {
+	var report PatternReport
+
+	if len(draws) == 0 {
+		return report, errors.New("没有开奖数据")
+	}
+
+	if window <= 0 || window > len(draws) {
+		window = len(draws)
+	}
+
+	labels := make(map[string][]string)
+
+	for i, draw := range draws[:window] {
+		var prev *lottery.Lottery
+
+		if i+1 < len(draws) {
+			prev = &draws[i+1]
+		}
+
+		pattern, err := GetPattern(draw, prev)
+		if err != nil {
+			return report, fmt.Errorf("期号 %d: %w", draw.Index, err)
+		}
+
+		report.Patterns = append(report.Patterns, pattern)
+		labels["sum"] = append(labels["sum"], strconv.Itoa(pattern.Sum))
+		labels["span"] = append(labels["span"], strconv.Itoa(pattern.Span))
+		labels["oddEven"] = append(labels["oddEven"], pattern.OddEven())
+		labels["bigSmall"] = append(labels["bigSmall"], pattern.BigSmall())
+		labels["zones"] = append(labels["zones"], pattern.ZoneRatio())
+		labels["consecutive"] = append(labels["consecutive"], strconv.Itoa(pattern.Consecutive))
+		labels["repeat"] = append(labels["repeat"], strconv.Itoa(pattern.Repeat))
+		labels["ac"] = append(labels["ac"], strconv.Itoa(pattern.AC))
+	}
+
+	report.Type = draws[0].Type
+	report.Window = window
+	report.Sum = getBuckets(labels["sum"])
+	report.Span = getBuckets(labels["span"])
+	report.OddEven = getBuckets(labels["oddEven"])
+	report.BigSmall = getBuckets(labels["bigSmall"])
+	report.Zones = getBuckets(labels["zones"])
+	report.Consecutive = getBuckets(labels["consecutive"])
+	report.Repeat = getBuckets(labels["repeat"])
+	report.AC = getBuckets(labels["ac"])
+
+	return report, nil
+}
+
+// Print
+//
+// @Description 以表格形式输出每期的形态以及各指标的分布
+//
+// @Param w io.Writer 输出
+func (report *PatternReport) Print(w io.Writer) {
+	fmt.Fprintf(w, "统计期数: %d\n\n", report.Window)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "期号\t和值\t跨度\t奇偶\t大小\t三区\t连号\t重号\tAC\t")
+
+	for _, p := range report.Patterns {
+		fmt.Fprintf(tw, "%d\t%d\t%d\t%s\t%s\t%s\t%d\t%d\t%d\t\n", p.Index, p.Sum, p.Span, p.OddEven(), p.BigSmall(), p.ZoneRatio(), p.Consecutive, p.Repeat, p.AC)
+	}
+
+	tw.Flush()
+
+	for _, dist := range []struct {
+		name    string
+		buckets []Bucket
+	}{
+		{"和值", report.Sum},
+		{"跨度", report.Span},
+		{"奇偶比", report.OddEven},
+		{"大小比", report.BigSmall},
+		{"三区比", report.Zones},
+		{"连号对数", report.Consecutive},
+		{"重号个数", report.Repeat},
+		{"AC值", report.AC},
+	} {
+		var items []string
+
+		for _, bucket := range dist.buckets {
+			items = append(items, fmt.Sprintf("%s×%d(%.1f%%)", bucket.Label, bucket.Count, bucket.Ratio*100))
+		}
+
+		fmt.Fprintf(w, "\n%s: %s\n", dist.name, strings.Join(items, ", "))
+	}
+}
@@ -0,0 +1,104 @@
+package stats
+
+import (
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+func TestGetPattern(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		prev   string
+		result Pattern
+	}{
+		{"大乐透", "DLT:02,04,11,29,30-02,08:25053", "", Pattern{25053, 76, 28, 2, 3, 2, 3, [3]int{3, 0, 2}, 1, 2, 0, 6}},
+		{"大乐透连号", "DLT:01,02,03,17,18-01,02", "", Pattern{0, 41, 17, 3, 2, 1, 4, [3]int{3, 2, 0}, 3, 3, 0, 2}},
+		{"大乐透重号", "DLT:12,13,24,25,35-01,02", "DLT:12,24,33,34,35-03,04", Pattern{0, 109, 23, 3, 2, 3, 2, [3]int{1, 2, 2}, 2, 2, 3, 3}},
+		{"双色球", "SSQ:01,05,12,22,31,33-16", "", Pattern{0, 104, 32, 4, 2, 3, 3, [3]int{2, 2, 2}, 0, 1, 0, 8}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lott, _ := lottery.GetLottery(tt.input)
+
+			var prev *lottery.Lottery
+
+			if tt.prev != "" {
+				prevLott, _ := lottery.GetLottery(tt.prev)
+				prev = &prevLott
+			}
+
+			pattern, err := GetPattern(lott, prev)
+
+			if err != nil {
+				t.Errorf("错误信息: %s", err)
+			} else if pattern != tt.result {
+				t.Errorf("期望: %+v, 实际: %+v", tt.result, pattern)
+			}
+		})
+	}
+
+	complexLott, _ := lottery.GetLottery("DLT:01,02,03,04,05,06-01,02")
+
+	if _, err := GetPattern(complexLott, nil); err == nil {
+		t.Errorf("复式票应该失败")
+	}
+}
+
+func TestPatternFilter(t *testing.T) {
+	pattern := Pattern{Sum: 76, Span: 28, Odd: 2, Even: 3, Big: 2, Small: 3, Consecutive: 1, MaxRun: 2, AC: 7}
+
+	tests := []struct {
+		name   string
+		filter PatternFilter
+		result string
+	}{
+		{"无条件", PatternFilter{}, ""},
+		{"全部满足", PatternFilter{Sum: &Range{60, 100}, Span: &Range{20, 30}, Odd: &Range{2, 3}, Big: &Range{2, 2}, MaxRun: &Range{1, 2}, AC: &Range{6, 10}}, ""},
+		{"和值不满足", PatternFilter{Sum: &Range{80, 100}, Span: &Range{0, 10}}, "sum"},
+		{"跨度不满足", PatternFilter{Sum: &Range{60, 100}, Span: &Range{0, 10}}, "span"},
+		{"奇数不满足", PatternFilter{Odd: &Range{0, 0}}, "odd"},
+		{"连号不满足", PatternFilter{Consecutive: &Range{0, 0}}, "consecutive"},
+		{"最长连号不满足", PatternFilter{MaxRun: &Range{3, 5}}, "maxRun"},
+		{"AC值不满足", PatternFilter{AC: &Range{8, 10}}, "ac"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.filter.Check(pattern); result != tt.result || tt.filter.Match(pattern) != (tt.result == "") {
+				t.Errorf("期望: %q, 实际: %q", tt.result, result)
+			}
+		})
+	}
+}
+
+func TestGetPatternReport(t *testing.T) {
+	draws := getDraws(t,
+		"DLT:01,02,03,04,05-01,02:25004",
+		"DLT:01,06,07,08,09-01,03:25003",
+		"DLT:02,06,10,11,12-02,03:25002",
+		"DLT:01,02,13,14,15-04,05:25001",
+	)
+
+	report, err := GetPatternReport(draws, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(report.Patterns) != 3 || report.Patterns[2].Index != 25002 {
+		t.Fatalf("形态列表错误: %+v", report.Patterns)
+	}
+
+	// 25002 与 25001 重复 02，25003 与 25002 重复 06，25004 与 25003 重复 01
+	expected := []Bucket{{"1", 3, 1}}
+	if len(report.Repeat) != 1 || report.Repeat[0] != expected[0] {
+		t.Errorf("重号分布期望: %v, 实际: %v", expected, report.Repeat)
+	}
+
+	// 和值: 15, 31, 41
+	if len(report.Sum) != 3 || report.Sum[0].Label != "15" || report.Sum[2].Label != "41" {
+		t.Errorf("和值分布错误: %v", report.Sum)
+	}
+}
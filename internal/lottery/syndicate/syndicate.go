@@ -0,0 +1,459 @@
+package syndicate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 余数处理方式，按最小分配单位分配后剩余的金额
+const (
+	LeftoverCarry   = "carry"   // 结转到下一期的奖金中
+	LeftoverLargest = "largest" // 按最大余数法逐个单位分给余数最大的成员，不足一个单位的部分结转
+	LeftoverFirst   = "first"   // 分给第一个成员 (发起人)，不足一个单位的部分结转
+)
+
+// 合买成员
+type Member struct {
+	Name   string `json:"name"`   // 成员名称
+	Shares int    `json:"shares"` // 默认份额
+}
+
+// 一期合买
+type Round struct {
+	Issue   int            `json:"issue"`            // 期号
+	Tickets []string       `json:"tickets"`          // 彩票字符串
+	Shares  map[string]int `json:"shares,omitempty"` // 本期份额，为空时使用成员的默认份额，份额为0的成员不参与本期
+}
+
+// 合买方案，金额单位均为分
+type Syndicate struct {
+	Name     string                                 `json:"name"`     // 合买名称
+	Members  []Member                               `json:"members"`  // 成员列表
+	Unit     int                                    `json:"unit"`     // 奖金分配的最小单位 (分)，为0时为1分
+	Leftover string                                 `json:"leftover"` // 余数处理方式，为空时为 carry
+	Rounds   []Round                                `json:"rounds"`   // 每期合买
+	Tax      func(result lottery.LotteryResult) int `json:"-"`        // 税额计算 (元)，为空时使用开奖结果中的税额
+}
+
+// 成员单期结算
+type MemberLine struct {
+	Name         string `json:"name"`         // 成员名称
+	Shares       int    `json:"shares"`       // 本期份额
+	Contribution int    `json:"contribution"` // 本期出资 (分)
+	Winnings     int    `json:"winnings"`     // 本期分得奖金 (分)
+	Balance      int    `json:"balance"`      // 截至本期的累计盈亏 (分)
+}
+
+// 单期结算
+type Settlement struct {
+	Issue    int          `json:"issue"`    // 期号
+	Cost     int          `json:"cost"`     // 投注金额 (分)
+	Gross    int          `json:"gross"`    // 税前奖金 (分)
+	Tax      int          `json:"tax"`      // 税额 (分)
+	Net      int          `json:"net"`      // 税后奖金 (分)
+	CarryIn  int          `json:"carryIn"`  // 上期结转 (分)
+	CarryOut int          `json:"carryOut"` // 结转到下期 (分)
+	Members  []MemberLine `json:"members"`  // 成员结算
+}
+
+// 合买结算单
+type Statement struct {
+	Name        string       `json:"name"`              // 合买名称
+	Settlements []Settlement `json:"settlements"`       // 每期结算，按期号从旧到新排列
+	Pending     []Settlement `json:"pending,omitempty"` // 尚未开奖的合买，只有投注金额和成员出资，按期号从旧到新排列
+	Leftover    int          `json:"leftover"`          // 最后一期结算后尚未分配的金额 (分)
+}
+
+// LoadSyndicate
+//
+// @Description 读取合买方案文件
+//
+// @Param path string 文件路径
+//
+// @Return Syndicate 合买方案
+//
+// @Return error 错误信息
+func LoadSyndicate(path string) (Syndicate, error) {
+	var s Syndicate
+
+	jsonData, err := os.ReadFile(path)
+	if err != nil {
+		return s, fmt.Errorf("文件读取失败: %w", err)
+	}
+
+	if err := json.Unmarshal(jsonData, &s); err != nil {
+		return s, fmt.Errorf("json解析失败: %w", err)
+	}
+
+	return s, nil
+}
+
+// split
+//
+// @Description 按份额拆分金额，每人分得的金额为最小单位的整数倍
+//
+// @Param amount int 金额 (分)
+//
+// @Param shares []int 份额列表
+//
+// @Param unit int 最小单位 (分)
+//
+// @Param mode string 余数处理方式
+//
+// @Return []int 每人分得的金额 (分)
+//
+// @Return int 未分配的金额 (分)
+func split(amount int, shares []int, unit int, mode string) ([]int, int) {
+	total := 0
+	for _, share := range shares {
+		total += share
+	}
+
+	result := make([]int, len(shares))
+	if total == 0 || amount <= 0 {
+		return result, amount
+	}
+
+	leftover := amount
+	remainders := make([]int, len(shares))
+
+	for i, share := range shares {
+		exact := amount * share
+		result[i] = exact / total / unit * unit
+		remainders[i] = exact - result[i]*total
+		leftover -= result[i]
+	}
+
+	switch mode {
+	case LeftoverLargest:
+		order := make([]int, len(shares))
+		for i := range order {
+			order[i] = i
+		}
+
+		sort.SliceStable(order, func(a, b int) bool {
+			return remainders[order[a]] > remainders[order[b]]
+		})
+
+		for _, i := range order {
+			if leftover < unit {
+				break
+			}
+
+			if shares[i] > 0 {
+				result[i] += unit
+				leftover -= unit
+			}
+		}
+	case LeftoverFirst:
+		for i, share := range shares {
+			if share > 0 {
+				extra := leftover / unit * unit
+				result[i] += extra
+				leftover -= extra
+
+				break
+			}
+		}
+	}
+
+	return result, leftover
+}
+
+// getShares
+//
+// @Description 获取成员在一期合买中的份额
+//
+// @Param round Round 一期合买
+//
+// @Return []int 份额列表，与成员列表顺序一致
+//
+// @Return error 错误信息
+func (s *Syndicate) getShares(round Round) ([]int, error) {
+	shares := make([]int, len(s.Members))
+	total := 0
+
+	for i, member := range s.Members {
+		shares[i] = member.Shares
+
+		if round.Shares != nil {
+			shares[i] = round.Shares[member.Name]
+		}
+
+		if shares[i] < 0 {
+			return nil, fmt.Errorf("成员 %s 的份额不能为负数", member.Name)
+		}
+
+		total += shares[i]
+	}
+
+	for name := range round.Shares {
+		if !s.hasMember(name) {
+			return nil, fmt.Errorf("第%d期份额中的成员不存在: %s", round.Issue, name)
+		}
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("第%d期没有成员出资", round.Issue)
+	}
+
+	return shares, nil
+}
+
+func (s *Syndicate) hasMember(name string) bool {
+	for _, member := range s.Members {
+		if member.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Settle
+//
+// @Description 按期号从旧到新核对每期彩票，按份额分摊投注金额并分配税后奖金。期号晚于最新开奖的合买列为待开奖，不参与结算
+//
+// @Param draws []lottery.Lottery 开奖号码列表，顺序不限
+//
+// @Return Statement 合买结算单
+//
+// @Return error 错误信息
+func (s *Syndicate) Settle(draws []lottery.Lottery) (Statement, error) {
+	statement := Statement{Name: s.Name}
+
+	if len(s.Members) == 0 {
+		return statement, errors.New("合买没有成员")
+	}
+
+	unit := max(s.Unit, 1)
+
+	mode := s.Leftover
+	if mode == "" {
+		mode = LeftoverCarry
+	}
+
+	if mode != LeftoverCarry && mode != LeftoverLargest && mode != LeftoverFirst {
+		return statement, fmt.Errorf("不支持的余数处理方式: %s", mode)
+	}
+
+	latest := 0
+	drawMap := make(map[int]lottery.Lottery, len(draws))
+	for _, draw := range draws {
+		drawMap[draw.Index] = draw
+		latest = max(latest, draw.Index)
+	}
+
+	rounds := append([]Round{}, s.Rounds...)
+	sort.SliceStable(rounds, func(i, j int) bool {
+		return rounds[i].Issue < rounds[j].Issue
+	})
+
+	balances := make([]int, len(s.Members))
+	carry := 0
+
+	for _, round := range rounds {
+		draw, ok := drawMap[round.Issue]
+		if !ok && round.Issue <= latest {
+			return statement, fmt.Errorf("第%d期没有开奖数据", round.Issue)
+		}
+
+		shares, err := s.getShares(round)
+		if err != nil {
+			return statement, err
+		}
+
+		tickets, err := getTickets(round)
+		if err != nil {
+			return statement, err
+		}
+
+		// 尚未开奖，只分摊投注金额
+		if !ok {
+			pending := Settlement{Issue: round.Issue}
+
+			for _, lott := range tickets {
+				pending.Cost += lott.GetCost() * 100
+			}
+
+			contributions, _ := split(pending.Cost, shares, 1, LeftoverLargest)
+
+			for i, member := range s.Members {
+				pending.Members = append(pending.Members, MemberLine{Name: member.Name, Shares: shares[i], Contribution: contributions[i]})
+			}
+
+			statement.Pending = append(statement.Pending, pending)
+
+			continue
+		}
+
+		settlement := Settlement{Issue: round.Issue, CarryIn: carry}
+
+		for _, lott := range tickets {
+			result, err := lott.GetLotteryResult(draw)
+			if err != nil {
+				return statement, fmt.Errorf("第%d期: %w", round.Issue, err)
+			}
+
+			settlement.Cost += lott.GetCost() * 100
+			settlement.Gross += result.Price * 100
+
+			if s.Tax != nil {
+				settlement.Tax += s.Tax(result) * 100
+			} else {
+				settlement.Tax += result.Tax * 100
+			}
+		}
+
+		settlement.Net = settlement.Gross - settlement.Tax
+
+		// 投注金额必须全部分摊，按最大余数法精确到分
+		contributions, _ := split(settlement.Cost, shares, 1, LeftoverLargest)
+		winnings, leftover := split(settlement.Net+carry, shares, unit, mode)
+
+		for i, member := range s.Members {
+			balances[i] += winnings[i] - contributions[i]
+
+			settlement.Members = append(settlement.Members, MemberLine{
+				Name:         member.Name,
+				Shares:       shares[i],
+				Contribution: contributions[i],
+				Winnings:     winnings[i],
+				Balance:      balances[i],
+			})
+		}
+
+		carry = leftover
+		settlement.CarryOut = carry
+		statement.Settlements = append(statement.Settlements, settlement)
+	}
+
+	statement.Leftover = carry
+
+	return statement, nil
+}
+
+// getTickets
+//
+// @Description 解析一期合买的彩票
+//
+// @Param round Round 一期合买
+//
+// @Return []lottery.Lottery 彩票列表
+//
+// @Return error 错误信息
+func getTickets(round Round) ([]lottery.Lottery, error) {
+	tickets := make([]lottery.Lottery, 0, len(round.Tickets))
+
+	for _, ticket := range round.Tickets {
+		lott, err := lottery.GetLottery(ticket)
+		if err != nil {
+			return nil, fmt.Errorf("第%d期: %w", round.Issue, err)
+		}
+
+		tickets = append(tickets, lott)
+	}
+
+	return tickets, nil
+}
+
+// formatAmount
+//
+// @Description 将以分为单位的金额格式化为元，例如: 12345 -> 123.45
+//
+// @Param amount int 金额 (分)
+//
+// @Return string 金额 (元)
+func formatAmount(amount int) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, amount/100, amount%100)
+}
+
+// WriteCSV
+//
+// @Description 以 CSV 格式输出每个成员每期的出资、奖金和累计盈亏，金额单位为元。待开奖的合买在最后输出，奖金和累计盈亏为空
+//
+// @Param w io.Writer 输出
+//
+// @Return error 错误信息
+func (statement *Statement) WriteCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"issue", "member", "shares", "contribution", "winnings", "balance"}); err != nil {
+		return err
+	}
+
+	for _, settlement := range statement.Settlements {
+		for _, line := range settlement.Members {
+			record := []string{
+				fmt.Sprint(settlement.Issue),
+				line.Name,
+				fmt.Sprint(line.Shares),
+				formatAmount(line.Contribution),
+				formatAmount(line.Winnings),
+				formatAmount(line.Balance),
+			}
+
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, pending := range statement.Pending {
+		for _, line := range pending.Members {
+			record := []string{fmt.Sprint(pending.Issue), line.Name, fmt.Sprint(line.Shares), formatAmount(line.Contribution), "", ""}
+
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
+}
+
+// Print
+//
+// @Description 输出每期的结算汇总、待开奖的合买和成员的最终盈亏
+//
+// @Param w io.Writer 输出
+func (statement *Statement) Print(w io.Writer) {
+	for _, settlement := range statement.Settlements {
+		fmt.Fprintf(w, "第%d期: 投注 %s，税前奖金 %s，税额 %s，税后奖金 %s，上期结转 %s，结转下期 %s\n",
+			settlement.Issue, formatAmount(settlement.Cost), formatAmount(settlement.Gross), formatAmount(settlement.Tax),
+			formatAmount(settlement.Net), formatAmount(settlement.CarryIn), formatAmount(settlement.CarryOut))
+	}
+
+	for _, pending := range statement.Pending {
+		fmt.Fprintf(w, "第%d期: 投注 %s，未开奖\n", pending.Issue, formatAmount(pending.Cost))
+	}
+
+	if len(statement.Settlements) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "\n成员累计盈亏:")
+
+	for _, line := range statement.Settlements[len(statement.Settlements)-1].Members {
+		fmt.Fprintf(w, "  %s: %s\n", line.Name, formatAmount(line.Balance))
+	}
+
+	if statement.Leftover > 0 {
+		fmt.Fprintf(w, "未分配金额: %s\n", formatAmount(statement.Leftover))
+	}
+}
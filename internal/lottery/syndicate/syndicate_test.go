@@ -0,0 +1,171 @@
+package syndicate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int
+		shares   []int
+		unit     int
+		mode     string
+		result   []int
+		leftover int
+	}{
+		{"最大余数", 1000, []int{1, 2}, 1, LeftoverLargest, []int{333, 667}, 0},
+		{"结转", 1000, []int{1, 2}, 100, LeftoverCarry, []int{300, 600}, 100},
+		{"按单位最大余数", 1000, []int{1, 2}, 100, LeftoverLargest, []int{300, 700}, 0},
+		{"分给发起人", 1000, []int{1, 2}, 100, LeftoverFirst, []int{400, 600}, 0},
+		{"不足一个单位", 1001, []int{1, 1}, 100, LeftoverLargest, []int{500, 500}, 1},
+		{"跳过未参与成员", 1000, []int{0, 1, 2}, 100, LeftoverFirst, []int{0, 400, 600}, 0},
+		{"金额为0", 0, []int{1, 2}, 1, LeftoverLargest, []int{0, 0}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, leftover := split(tt.amount, tt.shares, tt.unit, tt.mode)
+
+			if !reflect.DeepEqual(result, tt.result) || leftover != tt.leftover {
+				t.Errorf("期望: %v %d, 实际: %v %d", tt.result, tt.leftover, result, leftover)
+			}
+		})
+	}
+}
+
+func TestSettle(t *testing.T) {
+	var draws []lottery.Lottery
+
+	for _, input := range []string{"DLT:02,04,11,29,30-02,08:25053", "DLT:01,02,03,04,06-03,04:25052"} {
+		draw, _ := lottery.GetLottery(input)
+		draws = append(draws, draw)
+	}
+
+	s := Syndicate{
+		Name:    "测试合买",
+		Members: []Member{{"A", 1}, {"B", 2}},
+		Unit:    300,
+		Rounds: []Round{
+			{Issue: 25053, Tickets: []string{"DLT:02,04,11,29,31-02,08", "DLT:01,03,05,06,07-01,03"}},
+			{Issue: 25052, Tickets: []string{"DLT:01,02,03,04,05-01,02"}, Shares: map[string]int{"A": 1, "B": 1}},
+		},
+		Tax: func(result lottery.LotteryResult) int {
+			return result.Price / 5
+		},
+	}
+
+	statement, err := s.Settle(draws)
+	if err != nil {
+		t.Fatalf("错误信息: %s", err)
+	}
+
+	if len(statement.Settlements) != 2 || statement.Settlements[0].Issue != 25052 {
+		t.Fatalf("结算顺序错误: %+v", statement.Settlements)
+	}
+
+	first, second := statement.Settlements[0], statement.Settlements[1]
+
+	if first.Net != 8000 || first.CarryOut != 200 || second.CarryIn != 200 || second.Tax != 60000 || statement.Leftover != 500 {
+		t.Errorf("结算错误: %+v, %+v", first, second)
+	}
+
+	expected := []MemberLine{{"A", 1, 133, 79800, 83467}, {"B", 2, 267, 159900, 163433}}
+
+	if !reflect.DeepEqual(second.Members, expected) {
+		t.Errorf("期望: %+v, 实际: %+v", expected, second.Members)
+	}
+
+	var builder strings.Builder
+
+	if err := statement.WriteCSV(&builder); err != nil {
+		t.Fatalf("错误信息: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(builder.String()), "\n")
+
+	if len(lines) != 5 || lines[1] != "25052,A,1,1.00,39.00,38.00" || lines[4] != "25053,B,2,2.67,1599.00,1634.33" {
+		t.Errorf("CSV 错误: %v", lines)
+	}
+}
+
+func TestSettlePending(t *testing.T) {
+	draw, _ := lottery.GetLottery("DLT:02,04,11,29,30-02,08:25053")
+
+	s := Syndicate{
+		Members: []Member{{"A", 1}, {"B", 2}},
+		Rounds: []Round{
+			{Issue: 25055, Tickets: []string{"DLT:01,02,03,04,05-01,02x2"}},
+			{Issue: 25053, Tickets: []string{"DLT:02,04,11,29,31-02,08"}},
+			{Issue: 25054, Tickets: []string{"DLT:01,02,03,04,05-01,02"}},
+		},
+	}
+
+	statement, err := s.Settle([]lottery.Lottery{draw})
+	if err != nil {
+		t.Fatalf("错误信息: %s", err)
+	}
+
+	if len(statement.Settlements) != 1 || statement.Settlements[0].Issue != 25053 {
+		t.Fatalf("已开奖的合买结算错误: %+v", statement.Settlements)
+	}
+
+	if len(statement.Pending) != 2 || statement.Pending[0].Issue != 25054 || statement.Pending[1].Cost != 400 {
+		t.Fatalf("待开奖的合买错误: %+v", statement.Pending)
+	}
+
+	expected := []MemberLine{{"A", 1, 133, 0, 0}, {"B", 2, 267, 0, 0}}
+
+	if !reflect.DeepEqual(statement.Pending[1].Members, expected) {
+		t.Errorf("期望: %+v, 实际: %+v", expected, statement.Pending[1].Members)
+	}
+
+	var builder strings.Builder
+
+	if err := statement.WriteCSV(&builder); err != nil {
+		t.Fatalf("错误信息: %s", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(builder.String()), "\n")
+
+	if len(lines) != 7 || lines[3] != "25054,A,1,0.67,," || lines[6] != "25055,B,2,2.67,," {
+		t.Errorf("CSV 错误: %v", lines)
+	}
+
+	builder.Reset()
+	statement.Print(&builder)
+
+	if !strings.Contains(builder.String(), "第25055期: 投注 4.00，未开奖") {
+		t.Errorf("输出错误: %s", builder.String())
+	}
+}
+
+func TestSettleError(t *testing.T) {
+	draw, _ := lottery.GetLottery("DLT:02,04,11,29,30-02,08:25053")
+	members := []Member{{"A", 1}}
+
+	tests := []struct {
+		name      string
+		syndicate Syndicate
+	}{
+		{"没有成员", Syndicate{Rounds: []Round{{Issue: 25053}}}},
+		{"缺少已开奖期号的开奖数据", Syndicate{Members: members, Rounds: []Round{{Issue: 25052}}}},
+		{"待开奖的彩票错误", Syndicate{Members: members, Rounds: []Round{{Issue: 25054, Tickets: []string{"ABC:01"}}}}},
+		{"成员不存在", Syndicate{Members: members, Rounds: []Round{{Issue: 25053, Shares: map[string]int{"A": 1, "C": 1}}}}},
+		{"没有出资", Syndicate{Members: members, Rounds: []Round{{Issue: 25053, Shares: map[string]int{"A": 0}}}}},
+		{"余数处理方式错误", Syndicate{Members: members, Leftover: "abc"}},
+		{"彩票错误", Syndicate{Members: members, Rounds: []Round{{Issue: 25053, Tickets: []string{"ABC:01"}}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.syndicate.Settle([]lottery.Lottery{draw}); err == nil {
+				t.Errorf("应该失败")
+			}
+		})
+	}
+}
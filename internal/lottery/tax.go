@@ -0,0 +1,50 @@
+package lottery
+
+import "math"
+
+// 个人所得税规则，单注奖金超过起征额时按全额计税
+type TaxRule struct {
+	Threshold int     // 起征额 (元)，单注奖金大于该金额时计税
+	Rate      float64 // 税率
+}
+
+// 默认的个人所得税规则: 单注奖金超过10000元时按20%计税
+var DefaultTaxRule = TaxRule{Threshold: 10000, Rate: 0.2}
+
+// GetTax
+//
+// @Description 计算单注奖金的个人所得税，结果四舍五入到元
+//
+// @Param price int 单注奖金
+//
+// @Return int 税额
+func (rule TaxRule) GetTax(price int) int {
+	if price <= rule.Threshold {
+		return 0
+	}
+
+	return int(math.Round(float64(price) * rule.Rate))
+}
+
+// ApplyTax
+//
+// @Description 按税率规则重新计算开奖结果的税额和税后奖金。税额按单注计算，倍投时每一倍单独计税，复式票按展开后的单式票分别计税
+//
+// @Param rule TaxRule 个人所得税规则
+func (result *LotteryResult) ApplyTax(rule TaxRule) {
+	if len(result.List) == 0 {
+		result.Tax = rule.GetTax(result.UnitPrice) * max(result.Scale, 1)
+		result.Net = result.Price - result.Tax
+
+		return
+	}
+
+	result.Tax = 0
+	result.Net = 0
+
+	for i := range result.List {
+		result.List[i].ApplyTax(rule)
+		result.Tax += result.List[i].Tax
+		result.Net += result.List[i].Net
+	}
+}
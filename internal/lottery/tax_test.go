@@ -0,0 +1,41 @@
+package lottery
+
+import "testing"
+
+func TestApplyTax(t *testing.T) {
+	target, _ := GetLottery("DLT:01,02,03,04,05-01,02")
+
+	tests := []struct {
+		name   string
+		source string
+		rule   TaxRule
+		price  int
+		tax    int
+		net    int
+	}{
+		{"一等奖", "DLT:01,02,03,04,05-01,02", DefaultTaxRule, 10000000, 2000000, 8000000},
+		{"一等奖倍投", "DLT:01,02,03,04,05-01,02x3", DefaultTaxRule, 30000000, 6000000, 24000000},
+		{"三等奖不计税", "DLT:01,02,03,04,05-03,04x2", DefaultTaxRule, 20000, 0, 20000},
+		{"倍投合计超过起征额不计税", "DLT:01,02,03,04,06-01,02x5", DefaultTaxRule, 15000, 0, 15000},
+		{"复式票", "DLT:01,02,03,04,05-01,02,03", DefaultTaxRule, 10200000 + 200000, 2040000 + 40000, 8160000 + 160000},
+		{"自定义规则", "DLT:01,02,03,04,06-01,02x2", TaxRule{Threshold: 1000, Rate: 0.1}, 6000, 600, 5400},
+		{"未中奖", "DLT:06,07,08,09,10-03,04", DefaultTaxRule, 0, 0, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source, _ := GetLottery(tt.source)
+
+			result, err := source.GetLotteryResult(target)
+			if err != nil {
+				t.Fatalf("错误信息: %s", err)
+			}
+
+			result.ApplyTax(tt.rule)
+
+			if result.Price != tt.price || result.Tax != tt.tax || result.Net != tt.net {
+				t.Errorf("期望: %d %d %d, 实际: %d %d %d", tt.price, tt.tax, tt.net, result.Price, result.Tax, result.Net)
+			}
+		})
+	}
+}
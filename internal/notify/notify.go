@@ -0,0 +1,162 @@
+package notify
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/smtp"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// 通知内容
+type Message struct {
+	Subject string `json:"subject"`        // 标题
+	Body    string `json:"body"`           // 正文
+	Data    any    `json:"data,omitempty"` // 附加的结构化数据，仅 Webhook 使用
+}
+
+// 通知器
+type Notifier interface {
+	Notify(msg Message) error
+}
+
+// 标准输出通知
+type StdoutNotifier struct {
+	Writer io.Writer // 为空时使用 os.Stdout
+}
+
+// 本地命令通知，正文通过标准输入传入，标题通过环境变量 LOTT_SUBJECT 传入
+type CommandNotifier struct {
+	Name string   // 命令
+	Args []string // 命令参数
+}
+
+// Webhook 通知，将 Message 以 JSON 格式 POST 到指定地址
+type WebhookNotifier struct {
+	URL    string       // 地址
+	Client *http.Client // 为空时使用10秒超时的客户端
+}
+
+// 邮件通知
+type SMTPNotifier struct {
+	Addr     string   // SMTP 服务地址，例如 smtp.example.com:25
+	From     string   // 发件人
+	To       []string // 收件人
+	Username string   // 用户名，为空时不进行认证
+	Password string   // 密码
+}
+
+// 多个通知器的组合，依次调用全部通知器
+type MultiNotifier []Notifier
+
+func (n *StdoutNotifier) Notify(msg Message) error {
+	writer := n.Writer
+	if writer == nil {
+		writer = os.Stdout
+	}
+
+	_, err := fmt.Fprintf(writer, "%s\n%s\n", msg.Subject, msg.Body)
+
+	return err
+}
+
+func (n *CommandNotifier) Notify(msg Message) error {
+	cmd := exec.Command(n.Name, n.Args...)
+	cmd.Stdin = strings.NewReader(msg.Body)
+	cmd.Env = append(os.Environ(), "LOTT_SUBJECT="+msg.Subject)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("命令执行失败: %w。输出: %s", err, output)
+	}
+
+	return nil
+}
+
+func (n *WebhookNotifier) Notify(msg Message) error {
+	client := n.Client
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	jsonData, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("json解析失败: %w", err)
+	}
+
+	resp, err := client.Post(n.URL, "application/json", bytes.NewReader(jsonData))
+	if err != nil {
+		return fmt.Errorf("请求失败: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("请求失败，状态码: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+func base64Encode(str string) string {
+	return base64.StdEncoding.EncodeToString([]byte(str))
+}
+
+// buildMail
+//
+// @Description 生成邮件内容，标题使用 RFC 2047 编码以支持中文
+//
+// @Return []byte 邮件内容
+func (n *SMTPNotifier) buildMail(msg Message) []byte {
+	var buf bytes.Buffer
+
+	fmt.Fprintf(&buf, "From: %s\r\n", n.From)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(n.To, ", "))
+	fmt.Fprintf(&buf, "Subject: =?UTF-8?B?%s?=\r\n", base64Encode(msg.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	buf.WriteString("Content-Transfer-Encoding: base64\r\n")
+	buf.WriteString("\r\n")
+
+	body := base64Encode(msg.Body)
+	for len(body) > 76 {
+		buf.WriteString(body[:76] + "\r\n")
+		body = body[76:]
+	}
+	buf.WriteString(body + "\r\n")
+
+	return buf.Bytes()
+}
+
+func (n *SMTPNotifier) Notify(msg Message) error {
+	var auth smtp.Auth
+
+	if n.Username != "" {
+		host, _, _ := strings.Cut(n.Addr, ":")
+		auth = smtp.PlainAuth("", n.Username, n.Password, host)
+	}
+
+	if err := smtp.SendMail(n.Addr, auth, n.From, n.To, n.buildMail(msg)); err != nil {
+		return fmt.Errorf("邮件发送失败: %w", err)
+	}
+
+	return nil
+}
+
+func (notifiers MultiNotifier) Notify(msg Message) error {
+	var errs []error
+
+	for _, n := range notifiers {
+		if err := n.Notify(msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
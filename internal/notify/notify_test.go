@@ -0,0 +1,179 @@
+package notify
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+var testMessage = Message{
+	Subject: "大乐透第25053期开奖: 02,04,11,29,30-02,08",
+	Body:    "02,04,11,29,30-02,08\t一等奖\t奖金: 10000000\n合计奖金: 10000000",
+	Data:    map[string]int{"issue": 25053},
+}
+
+func TestStdoutNotifier(t *testing.T) {
+	var buf bytes.Buffer
+
+	if err := (&StdoutNotifier{Writer: &buf}).Notify(testMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected := testMessage.Subject + "\n" + testMessage.Body + "\n"; buf.String() != expected {
+		t.Errorf("期望: %q, 实际: %q", expected, buf.String())
+	}
+}
+
+func TestCommandNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notice.txt")
+	notifier := &CommandNotifier{Name: "sh", Args: []string{"-c", `{ echo "$LOTT_SUBJECT"; cat; } > "$0"`, path}}
+
+	if err := notifier.Notify(testMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	content, _ := os.ReadFile(path)
+
+	if expected := testMessage.Subject + "\n" + testMessage.Body; string(content) != expected {
+		t.Errorf("期望: %q, 实际: %q", expected, content)
+	}
+
+	if err := (&CommandNotifier{Name: "sh", Args: []string{"-c", "exit 1"}}).Notify(testMessage); err == nil {
+		t.Errorf("命令失败时应该返回错误")
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var received Message
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if r.Method != http.MethodPost || r.Header.Get("Content-Type") != "application/json" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&received); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	if err := (&WebhookNotifier{URL: server.URL}).Notify(testMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	if received.Subject != testMessage.Subject || received.Body != testMessage.Body || received.Data.(map[string]any)["issue"] != float64(25053) {
+		t.Errorf("期望: %+v, 实际: %+v", testMessage, received)
+	}
+
+	if err := (&WebhookNotifier{URL: server.URL + "/404"}).Notify(Message{}); err == nil {
+		t.Errorf("状态码错误时应该返回错误")
+	}
+}
+
+// startSMTPServer 启动一个只支持基本命令的本地 SMTP 服务，返回地址和收到的邮件内容
+func startSMTPServer(t *testing.T) (string, chan string) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Cleanup(func() { listener.Close() })
+
+	mails := make(chan string, 1)
+
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+
+		reply("220 localhost ESMTP")
+
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+
+			switch cmd := strings.ToUpper(strings.TrimSpace(line)); {
+			case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+				reply("250 localhost")
+			case strings.HasPrefix(cmd, "DATA"):
+				reply("354 start mail input")
+
+				var data strings.Builder
+				for {
+					line, err := reader.ReadString('\n')
+					if err != nil || line == ".\r\n" {
+						break
+					}
+					data.WriteString(line)
+				}
+
+				mails <- data.String()
+				reply("250 ok")
+			case strings.HasPrefix(cmd, "QUIT"):
+				reply("221 bye")
+				return
+			default:
+				reply("250 ok")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), mails
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	addr, mails := startSMTPServer(t)
+	notifier := &SMTPNotifier{Addr: addr, From: "lott@example.com", To: []string{"a@example.com", "b@example.com"}}
+
+	if err := notifier.Notify(testMessage); err != nil {
+		t.Fatal(err)
+	}
+
+	mail := <-mails
+	header, body, _ := strings.Cut(mail, "\r\n\r\n")
+	decoded, _ := base64.StdEncoding.DecodeString(strings.ReplaceAll(body, "\r\n", ""))
+
+	if !strings.Contains(header, "To: a@example.com, b@example.com") || !strings.Contains(header, "Subject: =?UTF-8?B?"+base64Encode(testMessage.Subject)+"?=") {
+		t.Errorf("邮件头错误: %s", header)
+	}
+
+	if string(decoded) != testMessage.Body {
+		t.Errorf("邮件正文期望: %q, 实际: %q", testMessage.Body, decoded)
+	}
+}
+
+func TestMultiNotifier(t *testing.T) {
+	var buf bytes.Buffer
+
+	notifiers := MultiNotifier{&StdoutNotifier{Writer: &buf}, &CommandNotifier{Name: "sh", Args: []string{"-c", "exit 1"}}, &StdoutNotifier{Writer: &buf}}
+
+	if err := notifiers.Notify(testMessage); err == nil {
+		t.Errorf("部分通知失败时应该返回错误")
+	}
+
+	if strings.Count(buf.String(), testMessage.Subject) != 2 {
+		t.Errorf("失败的通知不应该影响其他通知: %s", buf.String())
+	}
+}
@@ -0,0 +1,520 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+	"github.com/buggy-95/lott/internal/lottery/dlt"
+)
+
+// 错误代码
+const (
+	ErrBadRequest    = "bad_request"    // 请求格式错误
+	ErrBodyTooLarge  = "body_too_large" // 请求体过大
+	ErrInvalidTicket = "invalid_ticket" // 彩票字符串错误
+	ErrInvalidDraw   = "invalid_draw"   // 开奖号码错误
+	ErrTooManyBets   = "too_many_bets"  // 展开后的注数超过限制
+	ErrNotFound      = "not_found"      // 开奖数据不存在
+	ErrInternal      = "internal"       // 服务器内部错误
+)
+
+func init() {
+	lottery.RegisterMessages(lottery.LocaleZhCN, map[string]string{
+		"server.route":          "接口不存在: %s %s",
+		"server.body_too_large": "请求体超过%d字节",
+		"server.bad_request":    "请求解析失败: %s",
+		"server.no_ticket":      "缺少彩票",
+		"server.invalid_ticket": "%s: %s",
+		"server.type_mismatch":  "%s: 彩票类型 %s 与开奖号码类型 %s 不一致",
+		"server.too_many_bets":  "展开后的注数超过%d",
+		"server.no_draw":        "没有开奖数据",
+		"server.draw_not_found": "第%d期开奖数据不存在",
+		"server.invalid_draw":   "%s",
+		"server.invalid_issue":  "期号格式错误: %s",
+	})
+
+	lottery.RegisterMessages(lottery.LocaleEn, map[string]string{
+		"server.route":          "no such endpoint: %s %s",
+		"server.body_too_large": "request body exceeds %d bytes",
+		"server.bad_request":    "invalid request: %s",
+		"server.no_ticket":      "no tickets",
+		"server.invalid_ticket": "%s: %s",
+		"server.type_mismatch":  "%s: ticket type %s does not match the draw type %s",
+		"server.too_many_bets":  "more than %d bets after expansion",
+		"server.no_draw":        "no draws",
+		"server.draw_not_found": "draw %d not found",
+		"server.invalid_draw":   "%s",
+		"server.invalid_issue":  "invalid issue: %s",
+	})
+}
+
+// 默认限制
+const (
+	defaultMaxBodySize = 64 << 10
+	defaultMaxBets     = 10000
+)
+
+// HTTP JSON 接口服务
+type Server struct {
+	StorePath   string         // 历史文件路径，每次请求时读取，可以与 sync 命令同时使用
+	Type        string         // 彩票类型，为空时为 DLT
+	MaxBodySize int64          // 请求体的最大字节数，为0时为64KB
+	MaxBets     int            // 单次请求展开的最大注数，为0时为10000
+	Locale      lottery.Locale // 默认语言，请求没有指定语言时使用，为空时使用当前语言
+}
+
+// 错误响应
+type ErrorResponse struct {
+	Error struct {
+		Code    string            `json:"code"`           // 错误代码
+		Kind    lottery.ErrorKind `json:"kind,omitempty"` // 彩票和开奖号码的错误类型，不随语言变化
+		Message string            `json:"message"`        // 错误信息，按请求的语言格式化
+	} `json:"error"`
+}
+
+// 核对请求，Draw 和 Issue 都为空时使用最新一期开奖
+type CheckRequest struct {
+	Tickets []string `json:"tickets"`         // 彩票字符串
+	Draw    string   `json:"draw,omitempty"`  // 开奖号码字符串
+	Issue   int      `json:"issue,omitempty"` // 开奖期号，从历史数据中读取开奖号码
+}
+
+// 单张彩票的核对结果
+type TicketResult struct {
+	Ticket string               `json:"ticket"` // 彩票字符串
+	Bets   int                  `json:"bets"`   // 注数，不包含倍投
+	Cost   int                  `json:"cost"`   // 投注金额
+	Level  int                  `json:"level"`  // 最高中奖等级
+	Label  string               `json:"label"`  // 最高中奖等级名称
+	Levels []lottery.LevelCount `json:"levels"` // 各等级的中奖注数
+	Price  int                  `json:"price"`  // 税前奖金
+	Tax    int                  `json:"tax"`    // 税额
+	Net    int                  `json:"net"`    // 税后奖金
+}
+
+// 核对响应
+type CheckResponse struct {
+	Draw    string         `json:"draw"`    // 开奖号码字符串
+	Results []TicketResult `json:"results"` // 每张彩票的核对结果
+	Price   int            `json:"price"`   // 合计税前奖金
+	Tax     int            `json:"tax"`     // 合计税额
+	Net     int            `json:"net"`     // 合计税后奖金
+}
+
+// 展开请求
+type ExpandRequest struct {
+	Ticket string `json:"ticket"` // 彩票字符串
+}
+
+// 展开响应
+type ExpandResponse struct {
+	Ticket string   `json:"ticket"` // 彩票字符串
+	Bets   int      `json:"bets"`   // 注数，不包含倍投
+	Cost   int      `json:"cost"`   // 投注金额
+	List   []string `json:"list"`   // 单式票列表
+}
+
+// 开奖数据响应
+type DrawResponse struct {
+	Issue  int    `json:"issue"`  // 期号
+	Date   string `json:"date"`   // 开奖日期
+	Result string `json:"result"` // 开奖号码，例如: 02 04 11 29 30 02 08
+	Draw   string `json:"draw"`   // 开奖号码字符串，可以直接用于核对请求
+}
+
+// 带有状态码和错误代码的错误
+type apiError struct {
+	status int
+	code   string
+	err    error
+}
+
+func (e *apiError) Error() string {
+	return e.err.Error()
+}
+
+func newError(status int, code string, key string, args ...any) *apiError {
+	return &apiError{status, code, lottery.NewError("", key, args...)}
+}
+
+// Handler
+//
+// @Description 获取接口的 HTTP 处理器
+//
+// @Return http.Handler HTTP 处理器
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /check", s.handle(s.check))
+	mux.HandleFunc("POST /expand", s.handle(s.expand))
+	mux.HandleFunc("GET /draws/latest", s.handle(s.getLatestDraw))
+	mux.HandleFunc("GET /draws/{issue}", s.handle(s.getDraw))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, s.getLocale(r), newError(http.StatusNotFound, ErrNotFound, "server.route", r.Method, r.URL.Path))
+	})
+
+	return mux
+}
+
+// handle
+//
+// @Description 将返回结果和错误的处理函数包装为 HTTP 处理函数，结果和错误都以 JSON 格式返回
+//
+// @Param fn func(r *http.Request) (any, error) 处理函数
+//
+// @Return http.HandlerFunc HTTP 处理函数
+func (s *Server) handle(fn func(r *http.Request) (any, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		maxBodySize := s.MaxBodySize
+		if maxBodySize <= 0 {
+			maxBodySize = defaultMaxBodySize
+		}
+
+		r.Body = http.MaxBytesReader(w, r.Body, maxBodySize)
+
+		result, err := fn(r)
+		if err != nil {
+			writeError(w, s.getLocale(r), err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, result)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(value)
+}
+
+// getLocale
+//
+// @Description 获取请求的语言，依次使用 lang 查询参数、Accept-Language 请求头和服务的默认语言
+//
+// @Param r *http.Request 请求
+//
+// @Return lottery.Locale 语言
+func (s *Server) getLocale(r *http.Request) lottery.Locale {
+	if locale, ok := lottery.ParseLocale(r.URL.Query().Get("lang")); ok {
+		return locale
+	}
+
+	for _, item := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, _, _ := strings.Cut(item, ";")
+
+		if locale, ok := lottery.ParseLocale(strings.TrimSpace(tag)); ok {
+			return locale
+		}
+	}
+
+	if s.Locale != "" {
+		return s.Locale
+	}
+
+	return lottery.GetLocale()
+}
+
+func writeError(w http.ResponseWriter, locale lottery.Locale, err error) {
+	var (
+		resp     ErrorResponse
+		apiErr   *apiError
+		maxBytes *http.MaxBytesError
+	)
+
+	switch {
+	case errors.As(err, &apiErr):
+	case errors.As(err, &maxBytes):
+		apiErr = newError(http.StatusRequestEntityTooLarge, ErrBodyTooLarge, "server.body_too_large", maxBytes.Limit)
+	default:
+		apiErr = &apiError{http.StatusInternalServerError, ErrInternal, err}
+	}
+
+	resp.Error.Code = apiErr.code
+	resp.Error.Kind = lottery.GetErrorKind(apiErr.err)
+	resp.Error.Message = lottery.LocalizeError(apiErr.err, locale)
+
+	writeJSON(w, apiErr.status, resp)
+}
+
+// decode
+//
+// @Description 解析 JSON 请求体
+//
+// @Param r *http.Request 请求
+//
+// @Param value any 解析结果
+//
+// @Return error 错误信息
+func decode(r *http.Request, value any) error {
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(value); err != nil {
+		var maxBytes *http.MaxBytesError
+
+		if errors.As(err, &maxBytes) {
+			return err
+		}
+
+		return newError(http.StatusBadRequest, ErrBadRequest, "server.bad_request", err)
+	}
+
+	return nil
+}
+
+func (s *Server) getType() string {
+	if s.Type == "" {
+		return "DLT"
+	}
+
+	return s.Type
+}
+
+func (s *Server) getMaxBets() int {
+	if s.MaxBets <= 0 {
+		return defaultMaxBets
+	}
+
+	return s.MaxBets
+}
+
+// parseTickets
+//
+// @Description 解析彩票字符串，展开前先计算注数，合计注数超过限制时不展开
+//
+// @Param inputs []string 彩票字符串
+//
+// @Return []lottery.Lottery 彩票列表
+//
+// @Return error 错误信息
+func (s *Server) parseTickets(inputs []string) ([]lottery.Lottery, error) {
+	if len(inputs) == 0 {
+		return nil, newError(http.StatusBadRequest, ErrBadRequest, "server.no_ticket")
+	}
+
+	total := 0
+
+	for _, input := range inputs {
+		count, err := lottery.CountBets(input)
+		if err != nil {
+			return nil, newError(http.StatusUnprocessableEntity, ErrInvalidTicket, "server.invalid_ticket", input, err)
+		}
+
+		total += count
+
+		if total > s.getMaxBets() {
+			return nil, newError(http.StatusRequestEntityTooLarge, ErrTooManyBets, "server.too_many_bets", s.getMaxBets())
+		}
+	}
+
+	tickets := make([]lottery.Lottery, 0, len(inputs))
+
+	for _, input := range inputs {
+		ticket, err := lottery.GetLottery(input)
+		if err != nil {
+			return nil, newError(http.StatusUnprocessableEntity, ErrInvalidTicket, "server.invalid_ticket", input, err)
+		}
+
+		tickets = append(tickets, ticket)
+	}
+
+	return tickets, nil
+}
+
+// findDraw
+//
+// @Description 从历史数据中查找开奖数据
+//
+// @Param issue int 期号，为0时返回最新一期
+//
+// @Return dlt.PoolDraw 开奖数据
+//
+// @Return error 错误信息
+func (s *Server) findDraw(issue int) (dlt.PoolDraw, error) {
+	store, err := dlt.LoadGameStore(s.StorePath, s.getType())
+	if err != nil {
+		return dlt.PoolDraw{}, err
+	}
+
+	for _, draw := range store.List {
+		drawIssue, err := draw.GetIssue()
+		if err != nil {
+			continue
+		}
+
+		if issue == 0 || drawIssue == issue {
+			return draw, nil
+		}
+	}
+
+	if issue == 0 {
+		return dlt.PoolDraw{}, newError(http.StatusNotFound, ErrNotFound, "server.no_draw")
+	}
+
+	return dlt.PoolDraw{}, newError(http.StatusNotFound, ErrNotFound, "server.draw_not_found", issue)
+}
+
+// getDrawLottery
+//
+// @Description 获取核对请求的开奖号码
+//
+// @Param req CheckRequest 核对请求
+//
+// @Return lottery.Lottery 开奖号码
+//
+// @Return error 错误信息
+func (s *Server) getDrawLottery(req CheckRequest) (lottery.Lottery, error) {
+	if req.Draw != "" {
+		draw, err := lottery.GetLottery(req.Draw)
+		if err != nil {
+			return draw, newError(http.StatusUnprocessableEntity, ErrInvalidDraw, "server.invalid_draw", err)
+		}
+
+		rule, err := lottery.GetGameRule(draw.Type)
+		if err == nil {
+			err = rule.CheckDraw(draw.LotteryParts)
+		}
+
+		if err != nil {
+			return draw, newError(http.StatusUnprocessableEntity, ErrInvalidDraw, "server.invalid_draw", err)
+		}
+
+		return draw, nil
+	}
+
+	poolDraw, err := s.findDraw(req.Issue)
+	if err != nil {
+		return lottery.Lottery{}, err
+	}
+
+	return poolDraw.GetLottery(s.getType())
+}
+
+func (s *Server) check(r *http.Request) (any, error) {
+	var req CheckRequest
+
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.parseTickets(req.Tickets)
+	if err != nil {
+		return nil, err
+	}
+
+	draw, err := s.getDrawLottery(req)
+	if err != nil {
+		return nil, err
+	}
+
+	locale := s.getLocale(r)
+	resp := CheckResponse{Draw: draw.String()}
+
+	for _, ticket := range tickets {
+		// 按期号查询时开奖号码的类型为服务的彩票类型
+		if ticket.Type != draw.Type {
+			return nil, newError(http.StatusUnprocessableEntity, ErrInvalidTicket, "server.type_mismatch", ticket.String(), ticket.Type, draw.Type)
+		}
+
+		result, err := ticket.GetLotteryResult(draw)
+		if err != nil {
+			return nil, newError(http.StatusUnprocessableEntity, ErrInvalidTicket, "server.invalid_ticket", ticket.String(), err)
+		}
+
+		resp.Results = append(resp.Results, TicketResult{
+			Ticket: ticket.String(),
+			Bets:   ticket.GetBetCount(),
+			Cost:   ticket.GetCost(),
+			Level:  result.Level,
+			Label:  lottery.GetLocaleLevelLabel(locale, result.Level),
+			Levels: result.GetLevelCounts(),
+			Price:  result.Price,
+			Tax:    result.Tax,
+			Net:    result.Net,
+		})
+		resp.Price += result.Price
+		resp.Tax += result.Tax
+		resp.Net += result.Net
+	}
+
+	return resp, nil
+}
+
+func (s *Server) expand(r *http.Request) (any, error) {
+	var req ExpandRequest
+
+	if err := decode(r, &req); err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.parseTickets([]string{req.Ticket})
+	if err != nil {
+		return nil, err
+	}
+
+	ticket := tickets[0]
+	resp := ExpandResponse{Ticket: ticket.String(), Bets: ticket.GetBetCount(), Cost: ticket.GetCost()}
+
+	list := ticket.List
+	if len(list) == 0 {
+		list = []lottery.Lottery{ticket}
+	}
+
+	for _, item := range list {
+		resp.List = append(resp.List, item.String())
+	}
+
+	return resp, nil
+}
+
+// getDrawResponse
+//
+// @Description 将开奖数据转换为响应
+//
+// @Param draw dlt.PoolDraw 开奖数据
+//
+// @Return DrawResponse 开奖数据响应
+//
+// @Return error 错误信息
+func (s *Server) getDrawResponse(draw dlt.PoolDraw) (DrawResponse, error) {
+	lott, err := draw.GetLottery(s.getType())
+	if err != nil {
+		return DrawResponse{}, err
+	}
+
+	return DrawResponse{
+		Issue:  lott.Index,
+		Date:   draw.LotteryDrawTime,
+		Result: draw.LotteryDrawResult,
+		Draw:   lott.String(),
+	}, nil
+}
+
+func (s *Server) getLatestDraw(r *http.Request) (any, error) {
+	draw, err := s.findDraw(0)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getDrawResponse(draw)
+}
+
+func (s *Server) getDraw(r *http.Request) (any, error) {
+	issue, err := strconv.Atoi(r.PathValue("issue"))
+	if err != nil || issue <= 0 {
+		return nil, newError(http.StatusBadRequest, ErrBadRequest, "server.invalid_issue", r.PathValue("issue"))
+	}
+
+	draw, err := s.findDraw(issue)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.getDrawResponse(draw)
+}
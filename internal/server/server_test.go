@@ -0,0 +1,179 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func newTestServer(t *testing.T) *httptest.Server {
+	s := &Server{
+		StorePath:   "../lottery/dlt/testdata/dlt_history.json",
+		MaxBodySize: 1024,
+		MaxBets:     100,
+	}
+
+	server := httptest.NewServer(s.Handler())
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+// request
+//
+// @Description 发送请求并解析 JSON 响应
+//
+// @Return int 状态码
+func request(t *testing.T, server *httptest.Server, method, path, body string, value any) int {
+	req, _ := http.NewRequest(method, server.URL+path, strings.NewReader(body))
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("请求失败: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(value); err != nil {
+		t.Fatalf("响应解析失败: %s", err)
+	}
+
+	return resp.StatusCode
+}
+
+func TestDraws(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		path   string
+		status int
+		draw   string
+		code   string
+	}{
+		{"最新一期", "/draws/latest", 200, "DLT:02,04,11,29,30-02,08:25053", ""},
+		{"指定期号", "/draws/25050", 200, "DLT:01,07,15,26,34-03,10:25050", ""},
+		{"期号不存在", "/draws/25001", 404, "", ErrNotFound},
+		{"期号格式错误", "/draws/abc", 400, "", ErrBadRequest},
+		{"接口不存在", "/abc", 404, "", ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp struct {
+				DrawResponse
+				ErrorResponse
+			}
+
+			status := request(t, server, "GET", tt.path, "", &resp)
+
+			if status != tt.status || resp.DrawResponse.Draw != tt.draw || resp.ErrorResponse.Error.Code != tt.code {
+				t.Errorf("状态码: %d, 响应: %+v", status, resp)
+			}
+		})
+	}
+}
+
+func TestCheck(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+		price  int
+		code   string
+	}{
+		{"最新一期", `{"tickets": ["DLT:02,04,11,29,30-02,08", "DLT:02,04,11,29,31-02,09x2"]}`, 200, 10000600, ""},
+		{"指定期号", `{"tickets": ["DLT:03,13,21,27,33-01,07"], "issue": 25052}`, 200, 200000, ""},
+		{"指定开奖号码", `{"tickets": ["DLT:01,02,03,04,05,06-01,02,03"], "draw": "DLT:01,02,03,04,05-01,02"}`, 200, 10418000, ""},
+		{"彩票错误", `{"tickets": ["DLT:01,02,03,04,36-01,02"]}`, 422, 0, ErrInvalidTicket},
+		{"开奖号码错误", `{"tickets": ["DLT:01,02,03,04,05-01,02"], "draw": "DLT:01,02,03,04,05,06-01,02"}`, 422, 0, ErrInvalidDraw},
+		{"彩票类型与历史数据不一致", `{"tickets": ["SSQ:01,02,03,04,05,06-01"], "issue": 25053}`, 422, 0, ErrInvalidTicket},
+		{"彩票类型与开奖号码不一致", `{"tickets": ["QLC:01,02,03,04,05,06,07"], "draw": "DLT:01,02,03,04,05-01,02"}`, 422, 0, ErrInvalidTicket},
+		{"期号不存在", `{"tickets": ["DLT:01,02,03,04,05-01,02"], "issue": 25001}`, 404, 0, ErrNotFound},
+		{"缺少彩票", `{}`, 400, 0, ErrBadRequest},
+		{"未知字段", `{"ticket": "DLT:01,02,03,04,05-01,02"}`, 400, 0, ErrBadRequest},
+		{"注数超过限制", `{"tickets": ["DLT:01,02,03,04,05,06,07,08,09-01,02,03"]}`, 413, 0, ErrTooManyBets},
+		{"请求体过大", `{"tickets": ["` + strings.Repeat("DLT:01,02,03,04,05-01,02", 50) + `"]}`, 413, 0, ErrBodyTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp struct {
+				CheckResponse
+				ErrorResponse
+			}
+
+			status := request(t, server, "POST", "/check", tt.body, &resp)
+
+			if status != tt.status || resp.Price != tt.price || resp.ErrorResponse.Error.Code != tt.code {
+				t.Errorf("状态码: %d, 响应: %+v", status, resp)
+			}
+		})
+	}
+}
+
+func TestExpand(t *testing.T) {
+	server := newTestServer(t)
+
+	var resp ExpandResponse
+
+	status := request(t, server, "POST", "/expand", `{"ticket": "DLT:01,02~03,04,05,06-07,08x3"}`, &resp)
+
+	if status != 200 || resp.Bets != 4 || resp.Cost != 24 || len(resp.List) != 4 || resp.List[0] != "DLT:01,02,03,04,05-07,08x3" {
+		t.Errorf("状态码: %d, 响应: %+v", status, resp)
+	}
+
+	var errResp ErrorResponse
+
+	if status := request(t, server, "GET", "/expand", "", &errResp); status != 404 {
+		t.Errorf("状态码: %d, 响应: %+v", status, errResp)
+	}
+}
+
+func TestLocale(t *testing.T) {
+	server := newTestServer(t)
+
+	tests := []struct {
+		name    string
+		path    string
+		body    string
+		label   string
+		kind    string
+		message string
+	}{
+		{"默认中文", "/check", `{"tickets": ["DLT:02,04,11,29,30-02,08"]}`, "一等奖", "", ""},
+		{"英文", "/check?lang=en", `{"tickets": ["DLT:02,04,11,29,30-02,08"]}`, "1st prize", "", ""},
+		{"中文错误", "/check?lang=zh-CN", `{"tickets": ["DLT:01,02,03,04,36-01,02"]}`, "", "number_range", "DLT:01,02,03,04,36-01,02: 前区数字范围为1~35"},
+		{"英文错误", "/check?lang=en", `{"tickets": ["DLT:01,02,03,04,36-01,02"]}`, "", "number_range", "DLT:01,02,03,04,36-01,02: front numbers must be between 1 and 35"},
+		{"英文解析错误", "/check?lang=en", `{"tickets": ["DLT:01,01,03,04,05-01,02"]}`, "", "duplicate_number", "DLT:01,01,03,04,05-01,02: invalid front numbers: invalid numbers: duplicate leg numbers: [1]. input: DLT:01,01,03,04,05-01,02"},
+		{"英文服务错误", "/draws/abc?lang=en", "", "", "", "invalid issue: abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp struct {
+				CheckResponse
+				ErrorResponse
+			}
+
+			method := "POST"
+			if tt.body == "" {
+				method = "GET"
+			}
+
+			request(t, server, method, tt.path, tt.body, &resp)
+
+			label := ""
+			if len(resp.Results) > 0 {
+				label = resp.Results[0].Label
+			}
+
+			if label != tt.label || string(resp.Error.Kind) != tt.kind || resp.Error.Message != tt.message {
+				t.Errorf("响应: %+v", resp)
+			}
+		})
+	}
+}
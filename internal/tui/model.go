@@ -0,0 +1,413 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+// 按键
+type Key int
+
+const (
+	KeyRune      Key = iota // 普通字符
+	KeyEnter                // 回车
+	KeyBackspace            // 退格
+	KeyDelete               // 删除
+	KeyTab                  // 切换输入框和列表
+	KeyUp                   // 上
+	KeyDown                 // 下
+	KeyLeft                 // 左
+	KeyRight                // 右
+	KeyPrevIssue            // 上一期 (Ctrl-P)
+	KeyNextIssue            // 下一期 (Ctrl-N)
+	KeyEsc                  // 退出
+)
+
+// 按键事件
+type KeyEvent struct {
+	Key  Key
+	Rune rune // 普通字符
+}
+
+// 单张展开的单式票最多显示的行数
+const maxExpandRows = 20
+
+// 单张彩票展开后的最大注数，超过后不展开，避免输入大复式时卡顿
+const maxBets = 100000
+
+// 终端界面的状态，与终端无关，便于测试
+type Model struct {
+	Draws     []lottery.Lottery // 开奖号码列表，从新到旧排列
+	DrawIndex int               // 当前选择的开奖号码
+	UseColor  bool              // 是否用颜色标记中奖号码
+
+	input    []rune                  // 输入框内容
+	inputErr string                  // 输入框内容的解析错误
+	inputTip string                  // 输入框内容的注数和金额
+	focus    bool                    // 焦点是否在列表上
+	cursor   int                     // 列表中选择的行
+	tickets  []lottery.Lottery       // 已添加的彩票
+	results  []lottery.LotteryResult // 彩票的核对结果，与 tickets 顺序一致
+	expanded map[int]bool            // 展开的行
+	err      string                  // 最近一次操作的错误
+}
+
+// NewModel
+//
+// @Description 创建终端界面的状态
+//
+// @Param draws []lottery.Lottery 开奖号码列表，从新到旧排列
+//
+// @Return *Model 终端界面的状态
+func NewModel(draws []lottery.Lottery) *Model {
+	return &Model{Draws: draws, expanded: make(map[int]bool)}
+}
+
+// Update
+//
+// @Description 处理按键事件
+//
+// @Param event KeyEvent 按键事件
+//
+// @Return bool 是否退出
+func (m *Model) Update(event KeyEvent) bool {
+	m.err = ""
+
+	switch event.Key {
+	case KeyEsc:
+		return true
+	case KeyTab:
+		m.focus = !m.focus && len(m.tickets) > 0
+	case KeyPrevIssue:
+		m.selectDraw(m.DrawIndex + 1)
+	case KeyNextIssue:
+		m.selectDraw(m.DrawIndex - 1)
+	default:
+		if m.focus {
+			m.updateTable(event)
+		} else {
+			m.updateInput(event)
+		}
+	}
+
+	return false
+}
+
+// updateInput
+//
+// @Description 处理输入框的按键，每次输入后重新校验输入内容
+//
+// @Param event KeyEvent 按键事件
+func (m *Model) updateInput(event KeyEvent) {
+	switch event.Key {
+	case KeyRune:
+		m.input = append(m.input, event.Rune)
+	case KeyBackspace:
+		if len(m.input) > 0 {
+			m.input = m.input[:len(m.input)-1]
+		}
+	case KeyEnter:
+		m.addTicket()
+	case KeyDown:
+		m.focus = len(m.tickets) > 0
+	}
+
+	m.validate()
+}
+
+// updateTable
+//
+// @Description 处理列表的按键
+//
+// @Param event KeyEvent 按键事件
+func (m *Model) updateTable(event KeyEvent) {
+	switch event.Key {
+	case KeyUp:
+		if m.cursor > 0 {
+			m.cursor--
+		} else {
+			m.focus = false
+		}
+	case KeyDown:
+		m.cursor = min(m.cursor+1, len(m.tickets)-1)
+	case KeyLeft:
+		m.selectDraw(m.DrawIndex + 1)
+	case KeyRight:
+		m.selectDraw(m.DrawIndex - 1)
+	case KeyEnter:
+		m.expanded[m.cursor] = !m.expanded[m.cursor]
+	case KeyDelete, KeyBackspace:
+		m.removeTicket(m.cursor)
+	case KeyRune:
+		if event.Rune == ' ' {
+			m.expanded[m.cursor] = !m.expanded[m.cursor]
+		} else if event.Rune == 'd' {
+			m.removeTicket(m.cursor)
+		}
+	}
+}
+
+// validate
+//
+// @Description 校验输入框的内容，不展开单式票
+func (m *Model) validate() {
+	m.inputErr = ""
+	m.inputTip = ""
+
+	input := strings.TrimSpace(string(m.input))
+	if input == "" {
+		return
+	}
+
+	count, err := lottery.CountBets(input)
+	if err != nil {
+		m.inputErr = err.Error()
+		return
+	}
+
+	m.inputTip = fmt.Sprintf("%d注", count)
+
+	if count > maxBets {
+		m.inputErr = fmt.Sprintf("注数超过%d", maxBets)
+	}
+}
+
+// addTicket
+//
+// @Description 将输入框的内容添加到列表中
+func (m *Model) addTicket() {
+	input := strings.TrimSpace(string(m.input))
+	if input == "" {
+		return
+	}
+
+	if m.validate(); m.inputErr != "" {
+		m.err = m.inputErr
+		return
+	}
+
+	ticket, err := lottery.GetLottery(input)
+	if err != nil {
+		m.err = err.Error()
+		return
+	}
+
+	result, err := m.check(ticket)
+	if err != nil {
+		m.err = err.Error()
+		return
+	}
+
+	m.tickets = append(m.tickets, ticket)
+	m.results = append(m.results, result)
+	m.input = nil
+}
+
+// removeTicket
+//
+// @Description 删除列表中的彩票
+//
+// @Param index int 行号
+func (m *Model) removeTicket(index int) {
+	if index < 0 || index >= len(m.tickets) {
+		return
+	}
+
+	m.tickets = append(m.tickets[:index], m.tickets[index+1:]...)
+	m.results = append(m.results[:index], m.results[index+1:]...)
+
+	expanded := make(map[int]bool)
+	for i, ok := range m.expanded {
+		if i < index {
+			expanded[i] = ok
+		} else if i > index {
+			expanded[i-1] = ok
+		}
+	}
+
+	m.expanded = expanded
+	m.cursor = max(min(m.cursor, len(m.tickets)-1), 0)
+	m.focus = m.focus && len(m.tickets) > 0
+}
+
+// check
+//
+// @Description 用当前选择的开奖号码核对彩票
+//
+// @Param ticket lottery.Lottery 彩票
+//
+// @Return lottery.LotteryResult 核对结果，没有开奖数据时为空
+//
+// @Return error 错误信息
+func (m *Model) check(ticket lottery.Lottery) (lottery.LotteryResult, error) {
+	draw, ok := m.getDraw()
+	if !ok {
+		return lottery.LotteryResult{}, nil
+	}
+
+	if ticket.Type != draw.Type {
+		return lottery.LotteryResult{}, fmt.Errorf("彩票类型 %s 与开奖号码类型 %s 不一致", ticket.Type, draw.Type)
+	}
+
+	return ticket.GetLotteryResult(draw)
+}
+
+func (m *Model) getDraw() (lottery.Lottery, bool) {
+	if m.DrawIndex < 0 || m.DrawIndex >= len(m.Draws) {
+		return lottery.Lottery{}, false
+	}
+
+	return m.Draws[m.DrawIndex], true
+}
+
+// selectDraw
+//
+// @Description 切换开奖号码并重新核对全部彩票
+//
+// @Param index int 开奖号码的序号
+func (m *Model) selectDraw(index int) {
+	if index < 0 || index >= len(m.Draws) {
+		return
+	}
+
+	m.DrawIndex = index
+
+	for i, ticket := range m.tickets {
+		result, err := m.check(ticket)
+		if err != nil {
+			m.err = err.Error()
+		}
+
+		m.results[i] = result
+	}
+}
+
+// View
+//
+// @Description 渲染界面
+//
+// @Param width int 终端宽度
+//
+// @Param height int 终端高度，超出的行不显示
+//
+// @Return []string 每一行的内容
+func (m *Model) View(width, height int) []string {
+	var (
+		lines []string
+		total struct{ cost, price, net int }
+	)
+
+	line := strings.Repeat("─", max(min(width, 80), 10))
+
+	if draw, ok := m.getDraw(); ok {
+		lines = append(lines, fmt.Sprintf("开奖期号: %d  开奖号码: %s  (%d/%d，←/→ 或 Ctrl-P/Ctrl-N 切换)", draw.Index, draw.Format(false), m.DrawIndex+1, len(m.Draws)))
+	} else {
+		lines = append(lines, "没有开奖数据")
+	}
+
+	cursor := "█"
+	if m.focus {
+		cursor = ""
+	}
+
+	lines = append(lines, "> "+string(m.input)+cursor)
+
+	switch {
+	case m.err != "":
+		lines = append(lines, "  错误: "+m.err)
+	case m.inputErr != "":
+		lines = append(lines, "  "+m.inputErr)
+	default:
+		lines = append(lines, "  "+m.inputTip)
+	}
+
+	lines = append(lines, line, fmt.Sprintf("   %3s  %s  %s  %s  %s", "#", padRight("中奖", 24), padLeft("奖金", 8), padLeft("税后", 8), "彩票"))
+
+	for i, result := range m.results {
+		ticket := m.tickets[i]
+		total.cost += ticket.GetCost()
+		total.price += result.Price
+		total.net += result.Net
+
+		mark := " "
+		if m.focus && i == m.cursor {
+			mark = ">"
+		}
+
+		fold := " "
+		if len(ticket.List) > 0 {
+			fold = "+"
+			if m.expanded[i] {
+				fold = "-"
+			}
+		}
+
+		lines = append(lines, fmt.Sprintf("%s%s %3d  %s  %8d  %8d  %s", mark, fold, i+1, padRight(result.FormatLevelCounts(), 24), result.Price, result.Net, m.formatNumbers(ticket, result)))
+
+		if !m.expanded[i] {
+			continue
+		}
+
+		for j, item := range result.List {
+			if j == maxExpandRows {
+				lines = append(lines, fmt.Sprintf("        ... 共%d注", len(result.List)))
+				break
+			}
+
+			lines = append(lines, fmt.Sprintf("        %s  %8d  %8d  %s", padRight(lottery.GetLevelLabel(item.Level), 24), item.Price, item.Net, item.Format(m.UseColor, true)))
+		}
+	}
+
+	lines = append(lines, line, fmt.Sprintf("合计: %d张，投注 %d，奖金 %d，税后 %d", len(m.tickets), total.cost, total.price, total.net))
+	lines = append(lines, "Enter 添加彩票/展开复式  Tab 切换输入框和列表  d 删除  Esc 退出")
+
+	if height > 0 && len(lines) > height {
+		lines = lines[:height]
+	}
+
+	return lines
+}
+
+// formatNumbers
+//
+// @Description 格式化彩票号码，有核对结果时标记中奖号码
+//
+// @Return string 格式化后的字符串
+func (m *Model) formatNumbers(ticket lottery.Lottery, result lottery.LotteryResult) string {
+	if len(result.Numbers) == 0 {
+		return ticket.String()
+	}
+
+	return ticket.Type + ":" + result.Format(m.UseColor, true)
+}
+
+// getWidth
+//
+// @Description 获取字符串在终端中的显示宽度，中文等宽字符按2计算
+//
+// @Param str string 字符串
+//
+// @Return int 显示宽度
+func getWidth(str string) int {
+	width := 0
+
+	for _, char := range str {
+		if char >= 0x2E80 {
+			width += 2
+		} else {
+			width++
+		}
+	}
+
+	return width
+}
+
+func padRight(str string, width int) string {
+	return str + strings.Repeat(" ", max(width-getWidth(str), 0))
+}
+
+func padLeft(str string, width int) string {
+	return strings.Repeat(" ", max(width-getWidth(str), 0)) + str
+}
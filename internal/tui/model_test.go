@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/buggy-95/lott/internal/lottery"
+)
+
+func newTestModel(t *testing.T) *Model {
+	var draws []lottery.Lottery
+
+	for _, input := range []string{"DLT:02,04,11,29,30-02,08:25053", "DLT:03,13,21,27,33-01,06:25052"} {
+		draw, err := lottery.GetLottery(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		draws = append(draws, draw)
+	}
+
+	return NewModel(draws)
+}
+
+func typeString(m *Model, input string) {
+	for _, event := range ParseKeys([]byte(input)) {
+		m.Update(event)
+	}
+}
+
+func TestModelInput(t *testing.T) {
+	m := newTestModel(t)
+
+	typeString(m, "DLT:01,02,03,04,36")
+
+	if !strings.Contains(m.inputErr, "后区最少需要2个数字") {
+		t.Errorf("应该提示后区数量错误: %s", m.inputErr)
+	}
+
+	typeString(m, "-01,02")
+
+	if !strings.Contains(m.inputErr, "前区数字范围为1~35") {
+		t.Errorf("应该提示号码范围错误: %s", m.inputErr)
+	}
+
+	typeString(m, strings.Repeat("\x7f", 8)+"35-01,02")
+
+	if m.inputErr != "" || m.inputTip != "1注" {
+		t.Errorf("校验结果错误: %s %s", m.inputErr, m.inputTip)
+	}
+
+	typeString(m, "\rDLT:02,04,11,29,30,31-02,08,09\r")
+
+	if len(m.tickets) != 2 || len(m.input) != 0 {
+		t.Fatalf("添加彩票失败: %d", len(m.tickets))
+	}
+
+	if m.results[1].Level != 1 || m.results[1].Price != 10000000+2*200000+5*3000+10*300 {
+		t.Errorf("核对结果错误: %+v", m.results[1])
+	}
+
+	typeString(m, "DLT:01\r")
+
+	if len(m.tickets) != 2 || m.err == "" {
+		t.Errorf("错误的彩票不应该添加")
+	}
+}
+
+func TestModelTable(t *testing.T) {
+	m := newTestModel(t)
+
+	typeString(m, "DLT:02,04,11,29,30-02,08\rDLT:03,13,21,27,33,35-01,06\r")
+
+	view := strings.Join(m.View(120, 0), "\n")
+
+	if !strings.Contains(view, "开奖期号: 25053") || !strings.Contains(view, "一等奖×1") || !strings.Contains(view, "合计: 2张，投注 14，奖金 10000000，税后 8000000") {
+		t.Errorf("界面错误:\n%s", view)
+	}
+
+	// 切换到列表，展开第二张彩票
+	m.Update(KeyEvent{Key: KeyTab})
+	m.Update(KeyEvent{Key: KeyDown})
+	m.Update(KeyEvent{Key: KeyEnter})
+
+	if !m.expanded[1] || len(m.View(120, 0)) != 10+6 {
+		t.Errorf("展开失败:\n%s", strings.Join(m.View(120, 0), "\n"))
+	}
+
+	// 切换到上一期重新核对
+	m.Update(KeyEvent{Key: KeyLeft})
+
+	if m.DrawIndex != 1 || m.results[0].Level != 0 || m.results[1].Level != 1 {
+		t.Errorf("切换期号失败: %d %+v", m.DrawIndex, m.results)
+	}
+
+	m.Update(KeyEvent{Key: KeyRune, Rune: 'd'})
+
+	if len(m.tickets) != 1 || m.cursor != 0 || m.expanded[0] {
+		t.Errorf("删除失败: %d %d", len(m.tickets), m.cursor)
+	}
+
+	if view := m.View(120, 3); len(view) != 3 {
+		t.Errorf("超出高度的行不应该显示: %d", len(view))
+	}
+
+	if !m.Update(KeyEvent{Key: KeyEsc}) {
+		t.Errorf("应该退出")
+	}
+}
+
+func TestParseKeys(t *testing.T) {
+	tests := []struct {
+		name   string
+		input  string
+		events []KeyEvent
+	}{
+		{"字符", "D1", []KeyEvent{{KeyRune, 'D'}, {KeyRune, '1'}}},
+		{"方向键", "\x1b[A\x1b[B\x1bOC\x1b[D", []KeyEvent{{Key: KeyUp}, {Key: KeyDown}, {Key: KeyRight}, {Key: KeyLeft}}},
+		{"删除", "\x1b[3~\x7f", []KeyEvent{{Key: KeyDelete}, {Key: KeyBackspace}}},
+		{"粘贴多行", "1\r\n2\n", []KeyEvent{{KeyRune, '1'}, {Key: KeyEnter}, {KeyRune, '2'}, {Key: KeyEnter}}},
+		{"控制键", "\t\x10\x0e\x03", []KeyEvent{{Key: KeyTab}, {Key: KeyPrevIssue}, {Key: KeyNextIssue}, {Key: KeyEsc}}},
+		{"Esc", "\x1b", []KeyEvent{{Key: KeyEsc}}},
+		{"中文", "胆", []KeyEvent{{KeyRune, '胆'}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if events := ParseKeys([]byte(tt.input)); !reflect.DeepEqual(events, tt.events) {
+				t.Errorf("期望: %v, 实际: %v", tt.events, events)
+			}
+		})
+	}
+}
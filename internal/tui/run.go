@@ -0,0 +1,154 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// 按键对应的控制字符
+const (
+	ctrlC     = 0x03
+	ctrlN     = 0x0e
+	ctrlP     = 0x10
+	tab       = '\t'
+	enter     = '\r'
+	newline   = '\n'
+	backspace = 0x7f
+	ctrlH     = 0x08
+	esc       = 0x1b
+)
+
+// 方向键和删除键的转义序列
+var escapeKeys = map[string]Key{
+	"[A":  KeyUp,
+	"[B":  KeyDown,
+	"[C":  KeyRight,
+	"[D":  KeyLeft,
+	"OA":  KeyUp,
+	"OB":  KeyDown,
+	"OC":  KeyRight,
+	"OD":  KeyLeft,
+	"[3~": KeyDelete,
+}
+
+// ParseKeys
+//
+// @Description 将一次读取的终端输入解析为按键事件，粘贴的多行内容中的换行会被解析为回车
+//
+// @Param data []byte 终端输入
+//
+// @Return []KeyEvent 按键事件
+func ParseKeys(data []byte) []KeyEvent {
+	var events []KeyEvent
+
+	for len(data) > 0 {
+		switch data[0] {
+		case ctrlC:
+			events = append(events, KeyEvent{Key: KeyEsc})
+		case ctrlN:
+			events = append(events, KeyEvent{Key: KeyNextIssue})
+		case ctrlP:
+			events = append(events, KeyEvent{Key: KeyPrevIssue})
+		case tab:
+			events = append(events, KeyEvent{Key: KeyTab})
+		case enter, newline:
+			// 粘贴 Windows 换行时忽略 \r 之后的 \n
+			if data[0] == enter && len(data) > 1 && data[1] == newline {
+				data = data[1:]
+			}
+
+			events = append(events, KeyEvent{Key: KeyEnter})
+		case backspace, ctrlH:
+			events = append(events, KeyEvent{Key: KeyBackspace})
+		case esc:
+			if len(data) == 1 {
+				events = append(events, KeyEvent{Key: KeyEsc})
+				break
+			}
+
+			matched := false
+
+			for seq, key := range escapeKeys {
+				if strings.HasPrefix(string(data[1:]), seq) {
+					events = append(events, KeyEvent{Key: key})
+					data = data[len(seq):]
+					matched = true
+
+					break
+				}
+			}
+
+			if !matched {
+				events = append(events, KeyEvent{Key: KeyEsc})
+			}
+		default:
+			char, size := utf8.DecodeRune(data)
+
+			if char >= ' ' && char != utf8.RuneError {
+				events = append(events, KeyEvent{Key: KeyRune, Rune: char})
+			}
+
+			data = data[size:]
+
+			continue
+		}
+
+		data = data[1:]
+	}
+
+	return events
+}
+
+// render
+//
+// @Description 清屏并输出界面
+//
+// @Param w io.Writer 输出
+//
+// @Param lines []string 每一行的内容
+func render(w io.Writer, lines []string) {
+	// 原始模式下换行不会回到行首，需要输出 \r\n
+	fmt.Fprint(w, "\x1b[H\x1b[2J"+strings.Join(lines, "\x1b[K\r\n"))
+}
+
+// Run
+//
+// @Description 在终端中运行交互界面，直到按下 Esc 或 Ctrl-C
+//
+// @Param model *Model 界面状态
+//
+// @Return error 错误信息
+func Run(model *Model) error {
+	fd := int(os.Stdin.Fd())
+
+	state, err := makeRaw(fd)
+	if err != nil {
+		return fmt.Errorf("终端切换失败: %w", err)
+	}
+	defer restore(fd, state)
+
+	// 隐藏光标，退出时恢复
+	fmt.Fprint(os.Stdout, "\x1b[?25l")
+	defer fmt.Fprint(os.Stdout, "\x1b[?25h\x1b[H\x1b[2J")
+
+	buf := make([]byte, 4096)
+
+	for {
+		width, height := getSize(fd)
+		render(os.Stdout, model.View(width, height))
+
+		n, err := os.Stdin.Read(buf)
+		if err != nil {
+			return err
+		}
+
+		for _, event := range ParseKeys(buf[:n]) {
+			if model.Update(event) {
+				return nil
+			}
+		}
+	}
+}
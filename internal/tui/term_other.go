@@ -0,0 +1,19 @@
+//go:build !(linux || darwin || freebsd || netbsd || openbsd || dragonfly)
+
+package tui
+
+import "errors"
+
+type termState struct{}
+
+func makeRaw(fd int) (*termState, error) {
+	return nil, errors.New("当前系统不支持终端界面")
+}
+
+func restore(fd int, state *termState) error {
+	return nil
+}
+
+func getSize(fd int) (int, int) {
+	return 80, 24
+}
@@ -0,0 +1,75 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package tui
+
+import (
+	"golang.org/x/sys/unix"
+)
+
+// 终端的原始状态，用于退出时恢复
+type termState struct {
+	termios unix.Termios
+}
+
+// makeRaw
+//
+// @Description 将终端切换为原始模式，逐个读取按键且不回显
+//
+// @Param fd int 终端的文件描述符
+//
+// @Return *termState 终端的原始状态
+//
+// @Return error 错误信息
+func makeRaw(fd int) (*termState, error) {
+	termios, err := unix.IoctlGetTermios(fd, ioctlGetTermios)
+	if err != nil {
+		return nil, err
+	}
+
+	state := &termState{*termios}
+
+	termios.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	termios.Oflag &^= unix.OPOST
+	termios.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	termios.Cflag &^= unix.CSIZE | unix.PARENB
+	termios.Cflag |= unix.CS8
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+
+	if err := unix.IoctlSetTermios(fd, ioctlSetTermios, termios); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// restore
+//
+// @Description 恢复终端的原始状态
+//
+// @Param fd int 终端的文件描述符
+//
+// @Param state *termState 终端的原始状态
+//
+// @Return error 错误信息
+func restore(fd int, state *termState) error {
+	return unix.IoctlSetTermios(fd, ioctlSetTermios, &state.termios)
+}
+
+// getSize
+//
+// @Description 获取终端的宽度和高度
+//
+// @Param fd int 终端的文件描述符
+//
+// @Return int 宽度
+//
+// @Return int 高度
+func getSize(fd int) (int, int) {
+	size, err := unix.IoctlGetWinsize(fd, unix.TIOCGWINSZ)
+	if err != nil {
+		return 80, 24
+	}
+
+	return int(size.Col), int(size.Row)
+}
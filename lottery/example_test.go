@@ -0,0 +1,117 @@
+package lottery_test
+
+import (
+	"fmt"
+
+	"github.com/buggy-95/lott/lottery"
+)
+
+func ExampleParse() {
+	lott, err := lottery.Parse("DLT:01,02~03,04,05,06-07,08x3:25053")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(lott.Type, lott.Index, lott.Scale, lott.FrontDan, lott.FrontTuo, lott.GetBetCount(), lott.GetCost())
+
+	_, err = lottery.Parse("DLT:01,02,03,04,36-07,08")
+	fmt.Println(err)
+	// Output:
+	// DLT 25053 3 [1 2] [3 4 5 6] 4 24
+	// 前区数字范围为1~35
+}
+
+func ExampleExpand() {
+	lott, _ := lottery.Parse("DLT:01,02,03,04,05,06-07,08")
+
+	for _, single := range lottery.Expand(lott) {
+		fmt.Println(lottery.Format(single))
+	}
+	// Output:
+	// DLT:01,02,03,04,05-07,08
+	// DLT:01,02,03,04,06-07,08
+	// DLT:01,02,03,05,06-07,08
+	// DLT:01,02,04,05,06-07,08
+	// DLT:01,03,04,05,06-07,08
+	// DLT:02,03,04,05,06-07,08
+}
+
+func ExampleCheck() {
+	ticket, _ := lottery.Parse("DLT:01,02,03,04,05,06-01,02,03x2")
+	draw, _ := lottery.ParseDraw("DLT:01,02,03,04,05-01,02:25053")
+
+	result, err := lottery.Check(ticket, draw)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println(lottery.GetLevelLabel(result.Level), result.FormatLevelCounts())
+	fmt.Println(result.Price, result.Tax, result.Net)
+	fmt.Println(lottery.FormatResult(result, false))
+	// Output:
+	// 一等奖 一等奖×2, 二等奖×4, 四等奖×10, 五等奖×20
+	// 20836000 4160000 16676000
+	// 01,02,03,04,05,06-01,02,03x2	一等奖×2, 二等奖×4, 四等奖×10, 五等奖×20	合计奖金: 20836000	税后: 16676000
+}
+
+func ExampleGetPrizeRule() {
+	for _, index := range []int{18001, 25053} {
+		rule, _ := lottery.GetPrizeRule("DLT", index)
+		level, price := rule.GetLevel(4, 2)
+
+		fmt.Println(index, len(rule.Levels), lottery.GetLevelLabel(level), price)
+	}
+	// Output:
+	// 18001 6 三等奖 10000
+	// 25053 9 四等奖 3000
+}
+
+func ExampleLotteryResult_ApplyTax() {
+	ticket, _ := lottery.Parse("DLT:01,02,03,04,06-01,02x2")
+	draw, _ := lottery.ParseDraw("DLT:01,02,03,04,05-01,02")
+
+	result, _ := lottery.Check(ticket, draw)
+	fmt.Println(result.Price, result.Tax, result.Net)
+
+	result.ApplyTax(lottery.TaxRule{Threshold: 1000, Rate: 0.1})
+	fmt.Println(result.Price, result.Tax, result.Net)
+	// Output:
+	// 6000 0 6000
+	// 6000 600 5400
+}
+
+func ExampleGetErrorKind() {
+	_, err := lottery.Parse("DLT:01,02,03,04,36-01,02")
+
+	fmt.Println(lottery.GetErrorKind(err) == lottery.ErrNumberRange)
+	fmt.Println(lottery.LocalizeError(err, lottery.LocaleZhCN))
+	fmt.Println(lottery.LocalizeError(err, lottery.LocaleEn))
+	fmt.Println(lottery.GetLocaleLevelLabel(lottery.LocaleEn, 1))
+	// Output:
+	// true
+	// 前区数字范围为1~35
+	// front numbers must be between 1 and 35
+	// 1st prize
+}
+
+func ExampleCheck_numbers() {
+	ticket, _ := lottery.Parse("QLC:01,02,03,04,05,06,08")
+	draw, _ := lottery.ParseDraw("QLC:01,02,03,04,05,06,07-08:2025100")
+
+	result, _ := lottery.Check(ticket, draw)
+
+	for _, num := range result.Numbers {
+		fmt.Println(num.Num, num.Bingo, num.Special)
+	}
+
+	fmt.Println(result.Levels)
+	// Output:
+	// 1 true false
+	// 2 true false
+	// 3 true false
+	// 4 true false
+	// 5 true false
+	// 6 true false
+	// 8 false true
+	// [{2 1 20000}]
+}
@@ -0,0 +1,576 @@
+// Package lottery 提供彩票字符串的解析、校验、展开、核对和格式化，供其他 Go 模块引用。
+//
+// 彩票字符串的格式为: 类型:前区-后区[x倍数][:期号]，胆码与拖码之间用 ~ 分隔，例如:
+//
+//	DLT:01,02,03,04,05-06,07
+//	DLT:01,02~03,04,05,06-07,08x3:25053
+//	SSQ:01,02,03,04,05,06,07-16
+package lottery
+
+import (
+	"slices"
+
+	impl "github.com/buggy-95/lott/internal/lottery"
+)
+
+// 单注价格 (元)
+const BetPrice = impl.BetPrice
+
+// 彩票，单式、复式和胆拖票使用相同的结构，通常由 Parse 创建
+type Lottery struct {
+	Type     string // 彩票类型 (DLT: 大乐透, SSQ: 双色球, QLC: 七乐彩)
+	Index    int    // 期号，为0时未指定
+	Scale    int    // 倍投倍数
+	FrontDan []int  // 前区胆码
+	FrontTuo []int  // 前区拖码，单式和复式票的前区号码都在拖码中
+	BackDan  []int  // 后区胆码
+	BackTuo  []int  // 后区拖码，有特别号的开奖号码的特别号也在后区拖码中
+}
+
+// 彩票开奖结果，由 Check 创建
+type LotteryResult struct {
+	Ticket       Lottery      // 核对的彩票
+	FrontMatched int          // 前区命中个数
+	BackMatched  int          // 后区命中个数，有特别号的彩票为选中特别号的个数
+	Level        int          // 最高中奖等级，未中奖时为0
+	Price        int          // 税前奖金，包含倍投
+	Tax          int          // 个人所得税
+	Net          int          // 税后奖金
+	Numbers      []ResultNum  // 彩票号码的命中情况，顺序与彩票号码一致
+	Levels       []LevelCount // 各中奖等级的中奖注数和奖金小计，按等级从高到低排列
+
+	result impl.LotteryResult
+}
+
+// 彩票号码的命中情况
+type ResultNum struct {
+	Num     int    // 号码
+	Type    string // 号码类型 (FrontDan: 前区胆码, FrontTuo: 前区拖码, BackDan: 后区胆码, BackTuo: 后区拖码)
+	Bingo   bool   // 是否命中开奖号码
+	Special bool   // 是否选中开奖号码的特别号
+}
+
+// 各中奖等级的中奖注数和奖金小计
+type LevelCount struct {
+	Level int // 中奖等级
+	Count int // 中奖注数，包含倍投
+	Price int // 税前奖金小计
+}
+
+// 彩票玩法规则，描述前区和后区的号码范围以及单式票的号码数量
+type GameRule struct {
+	Type      string // 彩票类型
+	Name      string // 彩票名称
+	FrontMin  int    // 前区最小号码
+	FrontMax  int    // 前区最大号码
+	FrontSize int    // 单式票前区号码数量
+	BackMin   int    // 后区最小号码
+	BackMax   int    // 后区最大号码
+	BackSize  int    // 单式票后区号码数量，没有后区时为0
+	Special   int    // 开奖号码的特别号数量
+}
+
+// 奖级规则，在期号范围 [From, To] 内生效
+type PrizeRule struct {
+	Type   string       // 彩票类型
+	Name   string       // 规则名称
+	From   int          // 生效的起始期号
+	To     int          // 生效的结束期号，为0时一直生效
+	Levels []PrizeLevel // 中奖等级，按等级从高到低排列
+}
+
+// 中奖等级，Matches 为该等级对应的 [前区命中个数, 后区命中个数] 组合
+type PrizeLevel struct {
+	Level    int      // 中奖等级
+	Matches  [][2]int // 命中组合
+	Price    int      // 单注奖金，浮动奖为参考金额
+	Floating bool     // 是否为浮动奖
+	AddPrice int      // 追加投注的单注奖金，为0时该等级没有追加奖金
+}
+
+// 个人所得税规则，单注奖金超过起征额时按全额计税
+type TaxRule struct {
+	Threshold int     // 起征额 (元)，单注奖金大于该金额时计税
+	Rate      float64 // 税率
+}
+
+// 语言
+type Locale string
+
+// 错误类型，不随语言变化
+type ErrorKind string
+
+// 支持的语言
+const (
+	LocaleZhCN = Locale(impl.LocaleZhCN) // 简体中文，默认语言
+	LocaleEn   = Locale(impl.LocaleEn)   // 英文
+)
+
+// 错误类型
+const (
+	ErrSyntax          = ErrorKind(impl.ErrSyntax)          // 彩票字符串格式错误
+	ErrUnknownType     = ErrorKind(impl.ErrUnknownType)     // 不支持的彩票类型
+	ErrInvalidNumber   = ErrorKind(impl.ErrInvalidNumber)   // 号码格式错误
+	ErrInvalidScale    = ErrorKind(impl.ErrInvalidScale)    // 倍投倍数错误
+	ErrInvalidIndex    = ErrorKind(impl.ErrInvalidIndex)    // 期号错误
+	ErrDuplicateNumber = ErrorKind(impl.ErrDuplicateNumber) // 号码重复
+	ErrDanTuoConflict  = ErrorKind(impl.ErrDanTuoConflict)  // 拖码与胆码重复
+	ErrDanCount        = ErrorKind(impl.ErrDanCount)        // 胆码数量错误
+	ErrNumberCount     = ErrorKind(impl.ErrNumberCount)     // 号码数量错误
+	ErrNumberRange     = ErrorKind(impl.ErrNumberRange)     // 号码超出范围
+	ErrNotSingle       = ErrorKind(impl.ErrNotSingle)       // 不是单式票
+	ErrUnknownIssue    = ErrorKind(impl.ErrUnknownIssue)    // 没有收录期号的奖级规则
+)
+
+// newLottery
+//
+// @Description 将内部的彩票结构转换为公开的彩票，号码列表会被复制
+//
+// @Param lott impl.Lottery 内部的彩票结构
+//
+// @Return Lottery 彩票
+func newLottery(lott impl.Lottery) Lottery {
+	return Lottery{
+		Type:     lott.Type,
+		Index:    lott.Index,
+		Scale:    lott.Scale,
+		FrontDan: slices.Clone(lott.FrontDan),
+		FrontTuo: slices.Clone(lott.FrontTuo),
+		BackDan:  slices.Clone(lott.BackDan),
+		BackTuo:  slices.Clone(lott.BackTuo),
+	}
+}
+
+// toImpl
+//
+// @Description 将彩票转换为内部的彩票结构，复式票和胆拖票会展开单式票列表
+//
+// @Return impl.Lottery 内部的彩票结构
+//
+// @Return error 错误信息
+func (lott Lottery) toImpl() (impl.Lottery, error) {
+	return impl.NewLottery(impl.LotteryParts{
+		LotteryBaseInfo: impl.LotteryBaseInfo{Type: lott.Type, Index: lott.Index, Scale: lott.Scale},
+		FrontDan:        slices.Clone(lott.FrontDan),
+		FrontTuo:        slices.Clone(lott.FrontTuo),
+		BackDan:         slices.Clone(lott.BackDan),
+		BackTuo:         slices.Clone(lott.BackTuo),
+	})
+}
+
+// newLotteryResult
+//
+// @Description 将内部的开奖结果转换为公开的开奖结果
+//
+// @Param ticket Lottery 核对的彩票
+//
+// @Param result impl.LotteryResult 内部的开奖结果
+//
+// @Return LotteryResult 开奖结果
+func newLotteryResult(ticket Lottery, result impl.LotteryResult) LotteryResult {
+	lottResult := LotteryResult{
+		Ticket:       ticket,
+		FrontMatched: result.FrontMatched,
+		BackMatched:  result.BackMatched,
+		Level:        result.Level,
+		Price:        result.Price,
+		Tax:          result.Tax,
+		Net:          result.Net,
+		result:       result,
+	}
+
+	for _, num := range result.Numbers {
+		lottResult.Numbers = append(lottResult.Numbers, ResultNum{Num: num.Num, Type: num.Type, Bingo: num.Bingo, Special: num.Special})
+	}
+
+	for _, count := range result.GetLevelCounts() {
+		lottResult.Levels = append(lottResult.Levels, LevelCount{Level: count.Level, Count: count.Count, Price: count.Price})
+	}
+
+	return lottResult
+}
+
+// GetBetCount
+//
+// @Description 获取彩票包含的单式注数，不包含倍投，号码无法组成单式票时为0
+//
+// @Return int 注数
+func (lott Lottery) GetBetCount() int {
+	implLott, err := lott.toImpl()
+	if err != nil {
+		return 0
+	}
+
+	return implLott.GetBetCount()
+}
+
+// GetCost
+//
+// @Description 获取彩票的投注金额，注数 × 倍投倍数 × 单注价格
+//
+// @Return int 投注金额
+func (lott Lottery) GetCost() int {
+	return lott.GetBetCount() * max(lott.Scale, 1) * BetPrice
+}
+
+// String
+//
+// @Description 获取彩票的完整字符串，与 Format 相同
+//
+// @Return string 彩票字符串
+func (lott Lottery) String() string {
+	return Format(lott)
+}
+
+// FormatLevelCounts
+//
+// @Description 使用当前语言格式化各中奖等级的中奖注数，例如: 二等奖×1, 五等奖×4，未中奖时为: 无
+//
+// @Return string 格式化后的字符串
+func (result LotteryResult) FormatLevelCounts() string {
+	return result.result.FormatLevelCounts()
+}
+
+// ApplyTax
+//
+// @Description 按个人所得税规则重新计算税额和税后奖金，税额按单注计算，倍投时每一倍单独计税
+//
+// @Param rule TaxRule 个人所得税规则
+func (result *LotteryResult) ApplyTax(rule TaxRule) {
+	result.result.ApplyTax(impl.TaxRule{Threshold: rule.Threshold, Rate: rule.Rate})
+	result.Tax = result.result.Tax
+	result.Net = result.result.Net
+}
+
+// GetLevel
+//
+// @Description 根据前区和后区的命中个数获取中奖等级和单注奖金
+//
+// @Param frontMatched int 前区命中个数
+//
+// @Param backMatched int 后区命中个数
+//
+// @Return int 中奖等级，未中奖时为0
+//
+// @Return int 单注奖金，未中奖时为0
+func (rule PrizeRule) GetLevel(frontMatched, backMatched int) (int, int) {
+	for _, level := range rule.Levels {
+		for _, match := range level.Matches {
+			if match[0] == frontMatched && match[1] == backMatched {
+				return level.Level, level.Price
+			}
+		}
+	}
+
+	return 0, 0
+}
+
+// Parse
+//
+// @Description 解析彩票字符串，并按玩法规则校验号码范围、数量和重复
+//
+// @Param input string 彩票字符串，例如: DLT:01,02~03,04,05,06-07,08x3:25053
+//
+// @Return Lottery 彩票
+//
+// @Return error 错误信息
+func Parse(input string) (Lottery, error) {
+	lott, err := impl.GetLottery(input)
+	if err != nil {
+		return Lottery{}, err
+	}
+
+	result := newLottery(lott)
+
+	if err := Validate(result); err != nil {
+		return Lottery{}, err
+	}
+
+	return result, nil
+}
+
+// Validate
+//
+// @Description 按玩法规则校验彩票
+//
+// @Param lott Lottery 彩票
+//
+// @Return error 错误信息
+func Validate(lott Lottery) error {
+	rule, err := impl.GetGameRule(lott.Type)
+	if err != nil {
+		return err
+	}
+
+	implLott, err := lott.toImpl()
+	if err != nil {
+		return err
+	}
+
+	return rule.Check(implLott.LotteryParts)
+}
+
+// ParseDraw
+//
+// @Description 解析开奖号码，开奖号码必须是单式票
+//
+// @Param input string 开奖号码字符串，例如: DLT:02,04,11,29,30-02,08:25053
+//
+// @Return Lottery 开奖号码
+//
+// @Return error 错误信息
+func ParseDraw(input string) (Lottery, error) {
+	lott, err := impl.GetLottery(input)
+	if err != nil {
+		return Lottery{}, err
+	}
+
+	rule, err := impl.GetGameRule(lott.Type)
+	if err != nil {
+		return Lottery{}, err
+	}
+
+	if err := rule.CheckDraw(lott.LotteryParts); err != nil {
+		return Lottery{}, err
+	}
+
+	return newLottery(lott), nil
+}
+
+// Expand
+//
+// @Description 将彩票展开为单式票列表，单式票返回只包含自身的列表
+//
+// @Param lott Lottery 彩票
+//
+// @Return []Lottery 单式票列表，号码无法组成单式票时为空
+func Expand(lott Lottery) []Lottery {
+	implLott, err := lott.toImpl()
+	if err != nil {
+		return nil
+	}
+
+	if implLott.IsSingleLottery() {
+		return []Lottery{newLottery(implLott)}
+	}
+
+	result := make([]Lottery, 0, len(implLott.List))
+
+	for _, single := range implLott.List {
+		result = append(result, newLottery(single))
+	}
+
+	return result
+}
+
+// Check
+//
+// @Description 用开奖号码核对彩票，奖级规则根据开奖号码或彩票的期号自动选择
+//
+// @Param ticket Lottery 彩票
+//
+// @Param draw Lottery 开奖号码
+//
+// @Return LotteryResult 开奖结果，包含税前奖金、税额、税后奖金和各等级的中奖注数
+//
+// @Return error 错误信息
+func Check(ticket, draw Lottery) (LotteryResult, error) {
+	implTicket, err := ticket.toImpl()
+	if err != nil {
+		return LotteryResult{}, err
+	}
+
+	implDraw, err := draw.toImpl()
+	if err != nil {
+		return LotteryResult{}, err
+	}
+
+	result, err := implTicket.GetLotteryResult(implDraw)
+	if err != nil {
+		return LotteryResult{}, err
+	}
+
+	return newLotteryResult(ticket, result), nil
+}
+
+// Format
+//
+// @Description 将彩票格式化为可以被 Parse 重新解析的字符串
+//
+// @Param lott Lottery 彩票
+//
+// @Return string 彩票字符串
+func Format(lott Lottery) string {
+	implLott := impl.Lottery{LotteryParts: impl.LotteryParts{
+		LotteryBaseInfo: impl.LotteryBaseInfo{Type: lott.Type, Index: lott.Index, Scale: lott.Scale},
+		FrontDan:        lott.FrontDan,
+		FrontTuo:        lott.FrontTuo,
+		BackDan:         lott.BackDan,
+		BackTuo:         lott.BackTuo,
+	}}
+
+	return implLott.String()
+}
+
+// FormatResult
+//
+// @Description 格式化开奖结果，例如: 01,02,03,04,05,06-01,02,03x2	一等奖×2, 二等奖×4	合计奖金: 20800000
+//
+// @Param result LotteryResult 开奖结果
+//
+// @Param useColor bool 是否用颜色标记中奖号码
+//
+// @Return string 格式化后的字符串
+func FormatResult(result LotteryResult, useColor bool) string {
+	return result.result.FormatResult(useColor, true)
+}
+
+// GetGameRule
+//
+// @Description 获取彩票类型对应的玩法规则
+//
+// @Param lotteryType string 彩票类型 (DLT: 大乐透, SSQ: 双色球, QLC: 七乐彩)
+//
+// @Return GameRule 玩法规则
+//
+// @Return error 错误信息
+func GetGameRule(lotteryType string) (GameRule, error) {
+	rule, err := impl.GetGameRule(lotteryType)
+	if err != nil {
+		return GameRule{}, err
+	}
+
+	return GameRule{
+		Type:      rule.Type,
+		Name:      rule.Name,
+		FrontMin:  rule.FrontMin,
+		FrontMax:  rule.FrontMax,
+		FrontSize: rule.FrontSize,
+		BackMin:   rule.BackMin,
+		BackMax:   rule.BackMax,
+		BackSize:  rule.BackSize,
+		Special:   rule.Special,
+	}, nil
+}
+
+// GetPrizeRule
+//
+// @Description 获取期号对应的奖级规则
+//
+// @Param lotteryType string 彩票类型
+//
+// @Param index int 期号，为0时使用最新的规则
+//
+// @Return PrizeRule 奖级规则
+//
+// @Return error 错误信息
+func GetPrizeRule(lotteryType string, index int) (PrizeRule, error) {
+	rule, err := impl.GetPrizeRule(lotteryType, index)
+	if err != nil {
+		return PrizeRule{}, err
+	}
+
+	result := PrizeRule{Type: rule.Type, Name: rule.Name, From: rule.From, To: rule.To}
+
+	for _, level := range rule.Levels {
+		result.Levels = append(result.Levels, PrizeLevel{
+			Level:    level.Level,
+			Matches:  slices.Clone(level.Matches),
+			Price:    level.Price,
+			Floating: level.Floating,
+			AddPrice: level.AddPrice,
+		})
+	}
+
+	return result, nil
+}
+
+// GetLevelLabel
+//
+// @Description 使用当前语言获取中奖等级的名称，例如: 一等奖，未中奖时为: 无
+//
+// @Param level int 中奖等级
+//
+// @Return string 中奖等级名称
+func GetLevelLabel(level int) string {
+	return impl.GetLevelLabel(level)
+}
+
+// GetDefaultTaxRule
+//
+// @Description 获取默认的个人所得税规则，Check 返回的结果使用该规则计税，可以通过 LotteryResult.ApplyTax 按其他规则重新计算
+//
+// @Return TaxRule 个人所得税规则
+func GetDefaultTaxRule() TaxRule {
+	return TaxRule{Threshold: impl.DefaultTaxRule.Threshold, Rate: impl.DefaultTaxRule.Rate}
+}
+
+// SetLocale
+//
+// @Description 设置当前语言，影响中奖等级名称、结果标签和错误信息，默认为简体中文
+//
+// @Param locale Locale 语言
+func SetLocale(locale Locale) {
+	impl.SetLocale(impl.Locale(locale))
+}
+
+// ParseLocale
+//
+// @Description 解析语言名称，支持 zh-CN、en_US.UTF-8 等格式
+//
+// @Param name string 语言名称
+//
+// @Return Locale 语言
+//
+// @Return bool 是否是支持的语言
+func ParseLocale(name string) (Locale, bool) {
+	locale, ok := impl.ParseLocale(name)
+
+	return Locale(locale), ok
+}
+
+// GetEnvLocale
+//
+// @Description 按 LC_ALL、LC_MESSAGES、LANG 环境变量获取语言
+//
+// @Return Locale 语言
+func GetEnvLocale() Locale {
+	return Locale(impl.GetEnvLocale())
+}
+
+// GetLocaleLevelLabel
+//
+// @Description 使用指定语言获取中奖等级的名称
+//
+// @Param locale Locale 语言
+//
+// @Param level int 中奖等级
+//
+// @Return string 中奖等级名称
+func GetLocaleLevelLabel(locale Locale, level int) string {
+	return impl.GetLocaleLevelLabel(impl.Locale(locale), level)
+}
+
+// GetErrorKind
+//
+// @Description 获取解析和校验错误的错误类型，用于程序判断错误原因，不随语言变化
+//
+// @Param err error 错误
+//
+// @Return ErrorKind 错误类型，不是本包的错误时为空
+func GetErrorKind(err error) ErrorKind {
+	return ErrorKind(impl.GetErrorKind(err))
+}
+
+// LocalizeError
+//
+// @Description 使用指定语言格式化错误信息，不影响当前语言
+//
+// @Param err error 错误
+//
+// @Param locale Locale 语言
+//
+// @Return string 错误信息
+func LocalizeError(err error, locale Locale) string {
+	return impl.LocalizeError(err, impl.Locale(locale))
+}